@@ -7,6 +7,9 @@
 //
 //	backtest -input history.csv -model sarima -sarima-s=24 \
 //	  -step=1m -horizon=30m -window=6h -target-per-pod=50
+//
+// With -replica-hour-cost and -underprovision-penalty it also prices the replicas of
+// the deterministic and cost planners against the actual load.
 package main
 
 import (
@@ -47,12 +50,17 @@ func main() {
 	byomURL := flag.String("byom-url", "", "BYOM service URL (required when model=byom)")
 
 	targetPerPod := flag.Float64("target-per-pod", 100.0, "Target metric value per pod")
+	capacityCurve := flag.String("capacity-curve", "", "Load per replica count replacing --target-per-pod: replicas:load pairs, usl:lambda,sigma,kappa, or usl: with pairs to fit")
 	headroom := flag.Float64("headroom", 1.2, "Headroom multiplier")
 	quantileLevel := flag.String("quantile-level", "0", "Quantile level (p90, 0.95, or 0 to disable)")
 	minReplicas := flag.Int("min", 1, "Minimum replicas")
 	maxReplicas := flag.Int("max", 100, "Maximum replicas")
 	upMaxFactor := flag.Float64("up-max-factor", 2.0, "Max scale-up factor per step")
 	downMaxPercent := flag.Int("down-max-percent", 50, "Max scale-down percent per step")
+	podStartup := durationx.Duration("pod-startup-time", 0, "Pod readiness delay, planned ahead for and applied when scoring")
+	planner := flag.String("planner", "deterministic", "Planner behind the capacity outcome: deterministic or cost")
+	replicaHourCost := flag.Float64("replica-hour-cost", 0, "Cost of one replica-hour; with a penalty, reports the cost of both planners")
+	underProvisionPenalty := flag.Float64("underprovision-penalty", 0, "Cost of one pod-hour of unserved demand")
 
 	flag.Parse()
 
@@ -63,11 +71,26 @@ func main() {
 		fail("--byom-url is required when --model=byom")
 	}
 
+	if *planner != "deterministic" && *planner != "cost" {
+		fail(fmt.Sprintf("invalid planner %q (want deterministic or cost)", *planner))
+	}
+	if *planner == "cost" && (*replicaHourCost <= 0 || *underProvisionPenalty <= 0) {
+		fail("--replica-hour-cost and --underprovision-penalty must be > 0 when --planner=cost")
+	}
+
 	quantile, err := capacity.ParseQuantileLevel(*quantileLevel)
 	if err != nil {
 		fail(fmt.Sprintf("invalid quantile level: %v", err))
 	}
 
+	var curve *capacity.Curve
+	if *capacityCurve != "" {
+		curve, err = capacity.ParseCurve(*capacityCurve)
+		if err != nil {
+			fail(fmt.Sprintf("invalid capacity curve: %v", err))
+		}
+	}
+
 	newModel, err := modelFactory(*model, *metric, stepSec, horizonSec, modelParams{
 		arimaP: *arimaP, arimaD: *arimaD, arimaQ: *arimaQ,
 		sarimaP: *sarimaP, sarimaD: *sarimaD, sarimaQ: *sarimaQ,
@@ -91,12 +114,17 @@ func main() {
 		NewModel: newModel,
 		Policy: capacity.Policy{
 			TargetPerPod:          *targetPerPod,
+			Curve:                 curve,
 			Headroom:              *headroom,
 			QuantileLevel:         quantile,
 			MinReplicas:           *minReplicas,
 			MaxReplicas:           *maxReplicas,
 			UpMaxFactorPerStep:    *upMaxFactor,
 			DownMaxPercentPerStep: *downMaxPercent,
+			PodStartupSeconds:     int(podStartup.Seconds()),
+			Planner:               *planner,
+			ReplicaHourCost:       *replicaHourCost,
+			UnderProvisionPenalty: *underProvisionPenalty,
 		},
 	}
 
@@ -168,6 +196,13 @@ func printText(r backtest.Report) {
 	fmt.Printf("  capacity outcome:\n")
 	fmt.Printf("    under-provisioned:    %.2f%% of steps\n", r.UnderProvisionedRate*100)
 	fmt.Printf("    mean over-provision:  %.2f%%\n", r.MeanOverProvision*100)
+	if len(r.Planners) > 0 {
+		fmt.Printf("  planner cost:\n")
+		for _, p := range r.Planners {
+			fmt.Printf("    %-14s        %.2f (%.2f replica-hours, %.2f pod-hours short, %.2f%% of steps under)\n",
+				p.Planner+":", p.Cost, p.ReplicaHours, p.ShortfallPodHours, p.UnderProvisionedRate*100)
+		}
+	}
 }
 
 func fail(message string) {
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/HatiCode/kedastral/cmd/forecaster/controller"
+	"github.com/HatiCode/kedastral/pkg/capacity"
+)
+
+// budgetCoordinator shares replica budgets between the workload forecasters of the
+// process. Each member claims its latest plan on every tick and receives, per step,
+// its share of every budget it belongs to given the other members' latest claims.
+// Safe for concurrent use.
+type budgetCoordinator struct {
+	mu      sync.Mutex
+	budgets map[string]controller.Budget
+	claims  map[string]workloadClaim
+}
+
+// workloadClaim is a member's latest plan, before any budget was applied.
+type workloadClaim struct {
+	start    time.Time
+	step     time.Duration
+	priority int
+	requests []int
+	floors   []int
+}
+
+func newBudgetCoordinator() *budgetCoordinator {
+	return &budgetCoordinator{
+		budgets: make(map[string]controller.Budget),
+		claims:  make(map[string]workloadClaim),
+	}
+}
+
+// at returns the claim at time t: the step covering t, the first step before the
+// plan starts, or the last after it ends. Members always keep their floor, and one
+// replica while they request any.
+func (c workloadClaim) at(t time.Time) capacity.BudgetClaim {
+	i := min(max(int(t.Sub(c.start)/c.step), 0), len(c.requests)-1)
+	return capacity.BudgetClaim{
+		Request:  c.requests[i],
+		Floor:    max(c.floors[i], min(c.requests[i], 1)),
+		Priority: c.priority,
+	}
+}
+
+func (bc *budgetCoordinator) SetBudget(name string, budget controller.Budget) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.budgets[name] = budget
+}
+
+func (bc *budgetCoordinator) RemoveBudget(name string) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	delete(bc.budgets, name)
+}
+
+// remove forgets the claim of a workload that stopped forecasting.
+func (bc *budgetCoordinator) remove(workload string) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	delete(bc.claims, workload)
+}
+
+// claim records a workload's plan and returns its allowance at each step: the
+// smallest share of the budgets it belongs to, or 0 where no budget applies. It
+// returns nil when the workload belongs to no budget.
+func (bc *budgetCoordinator) claim(workload string, claim workloadClaim) []int {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	if len(claim.requests) == 0 {
+		delete(bc.claims, workload)
+		return nil
+	}
+	bc.claims[workload] = claim
+
+	var caps []int
+	for _, budget := range bc.budgets {
+		if !slices.Contains(budget.Members, workload) {
+			continue
+		}
+		if caps == nil {
+			caps = make([]int, len(claim.requests))
+		}
+		for i := range caps {
+			members, claims := bc.claimsAt(budget, claim.start.Add(time.Duration(i)*claim.step))
+			share := capacity.AllocateBudget(budget.MaxReplicas, claims, budget.Strategy)[slices.Index(members, workload)]
+			if claim.requests[i] > 0 && (caps[i] == 0 || share < caps[i]) {
+				caps[i] = share
+			}
+		}
+	}
+	return caps
+}
+
+// BudgetUsage totals the members' requests and allocations on the grid of the
+// finest member step, from now to the end of the longest plan.
+func (bc *budgetCoordinator) BudgetUsage(name string, now time.Time) controller.BudgetUsage {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	budget, ok := bc.budgets[name]
+	if !ok {
+		return controller.BudgetUsage{}
+	}
+
+	var step time.Duration
+	var end time.Time
+	for _, member := range budget.Members {
+		claim, ok := bc.claims[member]
+		if !ok {
+			continue
+		}
+		if step == 0 || claim.step < step {
+			step = claim.step
+		}
+		if planEnd := claim.start.Add(time.Duration(len(claim.requests)) * claim.step); planEnd.After(end) {
+			end = planEnd
+		}
+	}
+	if step == 0 {
+		return controller.BudgetUsage{}
+	}
+
+	usage := controller.BudgetUsage{Start: now.Truncate(step), StepSeconds: int(step.Seconds())}
+	for t := usage.Start; t.Before(end); t = t.Add(step) {
+		_, claims := bc.claimsAt(budget, t)
+		requested, allocated := 0, 0
+		for i, share := range capacity.AllocateBudget(budget.MaxReplicas, claims, budget.Strategy) {
+			requested += claims[i].Request
+			allocated += share
+		}
+		usage.Requested = append(usage.Requested, requested)
+		usage.Allocated = append(usage.Allocated, allocated)
+	}
+	return usage
+}
+
+// claimsAt returns the members of budget that have claimed a plan, with their
+// claims at time t.
+func (bc *budgetCoordinator) claimsAt(budget controller.Budget, t time.Time) ([]string, []capacity.BudgetClaim) {
+	var members []string
+	var claims []capacity.BudgetClaim
+	for _, member := range budget.Members {
+		if claim, ok := bc.claims[member]; ok {
+			members = append(members, member)
+			claims = append(claims, claim.at(t))
+		}
+	}
+	return members, claims
+}
+
+// constrained reports whether caps hold any step below the planned replicas.
+func constrained(caps, replicas []int) bool {
+	for i, c := range caps {
+		if c > 0 && c < replicas[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// withBudget returns limits extended to cover caps, with each step's budget cap set.
+func withBudget(limits []capacity.StepLimit, caps []int) []capacity.StepLimit {
+	budgeted := make([]capacity.StepLimit, max(len(limits), len(caps)))
+	copy(budgeted, limits)
+	for i, c := range caps {
+		budgeted[i].BudgetReplicas = c
+	}
+	return budgeted
+}
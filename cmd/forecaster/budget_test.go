@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral/cmd/forecaster/controller"
+	"github.com/HatiCode/kedastral/pkg/adapters"
+	"github.com/HatiCode/kedastral/pkg/capacity"
+	"github.com/HatiCode/kedastral/pkg/features"
+	"github.com/HatiCode/kedastral/pkg/models"
+	"github.com/HatiCode/kedastral/pkg/storage"
+)
+
+func TestBudgetCoordinator_Claim(t *testing.T) {
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	bc := newBudgetCoordinator()
+	bc.SetBudget("quota", controller.Budget{MaxReplicas: 10, Strategy: capacity.BudgetPriority, Members: []string{"shop-web", "shop-batch"}})
+
+	// Alone in the budget, a member gets its whole plan.
+	caps := bc.claim("shop-batch", workloadClaim{start: start, step: time.Minute, requests: []int{6, 6}, floors: []int{1, 1}})
+	if want := []int{6, 6}; !reflect.DeepEqual(caps, want) {
+		t.Errorf("first claim caps = %v, want %v", caps, want)
+	}
+
+	// A higher-priority member is served first, and the lower-priority one keeps
+	// what is left. A plan starting later counts its first step before it starts.
+	caps = bc.claim("shop-web", workloadClaim{start: start.Add(time.Minute), step: time.Minute, priority: 10, requests: []int{8, 8}, floors: []int{2, 2}})
+	if want := []int{8, 8}; !reflect.DeepEqual(caps, want) {
+		t.Errorf("priority claim caps = %v, want %v", caps, want)
+	}
+	caps = bc.claim("shop-batch", workloadClaim{start: start, step: time.Minute, requests: []int{6, 6}, floors: []int{1, 1}})
+	if want := []int{2, 2}; !reflect.DeepEqual(caps, want) {
+		t.Errorf("scaled back caps = %v, want %v", caps, want)
+	}
+
+	if caps := bc.claim("other", workloadClaim{start: start, step: time.Minute, requests: []int{50}, floors: []int{0}}); caps != nil {
+		t.Errorf("non-member caps = %v, want nil", caps)
+	}
+
+	usage := bc.BudgetUsage("quota", start.Add(30*time.Second))
+	want := controller.BudgetUsage{Start: start, StepSeconds: 60, Requested: []int{14, 14, 14}, Allocated: []int{10, 10, 10}}
+	if !reflect.DeepEqual(usage, want) {
+		t.Errorf("BudgetUsage() = %+v, want %+v", usage, want)
+	}
+
+	bc.remove("shop-web")
+	if caps := bc.claim("shop-batch", workloadClaim{start: start, step: time.Minute, requests: []int{6, 6}, floors: []int{1, 1}}); !reflect.DeepEqual(caps, []int{6, 6}) {
+		t.Errorf("caps after member removed = %v, want [6 6]", caps)
+	}
+}
+
+func TestForecaster_Tick_ReplicaBudget(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Minute)
+	rows := make([]adapters.Row, 3)
+	for i := range rows {
+		rows[i] = adapters.Row{"ts": now.Add(time.Duration(i-3) * time.Minute).Format(time.RFC3339), "value": 800.0}
+	}
+
+	bc := newBudgetCoordinator()
+	bc.SetBudget("quota", controller.Budget{MaxReplicas: 10, Strategy: capacity.BudgetProportional, Members: []string{"web", "api"}})
+	bc.claim("api", workloadClaim{start: now, step: time.Minute, requests: []int{8, 8, 8, 8}, floors: []int{1, 1, 1, 1}})
+
+	store := storage.NewMemoryStore()
+	f := &WorkloadForecaster{
+		name:    "web",
+		adapter: &staticAdapter{rows: rows},
+		model:   models.NewLastValueModel("rps", 60, 180),
+		builder: features.NewBuilder(),
+		store:   store,
+		policy: &capacity.Policy{
+			TargetPerPod:          100,
+			Headroom:              1.0,
+			MinReplicas:           1,
+			MaxReplicas:           50,
+			UpMaxFactorPerStep:    10,
+			DownMaxPercentPerStep: 100,
+		},
+		budgets:         bc,
+		step:            time.Minute,
+		horizon:         3 * time.Minute,
+		window:          3 * time.Minute,
+		currentReplicas: 1,
+		logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	if err := f.tick(context.Background()); err != nil {
+		t.Fatalf("tick() error = %v", err)
+	}
+
+	snapshot, _, err := store.GetLatest(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("GetLatest() error = %v", err)
+	}
+	// Both request 8 of 10: each keeps its floor and shares the remaining 8.
+	if want := []int{5, 5, 5}; !reflect.DeepEqual(snapshot.DesiredReplicas, want) {
+		t.Errorf("DesiredReplicas = %v, want %v", snapshot.DesiredReplicas, want)
+	}
+	if want := []capacity.Clamp{{Rule: capacity.ClampReplicaBudget, From: 8, To: 5}}; !reflect.DeepEqual(snapshot.Trace[0].Clamps, want) {
+		t.Errorf("Trace[0].Clamps = %v, want %v", snapshot.Trace[0].Clamps, want)
+	}
+	if f.currentReplicas != 5 {
+		t.Errorf("currentReplicas = %d, want 5", f.currentReplicas)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/HatiCode/kedastral/pkg/adapters"
 	"github.com/HatiCode/kedastral/pkg/capacity"
 	"github.com/HatiCode/kedastral/pkg/features"
+	"github.com/HatiCode/kedastral/pkg/models"
 	"github.com/HatiCode/kedastral/pkg/storage"
 )
 
@@ -38,12 +39,19 @@ func buildWorkloadForecaster(wc config.WorkloadConfig, store storage.Store, logg
 
 	policy := &capacity.Policy{
 		TargetPerPod:          wc.TargetPerPod,
+		Curve:                 wc.Curve,
 		Headroom:              wc.Headroom,
 		QuantileLevel:         quantileLevel,
 		MinReplicas:           wc.MinReplicas,
 		MaxReplicas:           wc.MaxReplicas,
 		UpMaxFactorPerStep:    wc.UpMaxFactorPerStep,
 		DownMaxPercentPerStep: wc.DownMaxPercentPerStep,
+		PodStartupSeconds:     int(wc.PodStartupTime.Seconds()),
+		ServiceRatePerPod:     wc.QueueServiceRate,
+		DrainSeconds:          int(wc.QueueDrainTime.Seconds()),
+		Planner:               wc.Planner,
+		ReplicaHourCost:       wc.ReplicaHourCost,
+		UnderProvisionPenalty: wc.UnderProvisionPenalty,
 	}
 
 	forecaster := NewWorkloadForecaster(
@@ -61,5 +69,128 @@ func buildWorkloadForecaster(wc config.WorkloadConfig, store storage.Store, logg
 		metrics.GetOrCreate(wc.Name),
 	)
 
+	forecaster.metric = wc.Metric
+	forecaster.priority = wc.Priority
+	forecaster.persistModel = wc.PersistModel
+	forecaster.trainTimeout = wc.TrainTimeout
+	if wc.TrainInterval > 0 {
+		forecaster.trainInterval = wc.TrainInterval
+		forecaster.newModel = func() models.Model { return fmodels.NewForWorkload(wc, logger) }
+		logger.Info("background training enabled",
+			"workload", wc.Name,
+			"train_interval", wc.TrainInterval,
+			"train_timeout", wc.TrainTimeout)
+	}
+
+	if wc.ResampleEnabled {
+		forecaster.resampler = features.NewResampler(features.ResampleOptions{
+			StepSeconds: int(wc.Step.Seconds()),
+			Aggregate:   wc.ResampleAggregate,
+			Fill:        wc.ResampleFill,
+			SeasonSteps: int(wc.ResampleSeason / wc.Step),
+			MaxGapSteps: int(wc.ResampleMaxGap / wc.Step),
+		})
+		logger.Info("resampling to step grid enabled",
+			"workload", wc.Name,
+			"aggregate", wc.ResampleAggregate,
+			"fill", wc.ResampleFill,
+			"max_gap", wc.ResampleMaxGap)
+	}
+
+	if wc.CleanEnabled {
+		forecaster.cleaner = features.NewCleaner(features.CleanOptions{
+			OutlierWindow:    wc.CleanOutlierWindow,
+			OutlierThreshold: wc.CleanOutlierThreshold,
+			Replacement:      wc.CleanReplacement,
+			StepSeconds:      int(wc.Step.Seconds()),
+			MaxGapSteps:      int(wc.CleanMaxGap / wc.Step),
+		})
+		logger.Info("training data cleaning enabled",
+			"workload", wc.Name,
+			"outlier_window", wc.CleanOutlierWindow,
+			"replacement", wc.CleanReplacement,
+			"max_gap", wc.CleanMaxGap)
+	}
+
+	for _, o := range wc.Overrides {
+		schedule, err := o.ParseSchedule()
+		if err != nil {
+			return nil, fmt.Errorf("override %q for workload %q: %w", o.Name, wc.Name, err)
+		}
+		forecaster.overrides = append(forecaster.overrides, capacity.Override{
+			Name:            o.Name,
+			Schedule:        schedule,
+			MinReplicas:     o.MinReplicas,
+			MaxReplicas:     o.MaxReplicas,
+			FreezeScaleDown: o.FreezeScaleDown,
+		})
+	}
+	if len(wc.Overrides) > 0 {
+		logger.Info("scheduled overrides enabled", "workload", wc.Name, "overrides", len(wc.Overrides))
+	}
+
+	stabilization := capacity.Stabilization{
+		ScaleDownWindowSeconds: int(wc.ScaleDownWindow.Seconds()),
+		ScaleUpHoldSeconds:     int(wc.ScaleUpHold.Seconds()),
+		DeadBandReplicas:       wc.DeadBandReplicas,
+		DeadBandPercent:        wc.DeadBandPercent,
+	}
+	if stabilization.Enabled() {
+		forecaster.stabilizer = capacity.NewStabilizer(stabilization)
+		logger.Info("replica stabilization enabled",
+			"workload", wc.Name,
+			"scale_down_window", wc.ScaleDownWindow,
+			"scale_up_hold", wc.ScaleUpHold,
+			"dead_band_replicas", wc.DeadBandReplicas,
+			"dead_band_percent", wc.DeadBandPercent)
+	}
+
+	if wc.Recommendation != nil {
+		forecaster.recommender, err = newTargetRecommender(wc)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("targetPerPod recommender enabled",
+			"workload", wc.Name,
+			"latency_slo", wc.Recommendation.LatencySLO,
+			"lookback", wc.Recommendation.Lookback,
+			"apply", wc.Recommendation.Apply)
+	}
+
+	if wc.Planner == "cost" {
+		logger.Info("cost-aware capacity planning enabled",
+			"workload", wc.Name,
+			"replica_hour_cost", wc.ReplicaHourCost,
+			"underprovision_penalty", wc.UnderProvisionPenalty)
+	}
+
+	if wc.CapacityMode == "queue" {
+		forecaster.depthAdapter, err = adapters.New(wc.QueueDepthAdapter, wc.QueueDepthAdapterConfig, int(wc.Step.Seconds()))
+		if err != nil {
+			return nil, fmt.Errorf("create queue depth adapter for workload %q: %w", wc.Name, err)
+		}
+		logger.Info("queue capacity planning enabled",
+			"workload", wc.Name,
+			"service_rate_per_pod", wc.QueueServiceRate,
+			"drain_time", wc.QueueDrainTime)
+	}
+
+	if len(wc.Metrics) > 0 {
+		forecaster.combine = wc.Combine
+		forecaster.weight = wc.Weight
+		for _, m := range wc.Metrics {
+			signal, err := buildWorkloadForecaster(wc.ForMetric(m), store, logger)
+			if err != nil {
+				return nil, err
+			}
+			signal.weight = m.Weight
+			forecaster.signals = append(forecaster.signals, signal)
+		}
+		logger.Info("multi-metric capacity planning enabled",
+			"workload", wc.Name,
+			"metrics", len(wc.Metrics)+1,
+			"combine", wc.Combine)
+	}
+
 	return forecaster, nil
 }
@@ -29,11 +29,16 @@ package config
 import (
 	"flag"
 	"fmt"
+	"maps"
 	"os"
 	"regexp"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/HatiCode/kedastral/pkg/capacity"
 	"github.com/HatiCode/kedastral/pkg/durationx"
+	"github.com/HatiCode/kedastral/pkg/models"
 	"github.com/HatiCode/kedastral/pkg/tls"
 )
 
@@ -65,8 +70,16 @@ type Config struct {
 	MaxReplicas           int
 	UpMaxFactorPerStep    float64
 	DownMaxPercentPerStep int
+	PodStartupTime        time.Duration
+	ScaleDownWindow       time.Duration
+	ScaleUpHold           time.Duration
+	DeadBandReplicas      int
+	DeadBandPercent       int
+	CapacityCurve         string
 	Interval              time.Duration
 	Window                time.Duration
+	TrainInterval         time.Duration
+	TrainTimeout          time.Duration
 	Model                 string
 	ARIMA_P               int
 	ARIMA_D               int
@@ -79,12 +92,46 @@ type Config struct {
 	SARIMA_SQ             int
 	SARIMA_S              int
 	BYOMURL               string
+	BYOMTLS               tls.Config
+	BYOMAuth              models.BYOMAuth
+	BYOMResilience        models.BYOMResilience
+	FallbackModels        string
+	FallbackMaxJump       float64
+	CapacityMode          string
+	QueueServiceRate      float64
+	QueueDrainTime        time.Duration
+	Planner               string
+	ReplicaHourCost       float64
+	UnderProvisionPenalty float64
+	QueueDepthQuery       string
+	PersistModel          bool
+	ConformalEnabled      bool
+	ConformalWindow       int
+	CleanEnabled          bool
+	CleanOutlierWindow    int
+	CleanOutlierThreshold float64
+	CleanReplacement      string
+	CleanMaxGap           time.Duration
+	ResampleEnabled       bool
+	ResampleAggregate     string
+	ResampleFill          string
+	ResampleSeason        time.Duration
+	ResampleMaxGap        time.Duration
+
+	RecommendLatencyQuery  string
+	RecommendReplicasQuery string
+	RecommendLatencySLO    float64
+	RecommendLookback      time.Duration
+	RecommendInterval      time.Duration
+	RecommendApply         bool
+	RecommendMinConfidence float64
 }
 
 // WorkloadConfig holds configuration for a single workload.
 // This struct is now only populated from flags/env vars for single-workload mode.
 type WorkloadConfig struct {
 	Name                  string
+	Policy                string
 	Metric                string
 	Adapter               string
 	AdapterConfig         map[string]string
@@ -92,6 +139,8 @@ type WorkloadConfig struct {
 	Step                  time.Duration
 	Interval              time.Duration
 	Window                time.Duration
+	TrainInterval         time.Duration
+	TrainTimeout          time.Duration
 	Model                 string
 	TargetPerPod          float64
 	Headroom              float64
@@ -100,6 +149,11 @@ type WorkloadConfig struct {
 	MaxReplicas           int
 	UpMaxFactorPerStep    float64
 	DownMaxPercentPerStep int
+	PodStartupTime        time.Duration
+	ScaleDownWindow       time.Duration
+	ScaleUpHold           time.Duration
+	DeadBandReplicas      int
+	DeadBandPercent       int
 	ARIMA_P               int
 	ARIMA_D               int
 	ARIMA_Q               int
@@ -111,6 +165,141 @@ type WorkloadConfig struct {
 	SARIMA_SQ             int
 	SARIMA_S              int
 	BYOMURL               string
+	BYOMTLS               tls.Config
+	BYOMAuth              models.BYOMAuth
+	BYOMResilience        models.BYOMResilience
+	FallbackModels        []string
+	FallbackMaxJump       float64
+	CapacityMode          string
+	QueueServiceRate      float64
+	QueueDrainTime        time.Duration
+	Planner               string
+	ReplicaHourCost       float64
+	UnderProvisionPenalty float64
+	PersistModel          bool
+	ConformalEnabled      bool
+	ConformalWindow       int
+	CleanEnabled          bool
+	CleanOutlierWindow    int
+	CleanOutlierThreshold float64
+	CleanReplacement      string
+	CleanMaxGap           time.Duration
+	ResampleEnabled       bool
+	ResampleAggregate     string
+	ResampleFill          string
+	ResampleSeason        time.Duration
+	ResampleMaxGap        time.Duration
+
+	// Metrics are additional metrics planned alongside Metric, each forecast from its
+	// own data source and model. Combine and Weight apply only when Metrics is set.
+	Metrics []MetricConfig
+	Combine string
+	Weight  float64
+
+	// QueueDepthAdapter and QueueDepthAdapterConfig collect the current queue depth
+	// of a queue-mode workload, whose Metric is the arrival rate.
+	QueueDepthAdapter       string
+	QueueDepthAdapterConfig map[string]string
+
+	// Overrides are scheduled replica bounds applied on top of the forecast.
+	Overrides []OverrideConfig
+
+	// Curve replaces TargetPerPod with the load sustained at each replica count,
+	// for workloads whose throughput does not grow linearly with replicas.
+	Curve *capacity.Curve
+
+	// Recommendation learns TargetPerPod from the workload's observed latency. Nil
+	// when no recommender runs.
+	Recommendation *RecommendationConfig
+
+	// Priority ranks the workload against the other members of a replica budget
+	// that scales plans back by priority; higher is served first.
+	Priority int
+}
+
+// RecommendationConfig configures the TargetPerPod recommender of a workload, which
+// periodically collects its load, ready replicas, and latency over Lookback and
+// learns the per-pod load at which latency breaches LatencySLO.
+type RecommendationConfig struct {
+	LatencyAdapter        string
+	LatencyAdapterConfig  map[string]string
+	ReplicasAdapter       string
+	ReplicasAdapterConfig map[string]string
+	LatencySLO            float64
+	Lookback              time.Duration
+	Interval              time.Duration
+
+	// Apply replaces TargetPerPod with recommendations of at least MinConfidence.
+	Apply         bool
+	MinConfidence float64
+}
+
+// OverrideConfig is a scheduled rule bounding a workload's replicas while its
+// schedule is active. Schedule uses the durationx.ParseSchedule syntax, interpreted
+// in Timezone (an IANA name, UTC when empty).
+type OverrideConfig struct {
+	Name            string
+	Schedule        string
+	Timezone        string
+	MinReplicas     int
+	MaxReplicas     int
+	FreezeScaleDown bool
+}
+
+// ParseSchedule parses the override's schedule in its time zone.
+func (o OverrideConfig) ParseSchedule() (durationx.Schedule, error) {
+	loc := time.UTC
+	if o.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(o.Timezone); err != nil {
+			return durationx.Schedule{}, fmt.Errorf("invalid timezone %q: %w", o.Timezone, err)
+		}
+	}
+	return durationx.ParseSchedule(o.Schedule, loc)
+}
+
+// MetricConfig is an additional metric of a multi-metric workload. Model parameters,
+// training, cleaning, and resampling settings are shared with the workload.
+type MetricConfig struct {
+	Name          string
+	Adapter       string
+	AdapterConfig map[string]string
+	Model         string
+	TargetPerPod  float64
+	Weight        float64
+}
+
+// UsesBYOM reports whether the workload's primary, any fallback, or any additional
+// metric's model is byom.
+func (w WorkloadConfig) UsesBYOM() bool {
+	if w.Model == "byom" || slices.Contains(w.FallbackModels, "byom") {
+		return true
+	}
+	for _, m := range w.Metrics {
+		if m.Model == "byom" {
+			return true
+		}
+	}
+	return false
+}
+
+// ForMetric returns the configuration that forecasts the additional metric m: the
+// workload's settings with m's data source, model, and target, keyed
+// "<workload>_<metric>" for metrics and model state. Storage keys only allow
+// letters, digits, hyphens, and underscores, and Kubernetes names, which operator
+// workloads are built from, have no underscores.
+func (w WorkloadConfig) ForMetric(m MetricConfig) WorkloadConfig {
+	wc := w
+	wc.Name = w.Name + "_" + m.Name
+	wc.Metric = m.Name
+	wc.Adapter = m.Adapter
+	wc.AdapterConfig = m.AdapterConfig
+	wc.Model = m.Model
+	wc.TargetPerPod = m.TargetPerPod
+	wc.FallbackModels = slices.DeleteFunc(slices.Clone(w.FallbackModels), func(model string) bool { return model == m.Model })
+	wc.Metrics = nil
+	wc.Recommendation = nil
+	return wc
 }
 
 // ParseFlags parses command-line flags and environment variables into a Config.
@@ -150,7 +339,15 @@ func ParseFlags() *Config {
 	flag.IntVar(&cfg.MaxReplicas, "max", getEnvInt("MAX_REPLICAS", 100), "Maximum replicas")
 	flag.Float64Var(&cfg.UpMaxFactorPerStep, "up-max-factor", getEnvFloat("UP_MAX_FACTOR", 2.0), "Max scale-up factor per step")
 	flag.IntVar(&cfg.DownMaxPercentPerStep, "down-max-percent", getEnvInt("DOWN_MAX_PERCENT", 50), "Max scale-down percent per step")
+	durationx.Var(&cfg.PodStartupTime, "pod-startup-time", getEnvDuration("POD_STARTUP_TIME", 0), "Time for a new pod to become ready; replicas are planned this much ahead of demand")
+	durationx.Var(&cfg.ScaleDownWindow, "scale-down-stabilization", getEnvDuration("SCALE_DOWN_STABILIZATION", 0), "Keep replicas at the highest recommendation within this window before scaling down")
+	durationx.Var(&cfg.ScaleUpHold, "scale-up-hold", getEnvDuration("SCALE_UP_HOLD", 0), "Minimum time replicas are held after a scale-up")
+	flag.IntVar(&cfg.DeadBandReplicas, "dead-band-replicas", getEnvInt("DEAD_BAND_REPLICAS", 0), "Ignore replica changes of at most this many replicas")
+	flag.IntVar(&cfg.DeadBandPercent, "dead-band-percent", getEnvInt("DEAD_BAND_PERCENT", 0), "Ignore replica changes of at most this percentage")
+	flag.StringVar(&cfg.CapacityCurve, "capacity-curve", getEnv("CAPACITY_CURVE", ""), "Load sustained per replica count instead of --target-per-pod: replicas:load points (1:100,10:800), usl:lambda,sigma,kappa, or usl: followed by points to fit")
 	durationx.Var(&cfg.Interval, "interval", getEnvDuration("INTERVAL", 30*time.Second), "Forecast interval")
+	durationx.Var(&cfg.TrainInterval, "train-interval", getEnvDuration("TRAIN_INTERVAL", 0), "How often to retrain the model in the background (0 trains on every forecast interval)")
+	durationx.Var(&cfg.TrainTimeout, "train-timeout", getEnvDuration("TRAIN_TIMEOUT", 5*time.Second), "Timeout for a single model training pass")
 	durationx.Var(&cfg.Window, "window", getEnvDuration("WINDOW", 30*time.Minute), "Historical window")
 	flag.StringVar(&cfg.Model, "model", getEnv("MODEL", "baseline"), "Forecasting model: baseline, arima, sarima, or byom")
 	flag.IntVar(&cfg.ARIMA_P, "arima-p", getEnvInt("ARIMA_P", 0), "ARIMA AR order (0=auto, default 1)")
@@ -163,7 +360,46 @@ func ParseFlags() *Config {
 	flag.IntVar(&cfg.SARIMA_SD, "sarima-sd", getEnvInt("SARIMA_SD", 1), "SARIMA seasonal differencing order")
 	flag.IntVar(&cfg.SARIMA_SQ, "sarima-sq", getEnvInt("SARIMA_SQ", 1), "SARIMA seasonal MA order")
 	flag.IntVar(&cfg.SARIMA_S, "sarima-s", getEnvInt("SARIMA_S", 24), "SARIMA seasonal period (e.g., 24 for hourly with daily pattern)")
-	flag.StringVar(&cfg.BYOMURL, "byom-url", getEnv("BYOM_URL", ""), "BYOM service URL (required when model=byom)")
+	flag.StringVar(&cfg.BYOMURL, "byom-url", getEnv("BYOM_URL", ""), "BYOM service URL: an HTTP /predict URL or byom+grpc://host:port (required when model=byom)")
+	flag.BoolVar(&cfg.BYOMTLS.Enabled, "byom-tls-enabled", getEnvBool("BYOM_TLS_ENABLED", false), "Enable mutual TLS to the BYOM service")
+	flag.StringVar(&cfg.BYOMTLS.CertFile, "byom-tls-cert-file", getEnv("BYOM_TLS_CERT_FILE", ""), "Client certificate file for BYOM mTLS")
+	flag.StringVar(&cfg.BYOMTLS.KeyFile, "byom-tls-key-file", getEnv("BYOM_TLS_KEY_FILE", ""), "Client private key file for BYOM mTLS")
+	flag.StringVar(&cfg.BYOMTLS.CAFile, "byom-tls-ca-file", getEnv("BYOM_TLS_CA_FILE", ""), "CA certificate file for verifying the BYOM service")
+	flag.StringVar(&cfg.BYOMAuth.Token, "byom-auth-token", getEnv("BYOM_AUTH_TOKEN", ""), "Bearer token sent to the BYOM service")
+	flag.StringVar(&cfg.BYOMAuth.TokenFile, "byom-auth-token-file", getEnv("BYOM_AUTH_TOKEN_FILE", ""), "File holding the BYOM bearer token, re-read on every request (overrides --byom-auth-token)")
+	flag.IntVar(&cfg.BYOMResilience.MaxRetries, "byom-max-retries", getEnvInt("BYOM_MAX_RETRIES", models.DefaultBYOMResilience.MaxRetries), "Retries of a BYOM call after a transient failure (0 disables retries)")
+	durationx.Var(&cfg.BYOMResilience.RetryBackoff, "byom-retry-backoff", getEnvDuration("BYOM_RETRY_BACKOFF", models.DefaultBYOMResilience.RetryBackoff), "Base BYOM retry delay, doubled on every retry and jittered")
+	flag.IntVar(&cfg.BYOMResilience.BreakerThreshold, "byom-breaker-threshold", getEnvInt("BYOM_BREAKER_THRESHOLD", models.DefaultBYOMResilience.BreakerThreshold), "Consecutive BYOM failures that open the circuit breaker (0 disables the breaker)")
+	durationx.Var(&cfg.BYOMResilience.BreakerCooldown, "byom-breaker-cooldown", getEnvDuration("BYOM_BREAKER_COOLDOWN", models.DefaultBYOMResilience.BreakerCooldown), "How long the BYOM circuit breaker stays open before a trial call")
+	flag.StringVar(&cfg.FallbackModels, "fallback-models", getEnv("FALLBACK_MODELS", ""), "Comma-separated models tried in order when the primary model fails or forecasts implausibly: baseline, arima, sarima, byom, or last-value")
+	flag.Float64Var(&cfg.FallbackMaxJump, "fallback-max-jump", getEnvFloat("FALLBACK_MAX_JUMP", models.DefaultFallbackMaxJump), "Reject a forecast whose peak exceeds this multiple of the recent peak and try the next fallback model")
+	flag.StringVar(&cfg.CapacityMode, "capacity-mode", getEnv("CAPACITY_MODE", "throughput"), "Capacity planning mode: throughput (divide load by --target-per-pod) or queue (drain a backlog; the metric is the arrival rate)")
+	flag.Float64Var(&cfg.QueueServiceRate, "queue-service-rate", getEnvFloat("QUEUE_SERVICE_RATE", 0), "Queue items a pod processes per second (required in queue mode)")
+	durationx.Var(&cfg.QueueDrainTime, "queue-drain-time", getEnvDuration("QUEUE_DRAIN_TIME", 5*time.Minute), "Time within which queue mode drains the backlog plus expected arrivals")
+	flag.StringVar(&cfg.Planner, "planner", getEnv("PLANNER", "deterministic"), "Replica planner: deterministic (size each step for its forecast) or cost (minimise expected replica cost plus under-provisioning penalty)")
+	flag.Float64Var(&cfg.ReplicaHourCost, "replica-hour-cost", getEnvFloat("REPLICA_HOUR_COST", 0), "Cost of one replica running for an hour, used by the cost planner")
+	flag.Float64Var(&cfg.UnderProvisionPenalty, "underprovision-penalty", getEnvFloat("UNDERPROVISION_PENALTY", 0), "Cost of one pod-hour of unserved demand, used by the cost planner")
+	flag.StringVar(&cfg.QueueDepthQuery, "queue-depth-query", getEnv("QUEUE_DEPTH_QUERY", ""), "Adapter query returning the current queue depth, run with the workload's adapter settings (required in queue mode)")
+	flag.StringVar(&cfg.RecommendLatencyQuery, "recommend-latency-query", getEnv("RECOMMEND_LATENCY_QUERY", ""), "Adapter query returning the workload's latency; enables the targetPerPod recommender")
+	flag.StringVar(&cfg.RecommendReplicasQuery, "recommend-replicas-query", getEnv("RECOMMEND_REPLICAS_QUERY", ""), "Adapter query returning the workload's ready replicas (required by the recommender)")
+	flag.Float64Var(&cfg.RecommendLatencySLO, "recommend-latency-slo", getEnvFloat("RECOMMEND_LATENCY_SLO", 0), "Latency the workload must stay within, in the unit of --recommend-latency-query")
+	durationx.Var(&cfg.RecommendLookback, "recommend-lookback", getEnvDuration("RECOMMEND_LOOKBACK", 7*24*time.Hour), "History the recommender learns from")
+	durationx.Var(&cfg.RecommendInterval, "recommend-interval", getEnvDuration("RECOMMEND_INTERVAL", time.Hour), "How often the recommender runs")
+	flag.BoolVar(&cfg.RecommendApply, "recommend-apply", getEnvBool("RECOMMEND_APPLY", false), "Plan with the recommended targetPerPod once its confidence reaches --recommend-min-confidence")
+	flag.Float64Var(&cfg.RecommendMinConfidence, "recommend-min-confidence", getEnvFloat("RECOMMEND_MIN_CONFIDENCE", 0.8), "Confidence (0-1) a recommendation needs to be applied")
+	flag.BoolVar(&cfg.PersistModel, "persist-model", getEnvBool("PERSIST_MODEL", false), "Persist trained model state to the store and warm-start from it on startup")
+	flag.BoolVar(&cfg.ConformalEnabled, "conformal", getEnvBool("CONFORMAL_ENABLED", false), "Replace model quantiles with conformal intervals calibrated on recent forecast errors")
+	flag.IntVar(&cfg.ConformalWindow, "conformal-window", getEnvInt("CONFORMAL_WINDOW", 100), "Number of recent forecast errors per horizon step used for conformal calibration")
+	flag.BoolVar(&cfg.CleanEnabled, "clean", getEnvBool("CLEAN_ENABLED", false), "Clean outliers and fill short gaps in the training window before model training")
+	flag.IntVar(&cfg.CleanOutlierWindow, "clean-outlier-window", getEnvInt("CLEAN_OUTLIER_WINDOW", 5), "Hampel filter half-width in points (0 disables outlier detection)")
+	flag.Float64Var(&cfg.CleanOutlierThreshold, "clean-outlier-threshold", getEnvFloat("CLEAN_OUTLIER_THRESHOLD", 3.0), "Outlier threshold in scaled MADs from the window median")
+	flag.StringVar(&cfg.CleanReplacement, "clean-replacement", getEnv("CLEAN_REPLACEMENT", "median"), "Outlier replacement strategy: median, interpolate, or clip")
+	durationx.Var(&cfg.CleanMaxGap, "clean-max-gap", getEnvDuration("CLEAN_MAX_GAP", 10*time.Minute), "Longest data gap filled by linear interpolation (0 disables gap filling)")
+	flag.BoolVar(&cfg.ResampleEnabled, "resample", getEnvBool("RESAMPLE_ENABLED", false), "Snap collected data to the step grid before cleaning and training")
+	flag.StringVar(&cfg.ResampleAggregate, "resample-aggregate", getEnv("RESAMPLE_AGGREGATE", "mean"), "Aggregation for points on the same grid step: mean, sum, or last")
+	flag.StringVar(&cfg.ResampleFill, "resample-fill", getEnv("RESAMPLE_FILL", "linear"), "Fill for missing grid steps: linear, seasonal, or forward")
+	durationx.Var(&cfg.ResampleSeason, "resample-season", getEnvDuration("RESAMPLE_SEASON", 24*time.Hour), "Season length used by the seasonal fill")
+	durationx.Var(&cfg.ResampleMaxGap, "resample-max-gap", getEnvDuration("RESAMPLE_MAX_GAP", 15*time.Minute), "Longest gap the resampler fills; longer gaps fail the forecast tick")
 
 	flag.Parse()
 
@@ -311,6 +547,8 @@ func LoadWorkloads(cfg *Config) ([]WorkloadConfig, error) {
 		Horizon:               cfg.Horizon,
 		Step:                  cfg.Step,
 		Interval:              cfg.Interval,
+		TrainInterval:         cfg.TrainInterval,
+		TrainTimeout:          cfg.TrainTimeout,
 		Window:                cfg.Window,
 		Model:                 cfg.Model,
 		TargetPerPod:          cfg.TargetPerPod,
@@ -320,6 +558,11 @@ func LoadWorkloads(cfg *Config) ([]WorkloadConfig, error) {
 		MaxReplicas:           cfg.MaxReplicas,
 		UpMaxFactorPerStep:    cfg.UpMaxFactorPerStep,
 		DownMaxPercentPerStep: cfg.DownMaxPercentPerStep,
+		PodStartupTime:        cfg.PodStartupTime,
+		ScaleDownWindow:       cfg.ScaleDownWindow,
+		ScaleUpHold:           cfg.ScaleUpHold,
+		DeadBandReplicas:      cfg.DeadBandReplicas,
+		DeadBandPercent:       cfg.DeadBandPercent,
 		ARIMA_P:               cfg.ARIMA_P,
 		ARIMA_D:               cfg.ARIMA_D,
 		ARIMA_Q:               cfg.ARIMA_Q,
@@ -331,6 +574,57 @@ func LoadWorkloads(cfg *Config) ([]WorkloadConfig, error) {
 		SARIMA_SQ:             cfg.SARIMA_SQ,
 		SARIMA_S:              cfg.SARIMA_S,
 		BYOMURL:               cfg.BYOMURL,
+		BYOMTLS:               cfg.BYOMTLS,
+		BYOMAuth:              cfg.BYOMAuth,
+		BYOMResilience:        cfg.BYOMResilience,
+		FallbackModels:        splitList(cfg.FallbackModels),
+		FallbackMaxJump:       cfg.FallbackMaxJump,
+		CapacityMode:          cfg.CapacityMode,
+		QueueServiceRate:      cfg.QueueServiceRate,
+		QueueDrainTime:        cfg.QueueDrainTime,
+		Planner:               cfg.Planner,
+		ReplicaHourCost:       cfg.ReplicaHourCost,
+		UnderProvisionPenalty: cfg.UnderProvisionPenalty,
+		PersistModel:          cfg.PersistModel,
+		ConformalEnabled:      cfg.ConformalEnabled,
+		ConformalWindow:       cfg.ConformalWindow,
+		CleanEnabled:          cfg.CleanEnabled,
+		CleanOutlierWindow:    cfg.CleanOutlierWindow,
+		CleanOutlierThreshold: cfg.CleanOutlierThreshold,
+		CleanReplacement:      cfg.CleanReplacement,
+		CleanMaxGap:           cfg.CleanMaxGap,
+		ResampleEnabled:       cfg.ResampleEnabled,
+		ResampleAggregate:     cfg.ResampleAggregate,
+		ResampleFill:          cfg.ResampleFill,
+		ResampleSeason:        cfg.ResampleSeason,
+		ResampleMaxGap:        cfg.ResampleMaxGap,
+	}
+
+	if cfg.CapacityCurve != "" {
+		curve, err := capacity.ParseCurve(cfg.CapacityCurve)
+		if err != nil {
+			return nil, fmt.Errorf("invalid capacity curve: %w", err)
+		}
+		workload.Curve = curve
+	}
+
+	if cfg.QueueDepthQuery != "" {
+		workload.QueueDepthAdapter = cfg.Adapter
+		workload.QueueDepthAdapterConfig = withQuery(cfg.AdapterConfig, cfg.QueueDepthQuery)
+	}
+
+	if cfg.RecommendLatencyQuery != "" {
+		workload.Recommendation = &RecommendationConfig{
+			LatencyAdapter:        cfg.Adapter,
+			LatencyAdapterConfig:  withQuery(cfg.AdapterConfig, cfg.RecommendLatencyQuery),
+			ReplicasAdapter:       cfg.Adapter,
+			ReplicasAdapterConfig: withQuery(cfg.AdapterConfig, cfg.RecommendReplicasQuery),
+			LatencySLO:            cfg.RecommendLatencySLO,
+			Lookback:              cfg.RecommendLookback,
+			Interval:              cfg.RecommendInterval,
+			Apply:                 cfg.RecommendApply,
+			MinConfidence:         cfg.RecommendMinConfidence,
+		}
 	}
 
 	if err := validateWorkload(&workload, 0); err != nil {
@@ -384,6 +678,18 @@ func validateWorkload(w *WorkloadConfig, index int) error {
 		w.Window = 30 * time.Minute
 	}
 
+	if w.TrainInterval < 0 {
+		return fmt.Errorf("workload %q: train interval cannot be negative", w.Name)
+	}
+
+	if w.TrainInterval > 0 && w.TrainInterval < w.Interval {
+		return fmt.Errorf("workload %q: train interval (%v) cannot be shorter than interval (%v)", w.Name, w.TrainInterval, w.Interval)
+	}
+
+	if w.TrainTimeout <= 0 {
+		w.TrainTimeout = 5 * time.Second
+	}
+
 	if w.MinReplicas < 0 {
 		return fmt.Errorf("workload %q: minReplicas cannot be negative", w.Name)
 	}
@@ -396,7 +702,23 @@ func validateWorkload(w *WorkloadConfig, index int) error {
 		w.MaxReplicas = 100
 	}
 
-	if w.TargetPerPod <= 0 {
+	if err := validateQueue(w); err != nil {
+		return err
+	}
+
+	if err := validatePlanner(w); err != nil {
+		return err
+	}
+
+	if err := validateCurve(w); err != nil {
+		return err
+	}
+
+	if err := validateRecommendation(w); err != nil {
+		return err
+	}
+
+	if w.CapacityMode != "queue" && w.Curve == nil && w.TargetPerPod <= 0 {
 		return fmt.Errorf("workload %q: targetPerPod must be > 0", w.Name)
 	}
 
@@ -412,6 +734,18 @@ func validateWorkload(w *WorkloadConfig, index int) error {
 		return fmt.Errorf("workload %q: downMaxPercentPerStep must be 0-100", w.Name)
 	}
 
+	if w.PodStartupTime < 0 {
+		return fmt.Errorf("workload %q: pod startup time cannot be negative", w.Name)
+	}
+
+	if w.ScaleDownWindow < 0 || w.ScaleUpHold < 0 {
+		return fmt.Errorf("workload %q: stabilization window and scale-up hold cannot be negative", w.Name)
+	}
+
+	if w.DeadBandReplicas < 0 || w.DeadBandPercent < 0 || w.DeadBandPercent > 100 {
+		return fmt.Errorf("workload %q: dead band must be >= 0 replicas and 0-100 percent", w.Name)
+	}
+
 	if w.Model == "" {
 		w.Model = "baseline"
 	}
@@ -420,9 +754,352 @@ func validateWorkload(w *WorkloadConfig, index int) error {
 		return fmt.Errorf("workload %q: invalid model %q (must be baseline, arima, sarima, or byom)", w.Name, w.Model)
 	}
 
-	if w.Model == "byom" && w.BYOMURL == "" {
+	if err := validateFallback(w); err != nil {
+		return err
+	}
+
+	if err := validateMetrics(w); err != nil {
+		return err
+	}
+
+	if err := validateOverrides(w); err != nil {
+		return err
+	}
+
+	if w.UsesBYOM() && w.BYOMURL == "" {
 		return fmt.Errorf("workload %q: byomURL is required when model=byom", w.Name)
 	}
 
+	if w.UsesBYOM() {
+		if target, ok := strings.CutPrefix(w.BYOMURL, models.BYOMGRPCScheme); ok && target == "" {
+			return fmt.Errorf("workload %q: byomURL %q is missing the gRPC target host:port", w.Name, w.BYOMURL)
+		}
+
+		if err := w.BYOMTLS.Validate(); err != nil {
+			return fmt.Errorf("workload %q: byom tls: %w", w.Name, err)
+		}
+
+		if err := ValidateBYOMResilience(w.BYOMResilience); err != nil {
+			return fmt.Errorf("workload %q: %w", w.Name, err)
+		}
+	}
+
+	if w.ConformalEnabled {
+		if w.ConformalWindow <= 0 {
+			w.ConformalWindow = 100
+		}
+
+		if w.ConformalWindow < 10 {
+			return fmt.Errorf("workload %q: conformal window must be at least 10, got %d", w.Name, w.ConformalWindow)
+		}
+	}
+
+	if w.CleanEnabled {
+		if w.CleanOutlierWindow < 0 {
+			return fmt.Errorf("workload %q: clean outlier window cannot be negative", w.Name)
+		}
+
+		if w.CleanOutlierThreshold <= 0 {
+			w.CleanOutlierThreshold = 3.0
+		}
+
+		if w.CleanReplacement == "" {
+			w.CleanReplacement = "median"
+		}
+
+		if w.CleanReplacement != "median" && w.CleanReplacement != "interpolate" && w.CleanReplacement != "clip" {
+			return fmt.Errorf("workload %q: invalid clean replacement %q (must be median, interpolate, or clip)", w.Name, w.CleanReplacement)
+		}
+
+		if w.CleanMaxGap < 0 {
+			return fmt.Errorf("workload %q: clean max gap cannot be negative", w.Name)
+		}
+	}
+
+	if w.ResampleEnabled {
+		if w.ResampleAggregate == "" {
+			w.ResampleAggregate = "mean"
+		}
+
+		if w.ResampleAggregate != "mean" && w.ResampleAggregate != "sum" && w.ResampleAggregate != "last" {
+			return fmt.Errorf("workload %q: invalid resample aggregate %q (must be mean, sum, or last)", w.Name, w.ResampleAggregate)
+		}
+
+		if w.ResampleFill == "" {
+			w.ResampleFill = "linear"
+		}
+
+		if w.ResampleFill != "linear" && w.ResampleFill != "seasonal" && w.ResampleFill != "forward" {
+			return fmt.Errorf("workload %q: invalid resample fill %q (must be linear, seasonal, or forward)", w.Name, w.ResampleFill)
+		}
+
+		if w.ResampleFill == "seasonal" && w.ResampleSeason < w.Step {
+			return fmt.Errorf("workload %q: resample season (%v) must be at least one step (%v) for seasonal fill", w.Name, w.ResampleSeason, w.Step)
+		}
+
+		if w.ResampleMaxGap < 0 {
+			return fmt.Errorf("workload %q: resample max gap cannot be negative", w.Name)
+		}
+	}
+
+	return nil
+}
+
+// ValidateBYOMResilience checks the BYOM retry and circuit breaker settings.
+func ValidateBYOMResilience(r models.BYOMResilience) error {
+	if r.MaxRetries < 0 {
+		return fmt.Errorf("byom max retries must be >= 0, got %d", r.MaxRetries)
+	}
+	if r.RetryBackoff < 0 {
+		return fmt.Errorf("byom retry backoff must be >= 0, got %v", r.RetryBackoff)
+	}
+	if r.BreakerThreshold < 0 {
+		return fmt.Errorf("byom breaker threshold must be >= 0, got %d", r.BreakerThreshold)
+	}
+	if r.BreakerThreshold > 0 && r.BreakerCooldown <= 0 {
+		return fmt.Errorf("byom breaker cooldown must be > 0 when the breaker is enabled, got %v", r.BreakerCooldown)
+	}
+	return nil
+}
+
+// validateFallback checks the fallback chain: known model types, no model listed
+// twice (including the primary), and a jump bound above 1.
+func validateFallback(w *WorkloadConfig) error {
+	if len(w.FallbackModels) == 0 {
+		return nil
+	}
+
+	seen := map[string]bool{w.Model: true}
+	for _, model := range w.FallbackModels {
+		switch model {
+		case "baseline", "arima", "sarima", "byom", "last-value":
+		default:
+			return fmt.Errorf("workload %q: invalid fallback model %q (must be baseline, arima, sarima, byom, or last-value)", w.Name, model)
+		}
+		if seen[model] {
+			return fmt.Errorf("workload %q: fallback model %q is already in the chain", w.Name, model)
+		}
+		seen[model] = true
+	}
+
+	if w.FallbackMaxJump == 0 {
+		w.FallbackMaxJump = models.DefaultFallbackMaxJump
+	}
+	if w.FallbackMaxJump <= 1 {
+		return fmt.Errorf("workload %q: fallback max jump must be greater than 1, got %v", w.Name, w.FallbackMaxJump)
+	}
 	return nil
 }
+
+// validateMetrics checks the additional metrics of a multi-metric workload and how
+// they are combined, defaulting each metric's model to the workload's.
+func validateMetrics(w *WorkloadConfig) error {
+	if len(w.Metrics) == 0 {
+		return nil
+	}
+
+	if w.Combine == "" {
+		w.Combine = "max"
+	}
+	if w.Combine != "max" && w.Combine != "weighted" {
+		return fmt.Errorf("workload %q: invalid combine %q (must be max or weighted)", w.Name, w.Combine)
+	}
+	if w.Weight < 0 {
+		return fmt.Errorf("workload %q: weight cannot be negative", w.Name)
+	}
+
+	seen := map[string]bool{w.Metric: true}
+	for i := range w.Metrics {
+		m := &w.Metrics[i]
+		if m.Name == "" {
+			return fmt.Errorf("workload %q: metrics[%d]: name cannot be empty", w.Name, i)
+		}
+		if !validMetricName(m.Name) {
+			return fmt.Errorf("workload %q: metric %q: name may only contain letters, digits, hyphens, and underscores", w.Name, m.Name)
+		}
+		if seen[m.Name] {
+			return fmt.Errorf("workload %q: metric %q is listed twice", w.Name, m.Name)
+		}
+		seen[m.Name] = true
+
+		if m.Adapter == "" {
+			return fmt.Errorf("workload %q: metric %q: adapter cannot be empty", w.Name, m.Name)
+		}
+		if m.Model == "" {
+			m.Model = w.Model
+		}
+		if m.Model != "baseline" && m.Model != "arima" && m.Model != "sarima" && m.Model != "byom" {
+			return fmt.Errorf("workload %q: metric %q: invalid model %q (must be baseline, arima, sarima, or byom)", w.Name, m.Name, m.Model)
+		}
+		if m.TargetPerPod <= 0 {
+			return fmt.Errorf("workload %q: metric %q: targetPerPod must be > 0", w.Name, m.Name)
+		}
+		if m.Weight < 0 {
+			return fmt.Errorf("workload %q: metric %q: weight cannot be negative", w.Name, m.Name)
+		}
+	}
+	return nil
+}
+
+// validMetricName reports whether name can key an additional metric's forecaster,
+// whose name the storage backends restrict.
+func validMetricName(name string) bool {
+	for _, c := range name {
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// validateQueue checks the capacity mode and, in queue mode, the service rate, drain
+// time, and queue depth source.
+func validateQueue(w *WorkloadConfig) error {
+	if w.CapacityMode == "" {
+		w.CapacityMode = "throughput"
+	}
+	if w.CapacityMode != "throughput" && w.CapacityMode != "queue" {
+		return fmt.Errorf("workload %q: invalid capacity mode %q (must be throughput or queue)", w.Name, w.CapacityMode)
+	}
+	if w.CapacityMode != "queue" {
+		return nil
+	}
+
+	if w.QueueServiceRate <= 0 {
+		return fmt.Errorf("workload %q: queue service rate must be > 0 in queue mode", w.Name)
+	}
+	if w.QueueDrainTime <= 0 {
+		return fmt.Errorf("workload %q: queue drain time must be > 0 in queue mode", w.Name)
+	}
+	if w.QueueDepthAdapter == "" {
+		return fmt.Errorf("workload %q: a queue depth source is required in queue mode", w.Name)
+	}
+	if len(w.Metrics) > 0 {
+		return fmt.Errorf("workload %q: queue mode cannot be combined with additional metrics", w.Name)
+	}
+	return nil
+}
+
+// validateOverrides checks that scheduled overrides are named uniquely, parse, and
+// bound something.
+func validateOverrides(w *WorkloadConfig) error {
+	seen := make(map[string]bool, len(w.Overrides))
+	for _, o := range w.Overrides {
+		if o.Name == "" {
+			return fmt.Errorf("workload %q: override name is required", w.Name)
+		}
+		if seen[o.Name] {
+			return fmt.Errorf("workload %q: duplicate override %q", w.Name, o.Name)
+		}
+		seen[o.Name] = true
+
+		if _, err := o.ParseSchedule(); err != nil {
+			return fmt.Errorf("workload %q: override %q: %w", w.Name, o.Name, err)
+		}
+		if o.MinReplicas < 0 || o.MaxReplicas < 0 {
+			return fmt.Errorf("workload %q: override %q: replicas cannot be negative", w.Name, o.Name)
+		}
+		if o.MaxReplicas > 0 && o.MaxReplicas < o.MinReplicas {
+			return fmt.Errorf("workload %q: override %q: maxReplicas (%d) < minReplicas (%d)", w.Name, o.Name, o.MaxReplicas, o.MinReplicas)
+		}
+		if o.MinReplicas == 0 && o.MaxReplicas == 0 && !o.FreezeScaleDown {
+			return fmt.Errorf("workload %q: override %q must set minReplicas, maxReplicas, or freezeScaleDown", w.Name, o.Name)
+		}
+	}
+	return nil
+}
+
+// validatePlanner checks the replica planner and, for the cost planner, its costs.
+func validatePlanner(w *WorkloadConfig) error {
+	if w.Planner == "" {
+		w.Planner = "deterministic"
+	}
+	if w.Planner != "deterministic" && w.Planner != "cost" {
+		return fmt.Errorf("workload %q: invalid planner %q (must be deterministic or cost)", w.Name, w.Planner)
+	}
+	if w.Planner != "cost" {
+		return nil
+	}
+
+	if w.ReplicaHourCost <= 0 {
+		return fmt.Errorf("workload %q: replica hour cost must be > 0 with the cost planner", w.Name)
+	}
+	if w.UnderProvisionPenalty <= 0 {
+		return fmt.Errorf("workload %q: under-provision penalty must be > 0 with the cost planner", w.Name)
+	}
+	if w.CapacityMode == "queue" {
+		return fmt.Errorf("workload %q: the cost planner cannot be used in queue mode", w.Name)
+	}
+	if len(w.Metrics) > 0 {
+		return fmt.Errorf("workload %q: the cost planner cannot be combined with additional metrics", w.Name)
+	}
+	return nil
+}
+
+// validateCurve checks a capacity curve and that it is only used where the planner
+// applies it.
+func validateCurve(w *WorkloadConfig) error {
+	if w.Curve == nil {
+		return nil
+	}
+	if err := w.Curve.Validate(); err != nil {
+		return fmt.Errorf("workload %q: %w", w.Name, err)
+	}
+	if w.CapacityMode == "queue" {
+		return fmt.Errorf("workload %q: a capacity curve cannot be used in queue mode", w.Name)
+	}
+	if len(w.Metrics) > 0 {
+		return fmt.Errorf("workload %q: a capacity curve cannot be combined with additional metrics", w.Name)
+	}
+	return nil
+}
+
+// validateRecommendation checks the TargetPerPod recommender, which needs a latency
+// and replica source and an SLO, and can only apply its recommendation where
+// TargetPerPod sizes replicas.
+func validateRecommendation(w *WorkloadConfig) error {
+	r := w.Recommendation
+	if r == nil {
+		return nil
+	}
+	if r.LatencyAdapter == "" || r.ReplicasAdapter == "" {
+		return fmt.Errorf("workload %q: the recommender needs latency and replica sources", w.Name)
+	}
+	if r.LatencySLO <= 0 {
+		return fmt.Errorf("workload %q: recommender latency SLO must be > 0", w.Name)
+	}
+	if r.Lookback < w.Step {
+		return fmt.Errorf("workload %q: recommender lookback (%v) cannot be shorter than step (%v)", w.Name, r.Lookback, w.Step)
+	}
+	if r.Interval <= 0 {
+		r.Interval = time.Hour
+	}
+	if r.MinConfidence < 0 || r.MinConfidence > 1 {
+		return fmt.Errorf("workload %q: recommender min confidence must be 0-1", w.Name)
+	}
+	if r.Apply && (w.CapacityMode == "queue" || w.Curve != nil) {
+		return fmt.Errorf("workload %q: a recommended targetPerPod cannot be applied in queue mode or with a capacity curve", w.Name)
+	}
+	return nil
+}
+
+// withQuery returns a copy of an adapter configuration running query.
+func withQuery(adapterConfig map[string]string, query string) map[string]string {
+	c := maps.Clone(adapterConfig)
+	if c == nil {
+		c = make(map[string]string)
+	}
+	c["query"] = query
+	return c
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,10 +1,15 @@
 package config
 
 import (
+	"context"
 	"flag"
 	"os"
 	"testing"
 	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/HatiCode/kedastral/pkg/storage"
 )
 
 func TestGetEnv(t *testing.T) {
@@ -286,3 +291,23 @@ func TestConfig_CustomValues(t *testing.T) {
 		t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, "debug")
 	}
 }
+
+func TestWorkloadConfig_ForMetric_StorageKey(t *testing.T) {
+	server := miniredis.RunT(t)
+	store, err := storage.NewRedisStore(server.Addr(), "", 0, time.Minute)
+	if err != nil {
+		t.Fatalf("NewRedisStore() error = %v", err)
+	}
+	defer store.Close()
+
+	w := WorkloadConfig{Name: "shop-web", Metric: "rps"}
+	name := w.ForMetric(MetricConfig{Name: "ws_connections"}).Name
+
+	ctx := context.Background()
+	if err := store.PutModelState(ctx, name, []byte(`{}`)); err != nil {
+		t.Errorf("PutModelState(%q) error = %v", name, err)
+	}
+	if err := store.Put(ctx, storage.Snapshot{Workload: name, GeneratedAt: time.Now()}); err != nil {
+		t.Errorf("Put(%q) error = %v", name, err)
+	}
+}
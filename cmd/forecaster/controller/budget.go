@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedastralv1alpha1 "github.com/HatiCode/kedastral/pkg/api/v1alpha1"
+)
+
+// budgetRequeueInterval is how often a ReplicaBudget's status is refreshed from
+// its members' latest plans.
+const budgetRequeueInterval = time.Minute
+
+// Budget is a replica budget shared by the forecast loops of its member workloads.
+type Budget struct {
+	MaxReplicas int
+	Strategy    string
+	// Members are the workload keys of the member policies.
+	Members []string
+}
+
+// BudgetUsage is how a budget is used over the members' horizons: the replicas the
+// members requested and were allocated in total at each step from Start.
+type BudgetUsage struct {
+	Start       time.Time
+	StepSeconds int
+	Requested   []int
+	Allocated   []int
+}
+
+// BudgetManager coordinates the plans of workloads sharing a ReplicaBudget.
+type BudgetManager interface {
+	// SetBudget starts or replaces the named budget.
+	SetBudget(name string, budget Budget)
+	// RemoveBudget stops applying the named budget.
+	RemoveBudget(name string)
+	// BudgetUsage reports the use of the named budget from now on, from the latest
+	// plan of every member.
+	BudgetUsage(name string, now time.Time) BudgetUsage
+}
+
+// ReplicaBudgetReconciler reconciles ReplicaBudget resources, resolving their
+// member ForecastPolicies and reporting utilisation on their status.
+type ReplicaBudgetReconciler struct {
+	client.Client
+	Budgets BudgetManager
+	Logger  *slog.Logger
+}
+
+// Reconcile applies a ReplicaBudget to its current members and records its usage.
+func (r *ReplicaBudgetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var budget kedastralv1alpha1.ReplicaBudget
+	if err := r.Get(ctx, req.NamespacedName, &budget); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Budgets.RemoveBudget(req.Name)
+			r.Logger.Info("replicabudget deleted", "budget", req.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	members, err := r.members(ctx, &budget)
+	if err != nil {
+		r.Budgets.RemoveBudget(budget.Name)
+		return r.fail(ctx, &budget, "InvalidSpec", err.Error())
+	}
+
+	keys := make([]string, len(members))
+	budget.Status.Members = make([]string, len(members))
+	for i, policy := range members {
+		keys[i] = workloadKey(policy.Namespace, policy.Name)
+		budget.Status.Members[i] = policy.Namespace + "/" + policy.Name
+	}
+	r.Budgets.SetBudget(budget.Name, Budget{
+		MaxReplicas: budget.Spec.MaxReplicas,
+		Strategy:    budget.Spec.Strategy,
+		Members:     keys,
+	})
+
+	setBudgetUsage(&budget.Status, budget.Spec.MaxReplicas, r.Budgets.BudgetUsage(budget.Name, time.Now()))
+	budget.Status.ObservedGeneration = budget.Generation
+	meta.SetStatusCondition(&budget.Status.Conditions, metav1.Condition{
+		Type:               "Ready",
+		Status:             metav1.ConditionTrue,
+		Reason:             "Reconciled",
+		Message:            fmt.Sprintf("Budget applied to %d ForecastPolicies", len(members)),
+		ObservedGeneration: budget.Generation,
+	})
+	if err := r.Status().Update(ctx, &budget); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: budgetRequeueInterval}, nil
+}
+
+// members lists the ForecastPolicies a budget covers, sorted by namespace and name.
+func (r *ReplicaBudgetReconciler) members(ctx context.Context, budget *kedastralv1alpha1.ReplicaBudget) ([]kedastralv1alpha1.ForecastPolicy, error) {
+	selector := labels.Everything()
+	if budget.Spec.Selector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(budget.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector: %w", err)
+		}
+	}
+
+	var policies []kedastralv1alpha1.ForecastPolicy
+	list := func(opts ...client.ListOption) error {
+		var found kedastralv1alpha1.ForecastPolicyList
+		if err := r.List(ctx, &found, append(opts, client.MatchingLabelsSelector{Selector: selector})...); err != nil {
+			return err
+		}
+		policies = append(policies, found.Items...)
+		return nil
+	}
+	if len(budget.Spec.Namespaces) == 0 {
+		if err := list(); err != nil {
+			return nil, err
+		}
+	}
+	for _, namespace := range budget.Spec.Namespaces {
+		if err := list(client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(policies, func(a, b kedastralv1alpha1.ForecastPolicy) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	return slices.CompactFunc(policies, func(a, b kedastralv1alpha1.ForecastPolicy) bool {
+		return a.Namespace == b.Namespace && a.Name == b.Name
+	}), nil
+}
+
+// setBudgetUsage records usage against maxReplicas on status.
+func setBudgetUsage(status *kedastralv1alpha1.ReplicaBudgetStatus, maxReplicas int, usage BudgetUsage) {
+	status.StartTime = nil
+	if !usage.Start.IsZero() {
+		start := metav1.NewTime(usage.Start)
+		status.StartTime = &start
+	}
+	status.StepSeconds = usage.StepSeconds
+	status.RequestedReplicas = usage.Requested
+	status.AllocatedReplicas = usage.Allocated
+	status.UtilizationPercent = make([]int, len(usage.Allocated))
+	status.PeakUtilizationPercent = 0
+	status.ConstrainedSteps = 0
+	for i, allocated := range usage.Allocated {
+		percent := allocated * 100 / maxReplicas
+		status.UtilizationPercent[i] = percent
+		status.PeakUtilizationPercent = max(status.PeakUtilizationPercent, percent)
+		if usage.Requested[i] > allocated {
+			status.ConstrainedSteps++
+		}
+	}
+}
+
+// fail records a not-ready condition and requeues so the budget self-heals.
+func (r *ReplicaBudgetReconciler) fail(ctx context.Context, budget *kedastralv1alpha1.ReplicaBudget, reason, message string) (ctrl.Result, error) {
+	r.Logger.Warn("replicabudget not ready", "budget", budget.Name, "reason", reason, "message", message)
+
+	meta.SetStatusCondition(&budget.Status.Conditions, metav1.Condition{
+		Type:               "Ready",
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: budget.Generation,
+	})
+	budget.Status.ObservedGeneration = budget.Generation
+
+	if err := r.Status().Update(ctx, budget); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: failureRequeueInterval}, nil
+}
+
+// SetupWithManager registers the reconciler, watching ReplicaBudgets directly and
+// ForecastPolicies via a mapping to every budget, since a label or namespace change
+// can move a policy in or out of any of them. Policy status updates are ignored.
+func (r *ReplicaBudgetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kedastralv1alpha1.ReplicaBudget{}).
+		Watches(&kedastralv1alpha1.ForecastPolicy{}, handler.EnqueueRequestsFromMapFunc(r.allBudgets),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}))).
+		Complete(r)
+}
+
+// allBudgets maps a ForecastPolicy event to reconcile requests for every
+// ReplicaBudget.
+func (r *ReplicaBudgetReconciler) allBudgets(ctx context.Context, _ client.Object) []reconcile.Request {
+	var budgets kedastralv1alpha1.ReplicaBudgetList
+	if err := r.List(ctx, &budgets); err != nil {
+		r.Logger.Error("failed to list replicabudgets", "error", err)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(budgets.Items))
+	for i := range budgets.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&budgets.Items[i])}
+	}
+	return requests
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedastralv1alpha1 "github.com/HatiCode/kedastral/pkg/api/v1alpha1"
+)
+
+// fakeBudgets records the budgets it is given and reports a fixed usage.
+type fakeBudgets struct {
+	budgets map[string]Budget
+	usage   BudgetUsage
+}
+
+func (b *fakeBudgets) SetBudget(name string, budget Budget) { b.budgets[name] = budget }
+
+func (b *fakeBudgets) RemoveBudget(name string) { delete(b.budgets, name) }
+
+func (b *fakeBudgets) BudgetUsage(string, time.Time) BudgetUsage { return b.usage }
+
+func newBudgetReconciler(t *testing.T, budgets BudgetManager, objs ...runtime.Object) *ReplicaBudgetReconciler {
+	t.Helper()
+	builder := fake.NewClientBuilder().
+		WithScheme(testScheme(t)).
+		WithStatusSubresource(&kedastralv1alpha1.ReplicaBudget{}).
+		WithRuntimeObjects(objs...)
+	return &ReplicaBudgetReconciler{
+		Client:  builder.Build(),
+		Budgets: budgets,
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+func labelledPolicy(namespace, name, tier string) *kedastralv1alpha1.ForecastPolicy {
+	policy := basePolicy()
+	policy.Namespace, policy.Name = namespace, name
+	policy.Labels = map[string]string{"tier": tier}
+	return policy
+}
+
+func TestReplicaBudgetReconcile(t *testing.T) {
+	budget := &kedastralv1alpha1.ReplicaBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+		Spec: kedastralv1alpha1.ReplicaBudgetSpec{
+			MaxReplicas: 10,
+			Namespaces:  []string{"shop", "search"},
+			Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "frontend"}},
+			Strategy:    "priority",
+		},
+	}
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	budgets := &fakeBudgets{
+		budgets: map[string]Budget{},
+		usage:   BudgetUsage{Start: start, StepSeconds: 60, Requested: []int{8, 14}, Allocated: []int{8, 10}},
+	}
+	r := newBudgetReconciler(t, budgets, budget,
+		labelledPolicy("shop", "web", "frontend"),
+		labelledPolicy("shop", "batch", "backend"),
+		labelledPolicy("search", "api", "frontend"),
+		labelledPolicy("billing", "web", "frontend"))
+
+	if _, err := r.Reconcile(context.Background(), reconcileRequest("", "quota")); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	want := Budget{MaxReplicas: 10, Strategy: "priority", Members: []string{"search-api", "shop-web"}}
+	if got := budgets.budgets["quota"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("SetBudget() = %+v, want %+v", got, want)
+	}
+
+	var got kedastralv1alpha1.ReplicaBudget
+	if err := r.Get(context.Background(), reconcileRequest("", "quota").NamespacedName, &got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if want := []string{"search/api", "shop/web"}; !reflect.DeepEqual(got.Status.Members, want) {
+		t.Errorf("Members = %v, want %v", got.Status.Members, want)
+	}
+	if want := []int{80, 100}; !reflect.DeepEqual(got.Status.UtilizationPercent, want) {
+		t.Errorf("UtilizationPercent = %v, want %v", got.Status.UtilizationPercent, want)
+	}
+	if got.Status.PeakUtilizationPercent != 100 || got.Status.ConstrainedSteps != 1 {
+		t.Errorf("peak = %d, constrained = %d, want 100 and 1", got.Status.PeakUtilizationPercent, got.Status.ConstrainedSteps)
+	}
+	if got.Status.StartTime == nil || !got.Status.StartTime.Time.Equal(start) {
+		t.Errorf("StartTime = %v, want %v", got.Status.StartTime, start)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, "Ready") {
+		t.Errorf("Ready condition not true: %v", got.Status.Conditions)
+	}
+}
+
+func TestReplicaBudgetReconcile_InvalidSelector(t *testing.T) {
+	budget := &kedastralv1alpha1.ReplicaBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+		Spec: kedastralv1alpha1.ReplicaBudgetSpec{
+			MaxReplicas: 10,
+			Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: "Between"},
+			}},
+		},
+	}
+	budgets := &fakeBudgets{budgets: map[string]Budget{"quota": {MaxReplicas: 5}}}
+	r := newBudgetReconciler(t, budgets, budget)
+
+	if _, err := r.Reconcile(context.Background(), reconcileRequest("", "quota")); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if _, ok := budgets.budgets["quota"]; ok {
+		t.Error("invalid budget still applied")
+	}
+
+	var got kedastralv1alpha1.ReplicaBudget
+	if err := r.Get(context.Background(), reconcileRequest("", "quota").NamespacedName, &got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if cond := meta.FindStatusCondition(got.Status.Conditions, "Ready"); cond == nil || cond.Reason != "InvalidSpec" {
+		t.Errorf("Ready condition = %v, want reason InvalidSpec", cond)
+	}
+}
+
+func TestReplicaBudgetReconcile_Deleted(t *testing.T) {
+	budgets := &fakeBudgets{budgets: map[string]Budget{"quota": {MaxReplicas: 5}}}
+	r := newBudgetReconciler(t, budgets)
+
+	if _, err := r.Reconcile(context.Background(), reconcileRequest("", "quota")); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(budgets.budgets) != 0 {
+		t.Errorf("budgets = %v, want none", budgets.budgets)
+	}
+}
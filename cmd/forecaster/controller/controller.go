@@ -12,8 +12,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,6 +28,8 @@ import (
 
 	"github.com/HatiCode/kedastral/cmd/forecaster/config"
 	kedastralv1alpha1 "github.com/HatiCode/kedastral/pkg/api/v1alpha1"
+	"github.com/HatiCode/kedastral/pkg/capacity"
+	"github.com/HatiCode/kedastral/pkg/models"
 	"github.com/HatiCode/kedastral/pkg/storage"
 )
 
@@ -39,6 +43,24 @@ type ForecasterManager interface {
 	Remove(name string)
 }
 
+// BYOMStatusReporter is optionally implemented by a ForecasterManager to report the
+// health of a workload's bring-your-own-model service, which the reconciler surfaces
+// as the BYOMReady condition.
+type BYOMStatusReporter interface {
+	// BYOMStatus returns the BYOM service status for the workload key, and false if
+	// the workload does not use a BYOM model.
+	BYOMStatus(name string) (models.BYOMStatus, bool)
+}
+
+// RecommendationReporter is optionally implemented by a ForecasterManager to report
+// the targetPerPod recommendations of workloads, which the reconciler surfaces in
+// status.targetRecommendation.
+type RecommendationReporter interface {
+	// TargetRecommendation returns the latest recommendation for the workload key
+	// and when it was computed, and false if there is none yet.
+	TargetRecommendation(name string) (capacity.TargetRecommendation, time.Time, bool)
+}
+
 // ForecastPolicyReconciler reconciles ForecastPolicy resources.
 type ForecastPolicyReconciler struct {
 	client.Client
@@ -73,7 +95,41 @@ func (r *ForecastPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
-	workloadConfig, err := toWorkloadConfig(&policy, &dataSource)
+	sources := make(map[string]*kedastralv1alpha1.DataSource)
+	for _, ref := range extraDataSources(&policy) {
+		if _, ok := sources[ref.name]; ok {
+			continue
+		}
+		var source kedastralv1alpha1.DataSource
+		if err := r.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: ref.name}, &source); err != nil {
+			if apierrors.IsNotFound(err) {
+				return r.fail(ctx, &policy, "DataSourceNotFound", fmt.Sprintf("DataSource %q for %s not found", ref.name, ref.usage))
+			}
+			return ctrl.Result{}, err
+		}
+		sources[ref.name] = &source
+	}
+
+	workloadConfig, err := toWorkloadConfig(&policy, &dataSource, sources)
+	if err != nil {
+		return r.fail(ctx, &policy, "InvalidSpec", err.Error())
+	}
+
+	if auth := policy.Spec.Model.BYOMAuth; auth != nil && workloadConfig.UsesBYOM() {
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: auth.SecretName}, &secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return r.fail(ctx, &policy, "SecretNotFound", fmt.Sprintf("BYOM auth Secret %q not found", auth.SecretName))
+			}
+			return ctrl.Result{}, err
+		}
+		workloadConfig.BYOMAuth, err = byomAuthFromSecret(auth, &secret)
+		if err != nil {
+			return r.fail(ctx, &policy, "InvalidSpec", err.Error())
+		}
+	}
+
+	metadata, err := triggerMetadata(&policy, &dataSource, sources)
 	if err != nil {
 		return r.fail(ctx, &policy, "InvalidSpec", err.Error())
 	}
@@ -82,7 +138,7 @@ func (r *ForecastPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return r.fail(ctx, &policy, "ForecasterError", err.Error())
 	}
 
-	scaledObjectName, err := r.reconcileScaledObject(ctx, &policy, workloadConfig.Name)
+	scaledObjectName, err := r.reconcileScaledObject(ctx, &policy, workloadConfig.Name, metadata)
 	if err != nil {
 		return r.fail(ctx, &policy, "ScaledObjectError", err.Error())
 	}
@@ -134,10 +190,114 @@ func (r *ForecastPolicyReconciler) updateReadyStatus(ctx context.Context, policy
 		Message:            "Forecast loop running and ScaledObject reconciled",
 		ObservedGeneration: policy.Generation,
 	})
+	r.setBYOMCondition(policy, workload)
+	r.setTargetRecommendation(policy, workload)
 
 	return r.Status().Update(ctx, policy)
 }
 
+// setTargetRecommendation records the latest targetPerPod recommendation of a policy
+// with a recommender, and removes it from policies without one.
+func (r *ForecastPolicyReconciler) setTargetRecommendation(policy *kedastralv1alpha1.ForecastPolicy, workload string) {
+	spec := policy.Spec.Capacity.TargetRecommendation
+	if spec == nil {
+		policy.Status.TargetRecommendation = nil
+		return
+	}
+	reporter, ok := r.Manager.(RecommendationReporter)
+	if !ok {
+		return
+	}
+	rec, computedAt, ok := reporter.TargetRecommendation(workload)
+	if !ok {
+		return
+	}
+	policy.Status.TargetRecommendation = &kedastralv1alpha1.TargetRecommendationStatus{
+		TargetPerPod:   rec.TargetPerPod,
+		Confidence:     rec.Confidence,
+		Samples:        rec.Samples,
+		BreachObserved: rec.BreachObserved,
+		Applied:        spec.Apply && rec.Confidence >= spec.MinConfidence,
+		ComputedAt:     metav1.NewTime(computedAt),
+	}
+}
+
+// setBYOMCondition records the BYOMReady condition for policies using a BYOM model,
+// and removes it from policies that do not.
+func (r *ForecastPolicyReconciler) setBYOMCondition(policy *kedastralv1alpha1.ForecastPolicy, workload string) {
+	reporter, ok := r.Manager.(BYOMStatusReporter)
+	if !ok {
+		return
+	}
+	status, ok := reporter.BYOMStatus(workload)
+	if !ok {
+		meta.RemoveStatusCondition(&policy.Status.Conditions, "BYOMReady")
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               "BYOMReady",
+		ObservedGeneration: policy.Generation,
+	}
+	switch {
+	case status.CheckedAt.IsZero():
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "Pending"
+		condition.Message = fmt.Sprintf("BYOM service %s not contacted yet", status.Endpoint)
+	case status.CircuitOpen:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "CircuitOpen"
+		condition.Message = fmt.Sprintf("BYOM service %s failing repeatedly, calls suspended: %s", status.Endpoint, status.LastError)
+	case !status.Healthy:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Unhealthy"
+		condition.Message = status.LastError
+	case status.Info != nil:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Healthy"
+		condition.Message = fmt.Sprintf("BYOM service %s (%s, protocol %s, features %v)",
+			status.Endpoint, status.Info.Name, status.Info.Protocol, status.Info.Features)
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Healthy"
+		condition.Message = fmt.Sprintf("BYOM service %s (predict only)", status.Endpoint)
+	}
+	meta.SetStatusCondition(&policy.Status.Conditions, condition)
+}
+
+// byomAuthFromSecret resolves the bearer token and headers that spec references in
+// secret. Values are trimmed of surrounding whitespace, such as the trailing newline
+// of a Secret created from a file.
+func byomAuthFromSecret(spec *kedastralv1alpha1.BYOMAuthSpec, secret *corev1.Secret) (models.BYOMAuth, error) {
+	value := func(key string) (string, error) {
+		data, ok := secret.Data[key]
+		if !ok {
+			return "", fmt.Errorf("BYOM auth Secret %q has no key %q", secret.Name, key)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	var auth models.BYOMAuth
+	if spec.TokenKey != "" {
+		token, err := value(spec.TokenKey)
+		if err != nil {
+			return models.BYOMAuth{}, err
+		}
+		auth.Token = token
+	}
+	if len(spec.Headers) > 0 {
+		auth.Headers = make(map[string]string, len(spec.Headers))
+		for header, key := range spec.Headers {
+			v, err := value(key)
+			if err != nil {
+				return models.BYOMAuth{}, err
+			}
+			auth.Headers[header] = v
+		}
+	}
+	return auth, nil
+}
+
 // SetupWithManager registers the reconciler, watching ForecastPolicies directly and
 // DataSources via a mapping to their dependent policies.
 func (r *ForecastPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -158,7 +318,7 @@ func (r *ForecastPolicyReconciler) policiesForDataSource(ctx context.Context, ob
 
 	var requests []reconcile.Request
 	for i := range policies.Items {
-		if policies.Items[i].Spec.DataSourceRef.Name != obj.GetName() {
+		if !referencesDataSource(&policies.Items[i], obj.GetName()) {
 			continue
 		}
 		requests = append(requests, reconcile.Request{
@@ -171,6 +331,49 @@ func (r *ForecastPolicyReconciler) policiesForDataSource(ctx context.Context, ob
 	return requests
 }
 
+// dataSourceRef is a DataSource referenced by a policy besides spec.dataSourceRef,
+// with a description of what it is used for.
+type dataSourceRef struct {
+	name  string
+	usage string
+}
+
+// extraDataSources lists the DataSources a policy references for its additional
+// metrics, queue depth, targetPerPod recommender, and reactive live metric.
+func extraDataSources(policy *kedastralv1alpha1.ForecastPolicy) []dataSourceRef {
+	var refs []dataSourceRef
+	for _, m := range policy.Spec.Metrics {
+		refs = append(refs, dataSourceRef{name: m.DataSourceRef.Name, usage: fmt.Sprintf("metric %q", m.Name)})
+	}
+	if queue := policy.Spec.Capacity.Queue; queue != nil {
+		refs = append(refs, dataSourceRef{name: queue.DepthDataSourceRef.Name, usage: "queue depth"})
+	}
+	if rec := policy.Spec.Capacity.TargetRecommendation; rec != nil {
+		refs = append(refs,
+			dataSourceRef{name: rec.LatencyDataSourceRef.Name, usage: "recommender latency"},
+			dataSourceRef{name: rec.ReplicasDataSourceRef.Name, usage: "recommender replicas"})
+	}
+	if reactive := policy.Spec.Reactive; reactive != nil && reactive.DataSourceRef != nil {
+		refs = append(refs, dataSourceRef{name: reactive.DataSourceRef.Name, usage: "reactive live metric"})
+	}
+	return refs
+}
+
+// referencesDataSource reports whether policy collects its metric, any of its
+// additional metrics, its queue depth, its recommender inputs, or its live metric
+// from the named DataSource.
+func referencesDataSource(policy *kedastralv1alpha1.ForecastPolicy, name string) bool {
+	if policy.Spec.DataSourceRef.Name == name {
+		return true
+	}
+	for _, ref := range extraDataSources(policy) {
+		if ref.name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func maxInt(values []int) int {
 	highest := values[0]
 	for _, v := range values[1:] {
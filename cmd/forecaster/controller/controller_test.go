@@ -6,7 +6,11 @@ import (
 	"log/slog"
 	"sync"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -15,6 +19,8 @@ import (
 
 	"github.com/HatiCode/kedastral/cmd/forecaster/config"
 	kedastralv1alpha1 "github.com/HatiCode/kedastral/pkg/api/v1alpha1"
+	"github.com/HatiCode/kedastral/pkg/capacity"
+	"github.com/HatiCode/kedastral/pkg/models"
 	"github.com/HatiCode/kedastral/pkg/storage"
 )
 
@@ -38,12 +44,37 @@ func (m *fakeManager) Remove(name string) {
 	m.removed = append(m.removed, name)
 }
 
+// byomManager is a fakeManager that also reports a BYOM service status.
+type byomManager struct {
+	fakeManager
+	status models.BYOMStatus
+}
+
+func (m *byomManager) BYOMStatus(name string) (models.BYOMStatus, bool) {
+	return m.status, true
+}
+
+// recommendingManager is a fakeManager that also reports a targetPerPod
+// recommendation.
+type recommendingManager struct {
+	fakeManager
+	recommendation capacity.TargetRecommendation
+	computedAt     time.Time
+}
+
+func (m *recommendingManager) TargetRecommendation(name string) (capacity.TargetRecommendation, time.Time, bool) {
+	return m.recommendation, m.computedAt, true
+}
+
 func testScheme(t *testing.T) *runtime.Scheme {
 	t.Helper()
 	scheme := runtime.NewScheme()
 	if err := kedastralv1alpha1.AddToScheme(scheme); err != nil {
 		t.Fatalf("AddToScheme: %v", err)
 	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme: %v", err)
+	}
 	scheme.AddKnownTypeWithName(scaledObjectGVK, &unstructured.Unstructured{})
 	scheme.AddKnownTypeWithName(scaledObjectGVK.GroupVersion().WithKind("ScaledObjectList"), &unstructured.UnstructuredList{})
 	return scheme
@@ -126,6 +157,240 @@ func TestReconcile_Success(t *testing.T) {
 	}
 }
 
+func TestReconcile_BYOMCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     models.BYOMStatus
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{"pending", models.BYOMStatus{Endpoint: "http://byom/predict"}, metav1.ConditionUnknown, "Pending"},
+		{"unhealthy", models.BYOMStatus{CheckedAt: time.Now(), LastError: "byom: http 503"}, metav1.ConditionFalse, "Unhealthy"},
+		{"circuit open", models.BYOMStatus{CheckedAt: time.Now(), LastError: "byom: http 503", CircuitOpen: true}, metav1.ConditionFalse, "CircuitOpen"},
+		{"healthy", models.BYOMStatus{CheckedAt: time.Now(), Healthy: true, Info: &models.BYOMInfo{Protocol: "v1", Name: "prophet"}}, metav1.ConditionTrue, "Healthy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &byomManager{status: tt.status}
+			r := newReconciler(t, manager, storage.NewMemoryStore(), basePolicy(), promDataSource())
+
+			if _, err := r.Reconcile(context.Background(), reconcileRequest("shop", "web")); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			var updated kedastralv1alpha1.ForecastPolicy
+			if err := r.Get(context.Background(), types.NamespacedName{Namespace: "shop", Name: "web"}, &updated); err != nil {
+				t.Fatalf("get policy: %v", err)
+			}
+			condition := meta.FindStatusCondition(updated.Status.Conditions, "BYOMReady")
+			if condition == nil {
+				t.Fatalf("expected BYOMReady condition, got %v", updated.Status.Conditions)
+			}
+			if condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("BYOMReady = %s/%s, want %s/%s", condition.Status, condition.Reason, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestReconcile_BYOMAuth(t *testing.T) {
+	byomPolicy := func() *kedastralv1alpha1.ForecastPolicy {
+		policy := basePolicy()
+		policy.Spec.Model = kedastralv1alpha1.ModelSpec{
+			Type:    "byom",
+			BYOMURL: "http://prophet:8000/predict",
+			BYOMAuth: &kedastralv1alpha1.BYOMAuthSpec{
+				SecretName: "prophet-auth",
+				TokenKey:   "token",
+				Headers:    map[string]string{"X-Api-Key": "api-key"},
+			},
+		}
+		return policy
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prophet-auth", Namespace: "shop"},
+		Data: map[string][]byte{
+			"token":   []byte("s3cret\n"),
+			"api-key": []byte("key"),
+		},
+	}
+
+	t.Run("resolved", func(t *testing.T) {
+		manager := &fakeManager{}
+		r := newReconciler(t, manager, storage.NewMemoryStore(), byomPolicy(), promDataSource(), secret)
+
+		if _, err := r.Reconcile(context.Background(), reconcileRequest("shop", "web")); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if len(manager.upserted) != 1 {
+			t.Fatalf("expected 1 Upsert, got %d", len(manager.upserted))
+		}
+		auth := manager.upserted[0].BYOMAuth
+		if auth.Token != "s3cret" || auth.Headers["X-Api-Key"] != "key" {
+			t.Errorf("BYOMAuth = %+v, want token s3cret and X-Api-Key key", auth)
+		}
+	})
+
+	t.Run("secret not found", func(t *testing.T) {
+		manager := &fakeManager{}
+		r := newReconciler(t, manager, storage.NewMemoryStore(), byomPolicy(), promDataSource())
+
+		if _, err := r.Reconcile(context.Background(), reconcileRequest("shop", "web")); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if len(manager.upserted) != 0 {
+			t.Errorf("Upsert should not be called without the Secret, got %d", len(manager.upserted))
+		}
+
+		var updated kedastralv1alpha1.ForecastPolicy
+		if err := r.Get(context.Background(), types.NamespacedName{Namespace: "shop", Name: "web"}, &updated); err != nil {
+			t.Fatalf("get policy: %v", err)
+		}
+		if condition := meta.FindStatusCondition(updated.Status.Conditions, "Ready"); condition == nil || condition.Reason != "SecretNotFound" {
+			t.Errorf("expected SecretNotFound condition, got %v", updated.Status.Conditions)
+		}
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		policy := byomPolicy()
+		policy.Spec.Model.BYOMAuth.TokenKey = "missing"
+		manager := &fakeManager{}
+		r := newReconciler(t, manager, storage.NewMemoryStore(), policy, promDataSource(), secret)
+
+		if _, err := r.Reconcile(context.Background(), reconcileRequest("shop", "web")); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if len(manager.upserted) != 0 {
+			t.Errorf("Upsert should not be called with a missing Secret key, got %d", len(manager.upserted))
+		}
+	})
+}
+
+func TestReconcile_Activation(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Capacity.MinReplicas = 0
+	policy.Spec.Capacity.PodStartupTime = "90s"
+	policy.Spec.Activation = &kedastralv1alpha1.ActivationSpec{Threshold: 5, DeactivationThreshold: 1.5, Quantile: "p90"}
+	r := newReconciler(t, &fakeManager{}, storage.NewMemoryStore(), policy, promDataSource())
+
+	if _, err := r.Reconcile(context.Background(), reconcileRequest("shop", "web")); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	so := &unstructured.Unstructured{}
+	so.SetGroupVersionKind(scaledObjectGVK)
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: "shop", Name: "web"}, so); err != nil {
+		t.Fatalf("ScaledObject not created: %v", err)
+	}
+	if minReplicas, _, _ := unstructured.NestedInt64(so.Object, "spec", "minReplicaCount"); minReplicas != 0 {
+		t.Errorf("minReplicaCount = %d, want 0", minReplicas)
+	}
+	triggers, _, _ := unstructured.NestedSlice(so.Object, "spec", "triggers")
+	metadata := triggers[0].(map[string]any)["metadata"].(map[string]any)
+	want := map[string]string{
+		"workload":              "shop-web",
+		"activationThreshold":   "5",
+		"deactivationThreshold": "1.5",
+		"activationQuantile":    "p90",
+		"startupTime":           "90s",
+	}
+	for k, v := range want {
+		if metadata[k] != v {
+			t.Errorf("trigger %s = %v, want %s", k, metadata[k], v)
+		}
+	}
+}
+
+func TestReconcile_Reactive(t *testing.T) {
+	live := promDataSource()
+	live.Name = "prom-live"
+	live.Spec.Config = map[string]string{"url": "http://prom:9090", "query": "sum(rate(x[30s]))"}
+
+	policy := basePolicy()
+	policy.Spec.Reactive = &kedastralv1alpha1.ReactiveSpec{
+		DataSourceRef:   &kedastralv1alpha1.DataSourceRef{Name: "prom-live"},
+		CorrectionSigma: 3,
+	}
+	r := newReconciler(t, &fakeManager{}, storage.NewMemoryStore(), policy, promDataSource(), live)
+
+	if _, err := r.Reconcile(context.Background(), reconcileRequest("shop", "web")); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	so := &unstructured.Unstructured{}
+	so.SetGroupVersionKind(scaledObjectGVK)
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: "shop", Name: "web"}, so); err != nil {
+		t.Fatalf("ScaledObject not created: %v", err)
+	}
+	triggers, _, _ := unstructured.NestedSlice(so.Object, "spec", "triggers")
+	metadata := triggers[0].(map[string]any)["metadata"].(map[string]any)
+	want := map[string]string{
+		"reactiveAdapter": "prometheus",
+		"reactive.url":    "http://prom:9090",
+		"reactive.query":  "sum(rate(x[30s]))",
+		"targetPerPod":    "100",
+		"correctionSigma": "3",
+	}
+	for k, v := range want {
+		if metadata[k] != v {
+			t.Errorf("trigger %s = %v, want %s", k, metadata[k], v)
+		}
+	}
+}
+
+func TestReconcile_ScalerFallback(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Capacity.MinReplicas = 2
+	policy.Spec.ScalerFallback = &kedastralv1alpha1.ScalerFallbackSpec{Mode: "decay", DecayTime: "20m"}
+	r := newReconciler(t, &fakeManager{}, storage.NewMemoryStore(), policy, promDataSource())
+
+	if _, err := r.Reconcile(context.Background(), reconcileRequest("shop", "web")); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	so := &unstructured.Unstructured{}
+	so.SetGroupVersionKind(scaledObjectGVK)
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: "shop", Name: "web"}, so); err != nil {
+		t.Fatalf("ScaledObject not created: %v", err)
+	}
+	triggers, _, _ := unstructured.NestedSlice(so.Object, "spec", "triggers")
+	metadata := triggers[0].(map[string]any)["metadata"].(map[string]any)
+	want := map[string]string{"fallbackMode": "decay", "fallbackDecayTime": "20m", "minReplicas": "2"}
+	for k, v := range want {
+		if metadata[k] != v {
+			t.Errorf("trigger %s = %v, want %s", k, metadata[k], v)
+		}
+	}
+	fallback, _, _ := unstructured.NestedMap(so.Object, "spec", "fallback")
+	if fallback["failureThreshold"] != int64(3) || fallback["replicas"] != int64(2) {
+		t.Errorf("ScaledObject fallback = %v, want 3 failures to 2 replicas", fallback)
+	}
+
+	// Removing the fallback from the policy removes it from the ScaledObject.
+	var updated kedastralv1alpha1.ForecastPolicy
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: "shop", Name: "web"}, &updated); err != nil {
+		t.Fatalf("get policy: %v", err)
+	}
+	updated.Spec.ScalerFallback = nil
+	if err := r.Update(context.Background(), &updated); err != nil {
+		t.Fatalf("update policy: %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), reconcileRequest("shop", "web")); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: "shop", Name: "web"}, so); err != nil {
+		t.Fatalf("get ScaledObject: %v", err)
+	}
+	if _, found, _ := unstructured.NestedMap(so.Object, "spec", "fallback"); found {
+		t.Error("ScaledObject fallback should be removed with the policy's")
+	}
+	triggers, _, _ = unstructured.NestedSlice(so.Object, "spec", "triggers")
+	if _, ok := triggers[0].(map[string]any)["metadata"].(map[string]any)["fallbackMode"]; ok {
+		t.Error("trigger fallbackMode should be removed with the policy's fallback")
+	}
+}
+
 func TestReconcile_DataSourceNotFound(t *testing.T) {
 	manager := &fakeManager{}
 	store := storage.NewMemoryStore()
@@ -151,6 +416,75 @@ func TestReconcile_DataSourceNotFound(t *testing.T) {
 	}
 }
 
+func TestReconcile_MetricDataSources(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Metrics = []kedastralv1alpha1.MetricSpec{{
+		Name:          "ws_connections",
+		DataSourceRef: kedastralv1alpha1.DataSourceRef{Name: "gateway"},
+		TargetPerPod:  500,
+	}}
+	gateway := &kedastralv1alpha1.DataSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "shop"},
+		Spec:       kedastralv1alpha1.DataSourceSpec{Type: "http", Config: map[string]string{"url": "http://gateway/metrics"}},
+	}
+
+	manager := &fakeManager{}
+	r := newReconciler(t, manager, storage.NewMemoryStore(), policy, promDataSource())
+	if _, err := r.Reconcile(context.Background(), reconcileRequest("shop", "web")); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(manager.upserted) != 0 {
+		t.Fatalf("Upsert called without the metric's DataSource")
+	}
+
+	manager = &fakeManager{}
+	r = newReconciler(t, manager, storage.NewMemoryStore(), policy, promDataSource(), gateway)
+	if _, err := r.Reconcile(context.Background(), reconcileRequest("shop", "web")); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(manager.upserted) != 1 || len(manager.upserted[0].Metrics) != 1 || manager.upserted[0].Metrics[0].Adapter != "http" {
+		t.Fatalf("upserted = %+v, want one workload with the gateway metric", manager.upserted)
+	}
+
+	requests := r.policiesForDataSource(context.Background(), gateway)
+	if len(requests) != 1 || requests[0].Name != "web" {
+		t.Errorf("policiesForDataSource(gateway) = %v, want [shop/web]", requests)
+	}
+}
+
+func TestReconcile_TargetRecommendation(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Capacity.TargetRecommendation = &kedastralv1alpha1.TargetRecommendationSpec{
+		LatencyDataSourceRef:  kedastralv1alpha1.DataSourceRef{Name: "prom"},
+		ReplicasDataSourceRef: kedastralv1alpha1.DataSourceRef{Name: "prom"},
+		LatencySLO:            0.25,
+		Apply:                 true,
+		MinConfidence:         0.8,
+	}
+	computedAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	manager := &recommendingManager{
+		recommendation: capacity.TargetRecommendation{TargetPerPod: 140, Confidence: 0.9, Samples: 10080, BreachObserved: true},
+		computedAt:     computedAt,
+	}
+	r := newReconciler(t, manager, storage.NewMemoryStore(), policy, promDataSource())
+
+	if _, err := r.Reconcile(context.Background(), reconcileRequest("shop", "web")); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(manager.upserted) != 1 || manager.upserted[0].Recommendation == nil || manager.upserted[0].Recommendation.LatencySLO != 0.25 {
+		t.Fatalf("upserted = %+v, want a workload with the recommender", manager.upserted)
+	}
+
+	var updated kedastralv1alpha1.ForecastPolicy
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: "shop", Name: "web"}, &updated); err != nil {
+		t.Fatalf("get policy: %v", err)
+	}
+	got := updated.Status.TargetRecommendation
+	if got == nil || got.TargetPerPod != 140 || got.Confidence != 0.9 || !got.Applied || !got.ComputedAt.Time.Equal(computedAt) {
+		t.Errorf("status.targetRecommendation = %+v, want 140 at 0.9 confidence, applied", got)
+	}
+}
+
 func TestReconcile_DeletedPolicyRemovesForecaster(t *testing.T) {
 	manager := &fakeManager{}
 	store := storage.NewMemoryStore()
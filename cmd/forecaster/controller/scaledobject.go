@@ -2,13 +2,17 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kedastralv1alpha1 "github.com/HatiCode/kedastral/pkg/api/v1alpha1"
+	"github.com/HatiCode/kedastral/pkg/capacity"
+	"github.com/HatiCode/kedastral/pkg/durationx"
 )
 
 // scaledObjectGVK is the KEDA ScaledObject type. It is handled as an unstructured
@@ -23,8 +27,8 @@ var scaledObjectGVK = schema.GroupVersionKind{
 // reconcileScaledObject creates or updates the KEDA ScaledObject that wires the
 // external scaler to the policy's scale target. The ScaledObject is owned by the
 // ForecastPolicy so it is garbage-collected when the policy is deleted. It returns
-// the ScaledObject name.
-func (r *ForecastPolicyReconciler) reconcileScaledObject(ctx context.Context, policy *kedastralv1alpha1.ForecastPolicy, workload string) (string, error) {
+// the ScaledObject name. extra is added to the trigger metadata.
+func (r *ForecastPolicyReconciler) reconcileScaledObject(ctx context.Context, policy *kedastralv1alpha1.ForecastPolicy, workload string, extra map[string]any) (string, error) {
 	so := &unstructured.Unstructured{}
 	so.SetGroupVersionKind(scaledObjectGVK)
 	so.SetNamespace(policy.Namespace)
@@ -57,12 +61,24 @@ func (r *ForecastPolicyReconciler) reconcileScaledObject(ctx context.Context, po
 			return err
 		}
 
+		if fallback := kedaFallback(policy); fallback != nil {
+			if err := unstructured.SetNestedMap(so.Object, fallback, "spec", "fallback"); err != nil {
+				return err
+			}
+		} else {
+			unstructured.RemoveNestedField(so.Object, "spec", "fallback")
+		}
+
+		metadata := map[string]any{
+			"scalerAddress": r.ScalerAddress,
+			"workload":      workload,
+		}
+		for k, v := range extra {
+			metadata[k] = v
+		}
 		trigger := map[string]any{
-			"type": "external",
-			"metadata": map[string]any{
-				"scalerAddress": r.ScalerAddress,
-				"workload":      workload,
-			},
+			"type":     "external",
+			"metadata": metadata,
 		}
 		if err := unstructured.SetNestedSlice(so.Object, []any{trigger}, "spec", "triggers"); err != nil {
 			return err
@@ -76,3 +92,126 @@ func (r *ForecastPolicyReconciler) reconcileScaledObject(ctx context.Context, po
 
 	return so.GetName(), nil
 }
+
+// triggerMetadata returns the trigger metadata configuring the scaler's
+// forecast-driven activation, hybrid scaling, and fallback, beyond the scaler
+// address and workload. ds is the policy's DataSource and sources its additional
+// ones.
+func triggerMetadata(policy *kedastralv1alpha1.ForecastPolicy, ds *kedastralv1alpha1.DataSource, sources map[string]*kedastralv1alpha1.DataSource) (map[string]any, error) {
+	metadata := make(map[string]any)
+	if err := addActivationMetadata(metadata, policy); err != nil {
+		return nil, err
+	}
+	if err := addReactiveMetadata(metadata, policy, ds, sources); err != nil {
+		return nil, err
+	}
+	if err := addFallbackMetadata(metadata, policy); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// addActivationMetadata adds the settings of the scaler's forecast-driven
+// activation, when the policy configures it.
+func addActivationMetadata(metadata map[string]any, policy *kedastralv1alpha1.ForecastPolicy) error {
+	spec := policy.Spec.Activation
+	if spec == nil {
+		return nil
+	}
+	if spec.DeactivationThreshold > spec.Threshold {
+		return errors.New("activation.deactivationThreshold must not exceed activation.threshold")
+	}
+
+	metadata["activationThreshold"] = strconv.FormatFloat(spec.Threshold, 'g', -1, 64)
+	if spec.DeactivationThreshold > 0 {
+		metadata["deactivationThreshold"] = strconv.FormatFloat(spec.DeactivationThreshold, 'g', -1, 64)
+	}
+	if spec.Quantile != "" {
+		quantile, err := capacity.ParseQuantileLevel(spec.Quantile)
+		if err != nil {
+			return fmt.Errorf("activation.quantile: %w", err)
+		}
+		if quantile > 0 {
+			metadata["activationQuantile"] = spec.Quantile
+		}
+	}
+	if startup := policy.Spec.Capacity.PodStartupTime; startup != "" {
+		metadata["startupTime"] = startup
+	}
+	return nil
+}
+
+// addReactiveMetadata adds the settings of the scaler's hybrid scaling, when the
+// policy configures it: the live metric's adapter, with its configuration under
+// the reactive. prefix, and the target converting it to replicas.
+func addReactiveMetadata(metadata map[string]any, policy *kedastralv1alpha1.ForecastPolicy, ds *kedastralv1alpha1.DataSource, sources map[string]*kedastralv1alpha1.DataSource) error {
+	spec := policy.Spec.Reactive
+	if spec == nil {
+		return nil
+	}
+	capacitySpec := policy.Spec.Capacity
+	if capacitySpec.Mode == "queue" || capacitySpec.Curve != nil || capacitySpec.TargetPerPod <= 0 {
+		return errors.New("reactive requires throughput mode with capacity.targetPerPod and no capacity curve")
+	}
+
+	source := ds
+	if spec.DataSourceRef != nil {
+		source = sources[spec.DataSourceRef.Name]
+	}
+	metadata["reactiveAdapter"] = source.Spec.Type
+	for k, v := range source.Spec.Config {
+		metadata["reactive."+k] = v
+	}
+	metadata["targetPerPod"] = strconv.FormatFloat(capacitySpec.TargetPerPod, 'g', -1, 64)
+	if spec.CorrectionSigma > 0 {
+		metadata["correctionSigma"] = strconv.FormatFloat(spec.CorrectionSigma, 'g', -1, 64)
+	}
+	return nil
+}
+
+// addFallbackMetadata adds the settings of the scaler's fallback, when the policy
+// configures it.
+func addFallbackMetadata(metadata map[string]any, policy *kedastralv1alpha1.ForecastPolicy) error {
+	spec := policy.Spec.ScalerFallback
+	if spec == nil {
+		return nil
+	}
+	if spec.Mode == "fixed" && spec.Replicas == nil {
+		return errors.New("scalerFallback.replicas is required with fallback mode fixed")
+	}
+	if spec.DecayTime != "" {
+		if d, err := durationx.Parse(spec.DecayTime); err != nil || d <= 0 {
+			return fmt.Errorf("scalerFallback.decayTime %q must be a duration > 0", spec.DecayTime)
+		}
+		metadata["fallbackDecayTime"] = spec.DecayTime
+	}
+
+	metadata["fallbackMode"] = spec.Mode
+	if spec.Replicas != nil {
+		metadata["fallbackReplicas"] = strconv.Itoa(*spec.Replicas)
+	}
+	metadata["minReplicas"] = strconv.Itoa(policy.Spec.Capacity.MinReplicas)
+	return nil
+}
+
+// kedaFallback returns the ScaledObject's fallback block, applied by KEDA when the
+// scaler itself fails, or nil when the policy configures no fallback. Its replicas
+// match those the scaler serves before it has seen a fresh forecast.
+func kedaFallback(policy *kedastralv1alpha1.ForecastPolicy) map[string]any {
+	spec := policy.Spec.ScalerFallback
+	if spec == nil {
+		return nil
+	}
+	replicas := policy.Spec.Capacity.MinReplicas
+	if spec.Replicas != nil {
+		replicas = *spec.Replicas
+	}
+	failureThreshold := spec.FailureThreshold
+	if failureThreshold <= 0 {
+		failureThreshold = 3
+	}
+	return map[string]any{
+		"failureThreshold": int64(failureThreshold),
+		"replicas":         int64(replicas),
+	}
+}
@@ -1,10 +1,12 @@
 package controller
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/HatiCode/kedastral/cmd/forecaster/config"
 	kedastralv1alpha1 "github.com/HatiCode/kedastral/pkg/api/v1alpha1"
+	"github.com/HatiCode/kedastral/pkg/capacity"
 	"github.com/HatiCode/kedastral/pkg/durationx"
 )
 
@@ -25,9 +27,10 @@ func parseDurationOr(value string, fallback time.Duration) (time.Duration, error
 }
 
 // toWorkloadConfig translates a ForecastPolicy and its referenced DataSource into the
-// internal WorkloadConfig used by the forecast pipeline. The returned config is
+// internal WorkloadConfig used by the forecast pipeline. sources holds the additional
+// DataSources referenced by the policy (spec.metrics and capacity.queue), keyed by name. The returned config is
 // validated and normalized with the same defaults as flag mode.
-func toWorkloadConfig(policy *kedastralv1alpha1.ForecastPolicy, ds *kedastralv1alpha1.DataSource) (config.WorkloadConfig, error) {
+func toWorkloadConfig(policy *kedastralv1alpha1.ForecastPolicy, ds *kedastralv1alpha1.DataSource, sources map[string]*kedastralv1alpha1.DataSource) (config.WorkloadConfig, error) {
 	horizon, err := parseDurationOr(policy.Spec.Forecast.Horizon, 30*time.Minute)
 	if err != nil {
 		return config.WorkloadConfig{}, err
@@ -44,6 +47,18 @@ func toWorkloadConfig(policy *kedastralv1alpha1.ForecastPolicy, ds *kedastralv1a
 	if err != nil {
 		return config.WorkloadConfig{}, err
 	}
+	trainInterval, err := parseDurationOr(policy.Spec.Forecast.TrainInterval, 0)
+	if err != nil {
+		return config.WorkloadConfig{}, err
+	}
+	trainTimeout, err := parseDurationOr(policy.Spec.Forecast.TrainTimeout, 5*time.Second)
+	if err != nil {
+		return config.WorkloadConfig{}, err
+	}
+	podStartup, err := parseDurationOr(policy.Spec.Capacity.PodStartupTime, 0)
+	if err != nil {
+		return config.WorkloadConfig{}, err
+	}
 
 	model := policy.Spec.Model.Type
 	if model == "" {
@@ -57,6 +72,7 @@ func toWorkloadConfig(policy *kedastralv1alpha1.ForecastPolicy, ds *kedastralv1a
 
 	wc := config.WorkloadConfig{
 		Name:                  workloadKey(policy.Namespace, policy.Name),
+		Policy:                policy.Namespace + "/" + policy.Name,
 		Metric:                policy.Spec.Metric,
 		Adapter:               ds.Spec.Type,
 		AdapterConfig:         ds.Spec.Config,
@@ -64,6 +80,8 @@ func toWorkloadConfig(policy *kedastralv1alpha1.ForecastPolicy, ds *kedastralv1a
 		Step:                  step,
 		Interval:              interval,
 		Window:                window,
+		TrainInterval:         trainInterval,
+		TrainTimeout:          trainTimeout,
 		Model:                 model,
 		TargetPerPod:          policy.Spec.Capacity.TargetPerPod,
 		Headroom:              policy.Spec.Capacity.Headroom,
@@ -72,7 +90,10 @@ func toWorkloadConfig(policy *kedastralv1alpha1.ForecastPolicy, ds *kedastralv1a
 		MaxReplicas:           policy.Spec.Capacity.MaxReplicas,
 		UpMaxFactorPerStep:    policy.Spec.Capacity.UpMaxFactorPerStep,
 		DownMaxPercentPerStep: policy.Spec.Capacity.DownMaxPercentPerStep,
+		PodStartupTime:        podStartup,
+		Priority:              policy.Spec.Capacity.Priority,
 		BYOMURL:               policy.Spec.Model.BYOMURL,
+		PersistModel:          policy.Spec.Model.PersistState,
 	}
 
 	if policy.Spec.Model.ARIMA != nil {
@@ -91,9 +112,176 @@ func toWorkloadConfig(policy *kedastralv1alpha1.ForecastPolicy, ds *kedastralv1a
 		wc.SARIMA_S = policy.Spec.Model.SARIMA.SeasonalPeriod
 	}
 
+	if fallback := policy.Spec.Model.Fallback; fallback != nil {
+		wc.FallbackModels = fallback.Models
+		wc.FallbackMaxJump = fallback.MaxJump
+	}
+
+	if len(policy.Spec.Metrics) > 0 {
+		wc.Combine = policy.Spec.Capacity.Combine
+		wc.Weight = policy.Spec.Capacity.Weight
+		for _, m := range policy.Spec.Metrics {
+			source, ok := sources[m.DataSourceRef.Name]
+			if !ok {
+				return config.WorkloadConfig{}, fmt.Errorf("metric %q: DataSource %q not found", m.Name, m.DataSourceRef.Name)
+			}
+			wc.Metrics = append(wc.Metrics, config.MetricConfig{
+				Name:          m.Name,
+				Adapter:       source.Spec.Type,
+				AdapterConfig: source.Spec.Config,
+				Model:         m.Model,
+				TargetPerPod:  m.TargetPerPod,
+				Weight:        m.Weight,
+			})
+		}
+	}
+
+	for _, o := range policy.Spec.Capacity.Overrides {
+		wc.Overrides = append(wc.Overrides, config.OverrideConfig{
+			Name:            o.Name,
+			Schedule:        o.Schedule,
+			Timezone:        o.Timezone,
+			MinReplicas:     o.MinReplicas,
+			MaxReplicas:     o.MaxReplicas,
+			FreezeScaleDown: o.FreezeScaleDown,
+		})
+	}
+
+	if curve := policy.Spec.Capacity.Curve; curve != nil {
+		wc.Curve, err = toCurve(curve)
+		if err != nil {
+			return config.WorkloadConfig{}, err
+		}
+	}
+
+	if recommendation := policy.Spec.Capacity.TargetRecommendation; recommendation != nil {
+		wc.Recommendation, err = toRecommendation(recommendation, sources)
+		if err != nil {
+			return config.WorkloadConfig{}, err
+		}
+	}
+
+	if stabilization := policy.Spec.Capacity.Stabilization; stabilization != nil {
+		wc.ScaleDownWindow, err = parseDurationOr(stabilization.ScaleDownWindow, 0)
+		if err != nil {
+			return config.WorkloadConfig{}, err
+		}
+		wc.ScaleUpHold, err = parseDurationOr(stabilization.ScaleUpHold, 0)
+		if err != nil {
+			return config.WorkloadConfig{}, err
+		}
+		wc.DeadBandReplicas = stabilization.DeadBandReplicas
+		wc.DeadBandPercent = stabilization.DeadBandPercent
+	}
+
+	wc.CapacityMode = policy.Spec.Capacity.Mode
+	wc.Planner = policy.Spec.Capacity.Planner
+	if cost := policy.Spec.Capacity.Cost; cost != nil {
+		wc.ReplicaHourCost = cost.ReplicaHourCost
+		wc.UnderProvisionPenalty = cost.UnderProvisionPenalty
+	}
+	if queue := policy.Spec.Capacity.Queue; queue != nil {
+		drain, err := parseDurationOr(queue.DrainTime, 5*time.Minute)
+		if err != nil {
+			return config.WorkloadConfig{}, err
+		}
+		source, ok := sources[queue.DepthDataSourceRef.Name]
+		if !ok {
+			return config.WorkloadConfig{}, fmt.Errorf("queue depth DataSource %q not found", queue.DepthDataSourceRef.Name)
+		}
+		wc.QueueServiceRate = queue.ServiceRatePerPod
+		wc.QueueDrainTime = drain
+		wc.QueueDepthAdapter = source.Spec.Type
+		wc.QueueDepthAdapterConfig = source.Spec.Config
+	}
+
+	if conformal := policy.Spec.Model.Conformal; conformal != nil && conformal.Enabled {
+		wc.ConformalEnabled = true
+		wc.ConformalWindow = conformal.Window
+	}
+
+	if resampling := policy.Spec.Resampling; resampling != nil && resampling.Enabled {
+		season, err := parseDurationOr(resampling.Season, 24*time.Hour)
+		if err != nil {
+			return config.WorkloadConfig{}, err
+		}
+		maxGap, err := parseDurationOr(resampling.MaxGap, 15*time.Minute)
+		if err != nil {
+			return config.WorkloadConfig{}, err
+		}
+		wc.ResampleEnabled = true
+		wc.ResampleAggregate = resampling.Aggregate
+		wc.ResampleFill = resampling.Fill
+		wc.ResampleSeason = season
+		wc.ResampleMaxGap = maxGap
+	}
+
+	if cleaning := policy.Spec.Cleaning; cleaning != nil && cleaning.Enabled {
+		maxGap, err := parseDurationOr(cleaning.MaxGap, 10*time.Minute)
+		if err != nil {
+			return config.WorkloadConfig{}, err
+		}
+		wc.CleanEnabled = true
+		wc.CleanOutlierWindow = cleaning.OutlierWindow
+		wc.CleanOutlierThreshold = cleaning.OutlierThreshold
+		wc.CleanReplacement = cleaning.Replacement
+		wc.CleanMaxGap = maxGap
+	}
+
 	if err := config.ValidateWorkload(&wc); err != nil {
 		return config.WorkloadConfig{}, err
 	}
 
 	return wc, nil
 }
+
+// toCurve builds the capacity curve of a policy, fitting the USL to its points when
+// no parameters are given.
+func toCurve(spec *kedastralv1alpha1.CapacityCurveSpec) (*capacity.Curve, error) {
+	points := make([]capacity.CurvePoint, len(spec.Points))
+	for i, pt := range spec.Points {
+		points[i] = capacity.CurvePoint{Replicas: pt.Replicas, Load: pt.Load}
+	}
+	if spec.Model != "usl" {
+		return &capacity.Curve{Points: points}, nil
+	}
+	if spec.USL != nil {
+		return &capacity.Curve{USL: &capacity.USL{Lambda: spec.USL.Lambda, Sigma: spec.USL.Sigma, Kappa: spec.USL.Kappa}}, nil
+	}
+	usl, err := capacity.FitUSL(points)
+	if err != nil {
+		return nil, fmt.Errorf("capacity curve: %w", err)
+	}
+	return &capacity.Curve{USL: &usl}, nil
+}
+
+// toRecommendation builds the targetPerPod recommender configuration of a policy.
+func toRecommendation(spec *kedastralv1alpha1.TargetRecommendationSpec, sources map[string]*kedastralv1alpha1.DataSource) (*config.RecommendationConfig, error) {
+	lookback, err := parseDurationOr(spec.Lookback, 7*24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := parseDurationOr(spec.Interval, time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	latency, ok := sources[spec.LatencyDataSourceRef.Name]
+	if !ok {
+		return nil, fmt.Errorf("latency DataSource %q not found", spec.LatencyDataSourceRef.Name)
+	}
+	replicas, ok := sources[spec.ReplicasDataSourceRef.Name]
+	if !ok {
+		return nil, fmt.Errorf("replicas DataSource %q not found", spec.ReplicasDataSourceRef.Name)
+	}
+	return &config.RecommendationConfig{
+		LatencyAdapter:        latency.Spec.Type,
+		LatencyAdapterConfig:  latency.Spec.Config,
+		ReplicasAdapter:       replicas.Spec.Type,
+		ReplicasAdapterConfig: replicas.Spec.Config,
+		LatencySLO:            spec.LatencySLO,
+		Lookback:              lookback,
+		Interval:              interval,
+		Apply:                 spec.Apply,
+		MinConfidence:         spec.MinConfidence,
+	}, nil
+}
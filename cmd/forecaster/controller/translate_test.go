@@ -45,7 +45,7 @@ func TestWorkloadKey(t *testing.T) {
 }
 
 func TestToWorkloadConfig_Defaults(t *testing.T) {
-	wc, err := toWorkloadConfig(basePolicy(), promDataSource())
+	wc, err := toWorkloadConfig(basePolicy(), promDataSource(), nil)
 	if err != nil {
 		t.Fatalf("toWorkloadConfig() error = %v", err)
 	}
@@ -53,6 +53,9 @@ func TestToWorkloadConfig_Defaults(t *testing.T) {
 	if wc.Name != "shop-web" {
 		t.Errorf("Name = %q, want shop-web", wc.Name)
 	}
+	if wc.Policy != "shop/web" {
+		t.Errorf("Policy = %q, want shop/web", wc.Policy)
+	}
 	if wc.Adapter != "prometheus" {
 		t.Errorf("Adapter = %q, want prometheus", wc.Adapter)
 	}
@@ -81,7 +84,7 @@ func TestToWorkloadConfig_ARIMA(t *testing.T) {
 	}
 	policy.Spec.Forecast = kedastralv1alpha1.ForecastSpec{Horizon: "1h", Step: "5m", Interval: "1m", Window: "2h"}
 
-	wc, err := toWorkloadConfig(policy, promDataSource())
+	wc, err := toWorkloadConfig(policy, promDataSource(), nil)
 	if err != nil {
 		t.Fatalf("toWorkloadConfig() error = %v", err)
 	}
@@ -101,7 +104,7 @@ func TestToWorkloadConfig_InvalidDuration(t *testing.T) {
 	policy := basePolicy()
 	policy.Spec.Forecast.Horizon = "not-a-duration"
 
-	if _, err := toWorkloadConfig(policy, promDataSource()); err == nil {
+	if _, err := toWorkloadConfig(policy, promDataSource(), nil); err == nil {
 		t.Fatal("expected error for invalid duration, got nil")
 	}
 }
@@ -110,7 +113,468 @@ func TestToWorkloadConfig_FailsValidation(t *testing.T) {
 	policy := basePolicy()
 	policy.Spec.Capacity.TargetPerPod = 0 // invalid: must be > 0
 
-	if _, err := toWorkloadConfig(policy, promDataSource()); err == nil {
+	if _, err := toWorkloadConfig(policy, promDataSource(), nil); err == nil {
 		t.Fatal("expected validation error for zero targetPerPod, got nil")
 	}
 }
+
+func TestToWorkloadConfig_Cleaning(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Cleaning = &kedastralv1alpha1.CleaningSpec{
+		Enabled:       true,
+		OutlierWindow: 7,
+		Replacement:   "interpolate",
+		MaxGap:        "5m",
+	}
+
+	wc, err := toWorkloadConfig(policy, promDataSource(), nil)
+	if err != nil {
+		t.Fatalf("toWorkloadConfig() error = %v", err)
+	}
+
+	if !wc.CleanEnabled {
+		t.Fatal("CleanEnabled = false, want true")
+	}
+	if wc.CleanOutlierWindow != 7 {
+		t.Errorf("CleanOutlierWindow = %d, want 7", wc.CleanOutlierWindow)
+	}
+	if wc.CleanOutlierThreshold != 3.0 {
+		t.Errorf("CleanOutlierThreshold = %v, want 3 default", wc.CleanOutlierThreshold)
+	}
+	if wc.CleanReplacement != "interpolate" {
+		t.Errorf("CleanReplacement = %q, want interpolate", wc.CleanReplacement)
+	}
+	if wc.CleanMaxGap != 5*time.Minute {
+		t.Errorf("CleanMaxGap = %v, want 5m", wc.CleanMaxGap)
+	}
+}
+
+func TestToWorkloadConfig_InvalidCleaningReplacement(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Cleaning = &kedastralv1alpha1.CleaningSpec{Enabled: true, Replacement: "mean"}
+
+	if _, err := toWorkloadConfig(policy, promDataSource(), nil); err == nil {
+		t.Fatal("expected validation error for invalid replacement, got nil")
+	}
+}
+
+func TestToWorkloadConfig_Resampling(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Resampling = &kedastralv1alpha1.ResamplingSpec{
+		Enabled:   true,
+		Aggregate: "sum",
+		Fill:      "seasonal",
+		Season:    "1d",
+	}
+
+	wc, err := toWorkloadConfig(policy, promDataSource(), nil)
+	if err != nil {
+		t.Fatalf("toWorkloadConfig() error = %v", err)
+	}
+
+	if !wc.ResampleEnabled {
+		t.Fatal("ResampleEnabled = false, want true")
+	}
+	if wc.ResampleAggregate != "sum" || wc.ResampleFill != "seasonal" {
+		t.Errorf("aggregate/fill = %q/%q, want sum/seasonal", wc.ResampleAggregate, wc.ResampleFill)
+	}
+	if wc.ResampleSeason != 24*time.Hour {
+		t.Errorf("ResampleSeason = %v, want 24h", wc.ResampleSeason)
+	}
+	if wc.ResampleMaxGap != 15*time.Minute {
+		t.Errorf("ResampleMaxGap = %v, want 15m default", wc.ResampleMaxGap)
+	}
+}
+
+func TestToWorkloadConfig_InvalidResampleFill(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Resampling = &kedastralv1alpha1.ResamplingSpec{Enabled: true, Fill: "cubic"}
+
+	if _, err := toWorkloadConfig(policy, promDataSource(), nil); err == nil {
+		t.Fatal("expected validation error for invalid fill, got nil")
+	}
+}
+
+func TestToWorkloadConfig_BYOMGRPC(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"byom+grpc://prophet.models.svc:9090", false},
+		{"byom+grpc://", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			policy := basePolicy()
+			policy.Spec.Model.Type = "byom"
+			policy.Spec.Model.BYOMURL = tt.url
+
+			_, err := toWorkloadConfig(policy, promDataSource(), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("toWorkloadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToWorkloadConfig_PersistState(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Model.PersistState = true
+
+	wc, err := toWorkloadConfig(policy, promDataSource(), nil)
+	if err != nil {
+		t.Fatalf("toWorkloadConfig() error = %v", err)
+	}
+	if !wc.PersistModel {
+		t.Error("PersistModel = false, want true")
+	}
+}
+
+func TestToWorkloadConfig_TrainInterval(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Forecast.TrainInterval = "10m"
+	policy.Spec.Forecast.TrainTimeout = "1m"
+
+	wc, err := toWorkloadConfig(policy, promDataSource(), nil)
+	if err != nil {
+		t.Fatalf("toWorkloadConfig() error = %v", err)
+	}
+	if wc.TrainInterval != 10*time.Minute || wc.TrainTimeout != time.Minute {
+		t.Errorf("TrainInterval/TrainTimeout = %v/%v, want 10m/1m", wc.TrainInterval, wc.TrainTimeout)
+	}
+
+	policy.Spec.Forecast.TrainInterval = "1s"
+	if _, err := toWorkloadConfig(policy, promDataSource(), nil); err == nil {
+		t.Error("expected error for train interval shorter than interval")
+	}
+}
+
+func TestToWorkloadConfig_Conformal(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Model.Conformal = &kedastralv1alpha1.ConformalSpec{Enabled: true}
+
+	wc, err := toWorkloadConfig(policy, promDataSource(), nil)
+	if err != nil {
+		t.Fatalf("toWorkloadConfig() error = %v", err)
+	}
+	if !wc.ConformalEnabled || wc.ConformalWindow != 100 {
+		t.Errorf("ConformalEnabled/Window = %v/%d, want true/100", wc.ConformalEnabled, wc.ConformalWindow)
+	}
+}
+
+func TestToWorkloadConfig_Fallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		fallback kedastralv1alpha1.FallbackSpec
+		wantJump float64
+		wantErr  bool
+	}{
+		{"chain", kedastralv1alpha1.FallbackSpec{Models: []string{"baseline", "last-value"}}, 10, false},
+		{"max jump", kedastralv1alpha1.FallbackSpec{Models: []string{"last-value"}, MaxJump: 4}, 4, false},
+		{"primary repeated", kedastralv1alpha1.FallbackSpec{Models: []string{"arima"}}, 0, true},
+		{"byom without url", kedastralv1alpha1.FallbackSpec{Models: []string{"byom"}}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := basePolicy()
+			policy.Spec.Model.Type = "arima"
+			policy.Spec.Model.Fallback = &tt.fallback
+
+			wc, err := toWorkloadConfig(policy, promDataSource(), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("toWorkloadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(wc.FallbackModels) != len(tt.fallback.Models) || wc.FallbackMaxJump != tt.wantJump {
+				t.Errorf("fallback = %v (max jump %v), want %v (max jump %v)", wc.FallbackModels, wc.FallbackMaxJump, tt.fallback.Models, tt.wantJump)
+			}
+		})
+	}
+}
+
+func TestToWorkloadConfig_Metrics(t *testing.T) {
+	ws := &kedastralv1alpha1.DataSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "shop"},
+		Spec:       kedastralv1alpha1.DataSourceSpec{Type: "http", Config: map[string]string{"url": "http://gateway/metrics"}},
+	}
+	sources := map[string]*kedastralv1alpha1.DataSource{"gateway": ws}
+
+	tests := []struct {
+		name    string
+		mutate  func(*kedastralv1alpha1.ForecastPolicy)
+		sources map[string]*kedastralv1alpha1.DataSource
+		wantErr bool
+	}{
+		{"defaults", func(p *kedastralv1alpha1.ForecastPolicy) {}, sources, false},
+		{"missing datasource", func(p *kedastralv1alpha1.ForecastPolicy) {}, nil, true},
+		{"duplicate of primary", func(p *kedastralv1alpha1.ForecastPolicy) { p.Spec.Metrics[0].Name = "http_rps" }, sources, true},
+		{"no target", func(p *kedastralv1alpha1.ForecastPolicy) { p.Spec.Metrics[0].TargetPerPod = 0 }, sources, true},
+		{"invalid combine", func(p *kedastralv1alpha1.ForecastPolicy) { p.Spec.Capacity.Combine = "min" }, sources, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := basePolicy()
+			policy.Spec.Model.Type = "arima"
+			policy.Spec.Metrics = []kedastralv1alpha1.MetricSpec{{
+				Name:          "ws_connections",
+				DataSourceRef: kedastralv1alpha1.DataSourceRef{Name: "gateway"},
+				TargetPerPod:  500,
+			}}
+			tt.mutate(policy)
+
+			wc, err := toWorkloadConfig(policy, promDataSource(), tt.sources)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("toWorkloadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if wc.Combine != "max" {
+				t.Errorf("Combine = %q, want max", wc.Combine)
+			}
+			if len(wc.Metrics) != 1 {
+				t.Fatalf("len(Metrics) = %d, want 1", len(wc.Metrics))
+			}
+			m := wc.Metrics[0]
+			if m.Adapter != "http" || m.Model != "arima" || m.TargetPerPod != 500 {
+				t.Errorf("metric = %+v, want http adapter, arima model, target 500", m)
+			}
+
+			signal := wc.ForMetric(m)
+			if signal.Name != "shop-web_ws_connections" || signal.Metric != "ws_connections" || signal.Metrics != nil {
+				t.Errorf("ForMetric() = name %q metric %q metrics %v", signal.Name, signal.Metric, signal.Metrics)
+			}
+		})
+	}
+}
+
+func TestToWorkloadConfig_Queue(t *testing.T) {
+	depth := &kedastralv1alpha1.DataSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "depth", Namespace: "shop"},
+		Spec:       kedastralv1alpha1.DataSourceSpec{Type: "prometheus", Config: map[string]string{"url": "http://prom:9090", "query": "sum(queue_depth)"}},
+	}
+	sources := map[string]*kedastralv1alpha1.DataSource{"depth": depth}
+
+	tests := []struct {
+		name    string
+		mutate  func(*kedastralv1alpha1.ForecastPolicy)
+		sources map[string]*kedastralv1alpha1.DataSource
+		wantErr bool
+	}{
+		{"defaults", func(p *kedastralv1alpha1.ForecastPolicy) {}, sources, false},
+		{"missing datasource", func(p *kedastralv1alpha1.ForecastPolicy) {}, nil, true},
+		{"no service rate", func(p *kedastralv1alpha1.ForecastPolicy) { p.Spec.Capacity.Queue.ServiceRatePerPod = 0 }, sources, true},
+		{"no queue spec", func(p *kedastralv1alpha1.ForecastPolicy) { p.Spec.Capacity.Queue = nil }, sources, true},
+		{"invalid drain time", func(p *kedastralv1alpha1.ForecastPolicy) { p.Spec.Capacity.Queue.DrainTime = "soon" }, sources, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := basePolicy()
+			policy.Spec.Capacity.TargetPerPod = 0
+			policy.Spec.Capacity.Mode = "queue"
+			policy.Spec.Capacity.Queue = &kedastralv1alpha1.QueueSpec{
+				DepthDataSourceRef: kedastralv1alpha1.DataSourceRef{Name: "depth"},
+				ServiceRatePerPod:  20,
+			}
+			tt.mutate(policy)
+
+			wc, err := toWorkloadConfig(policy, promDataSource(), tt.sources)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("toWorkloadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if wc.CapacityMode != "queue" || wc.QueueServiceRate != 20 || wc.QueueDrainTime != 5*time.Minute {
+				t.Errorf("queue config = mode %q rate %v drain %v", wc.CapacityMode, wc.QueueServiceRate, wc.QueueDrainTime)
+			}
+			if wc.QueueDepthAdapter != "prometheus" || wc.QueueDepthAdapterConfig["query"] != "sum(queue_depth)" {
+				t.Errorf("depth adapter = %q %v", wc.QueueDepthAdapter, wc.QueueDepthAdapterConfig)
+			}
+		})
+	}
+}
+
+func TestToWorkloadConfig_CostPlanner(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*kedastralv1alpha1.ForecastPolicy)
+		wantErr bool
+	}{
+		{"valid", func(p *kedastralv1alpha1.ForecastPolicy) {}, false},
+		{"no cost", func(p *kedastralv1alpha1.ForecastPolicy) { p.Spec.Capacity.Cost = nil }, true},
+		{"no penalty", func(p *kedastralv1alpha1.ForecastPolicy) { p.Spec.Capacity.Cost.UnderProvisionPenalty = 0 }, true},
+		{"invalid planner", func(p *kedastralv1alpha1.ForecastPolicy) { p.Spec.Capacity.Planner = "greedy" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := basePolicy()
+			policy.Spec.Capacity.Planner = "cost"
+			policy.Spec.Capacity.Cost = &kedastralv1alpha1.CostSpec{ReplicaHourCost: 0.04, UnderProvisionPenalty: 1}
+			tt.mutate(policy)
+
+			wc, err := toWorkloadConfig(policy, promDataSource(), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("toWorkloadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if wc.Planner != "cost" || wc.ReplicaHourCost != 0.04 || wc.UnderProvisionPenalty != 1 {
+				t.Errorf("planner = %q, costs %v / %v", wc.Planner, wc.ReplicaHourCost, wc.UnderProvisionPenalty)
+			}
+		})
+	}
+}
+
+func TestToWorkloadConfig_PodStartupTime(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Capacity.PodStartupTime = "90s"
+	wc, err := toWorkloadConfig(policy, promDataSource(), nil)
+	if err != nil {
+		t.Fatalf("toWorkloadConfig() error = %v", err)
+	}
+	if wc.PodStartupTime != 90*time.Second {
+		t.Errorf("PodStartupTime = %v, want 90s", wc.PodStartupTime)
+	}
+
+	policy.Spec.Capacity.PodStartupTime = "slow"
+	if _, err := toWorkloadConfig(policy, promDataSource(), nil); err == nil {
+		t.Error("expected error for invalid podStartupTime")
+	}
+}
+
+func TestToWorkloadConfig_Priority(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Capacity.Priority = 10
+	wc, err := toWorkloadConfig(policy, promDataSource(), nil)
+	if err != nil {
+		t.Fatalf("toWorkloadConfig() error = %v", err)
+	}
+	if wc.Priority != 10 {
+		t.Errorf("Priority = %d, want 10", wc.Priority)
+	}
+}
+
+func TestToWorkloadConfig_Stabilization(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Capacity.Stabilization = &kedastralv1alpha1.StabilizationSpec{
+		ScaleDownWindow:  "5m",
+		ScaleUpHold:      "2m",
+		DeadBandReplicas: 1,
+		DeadBandPercent:  10,
+	}
+	wc, err := toWorkloadConfig(policy, promDataSource(), nil)
+	if err != nil {
+		t.Fatalf("toWorkloadConfig() error = %v", err)
+	}
+	if wc.ScaleDownWindow != 5*time.Minute || wc.ScaleUpHold != 2*time.Minute {
+		t.Errorf("window = %v, hold = %v, want 5m and 2m", wc.ScaleDownWindow, wc.ScaleUpHold)
+	}
+	if wc.DeadBandReplicas != 1 || wc.DeadBandPercent != 10 {
+		t.Errorf("dead band = %d replicas / %d%%, want 1 / 10%%", wc.DeadBandReplicas, wc.DeadBandPercent)
+	}
+
+	policy.Spec.Capacity.Stabilization.ScaleUpHold = "a while"
+	if _, err := toWorkloadConfig(policy, promDataSource(), nil); err == nil {
+		t.Error("expected error for invalid scaleUpHold")
+	}
+}
+
+func TestToWorkloadConfig_Curve(t *testing.T) {
+	points := []kedastralv1alpha1.CurvePointSpec{{Replicas: 1, Load: 50}, {Replicas: 2, Load: 100}, {Replicas: 4, Load: 200}}
+
+	policy := basePolicy()
+	policy.Spec.Capacity.Curve = &kedastralv1alpha1.CapacityCurveSpec{Points: points}
+	wc, err := toWorkloadConfig(policy, promDataSource(), nil)
+	if err != nil {
+		t.Fatalf("toWorkloadConfig() error = %v", err)
+	}
+	if wc.Curve == nil || len(wc.Curve.Points) != 3 || wc.Curve.USL != nil {
+		t.Errorf("Curve = %+v, want 3 piecewise points", wc.Curve)
+	}
+
+	policy.Spec.Capacity.Curve.Model = "usl"
+	wc, err = toWorkloadConfig(policy, promDataSource(), nil)
+	if err != nil {
+		t.Fatalf("toWorkloadConfig(usl) error = %v", err)
+	}
+	if wc.Curve == nil || wc.Curve.USL == nil || wc.Curve.USL.Lambda < 49.999 || wc.Curve.USL.Lambda > 50.001 {
+		t.Errorf("Curve = %+v, want a USL fitted with lambda 50", wc.Curve)
+	}
+
+	policy.Spec.Capacity.Curve.Points = points[:2]
+	if _, err := toWorkloadConfig(policy, promDataSource(), nil); err == nil {
+		t.Error("expected error fitting a USL to 2 points")
+	}
+}
+
+func TestToWorkloadConfig_Overrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*kedastralv1alpha1.ScheduledOverride)
+		wantErr bool
+	}{
+		{"valid", func(o *kedastralv1alpha1.ScheduledOverride) {}, false},
+		{"invalid schedule", func(o *kedastralv1alpha1.ScheduledOverride) { o.Schedule = "weekdays" }, true},
+		{"invalid timezone", func(o *kedastralv1alpha1.ScheduledOverride) { o.Timezone = "Mars/Olympus" }, true},
+		{"max below min", func(o *kedastralv1alpha1.ScheduledOverride) { o.MaxReplicas = 5 }, true},
+		{"no effect", func(o *kedastralv1alpha1.ScheduledOverride) { o.MinReplicas = 0 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			override := kedastralv1alpha1.ScheduledOverride{
+				Name:        "business-hours",
+				Schedule:    "Mon-Fri 08:00-10:00",
+				Timezone:    "UTC",
+				MinReplicas: 10,
+			}
+			tt.mutate(&override)
+			policy := basePolicy()
+			policy.Spec.Capacity.Overrides = []kedastralv1alpha1.ScheduledOverride{override}
+
+			wc, err := toWorkloadConfig(policy, promDataSource(), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("toWorkloadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(wc.Overrides) != 1 || wc.Overrides[0].Name != "business-hours" || wc.Overrides[0].MinReplicas != 10 {
+				t.Errorf("Overrides = %+v", wc.Overrides)
+			}
+		})
+	}
+}
+
+func TestToWorkloadConfig_TargetRecommendation(t *testing.T) {
+	policy := basePolicy()
+	policy.Spec.Capacity.TargetRecommendation = &kedastralv1alpha1.TargetRecommendationSpec{
+		LatencyDataSourceRef:  kedastralv1alpha1.DataSourceRef{Name: "prom"},
+		ReplicasDataSourceRef: kedastralv1alpha1.DataSourceRef{Name: "prom"},
+		LatencySLO:            0.25,
+		Lookback:              "3d",
+	}
+	sources := map[string]*kedastralv1alpha1.DataSource{"prom": promDataSource()}
+
+	wc, err := toWorkloadConfig(policy, promDataSource(), sources)
+	if err != nil {
+		t.Fatalf("toWorkloadConfig() error = %v", err)
+	}
+	rec := wc.Recommendation
+	if rec == nil || rec.LatencyAdapter != "prometheus" || rec.Lookback != 72*time.Hour || rec.Interval != time.Hour {
+		t.Errorf("Recommendation = %+v, want prometheus sources, 3d lookback, 1h interval", rec)
+	}
+
+	if _, err := toWorkloadConfig(policy, promDataSource(), nil); err == nil {
+		t.Error("expected error without the latency DataSource")
+	}
+}
@@ -3,7 +3,7 @@
 // This file contains the WorkloadForecaster and MultiForecaster types which orchestrate
 // the forecast pipeline for one or more workloads concurrently:
 //
-//	collect → buildFeatures → predict → calculateReplicas → storeSnapshot
+//	collect → buildFeatures → resample → clean → train → predict → calculateReplicas → storeSnapshot
 //
 // WorkloadForecaster manages forecasting for a single workload with isolated state.
 // MultiForecaster coordinates multiple WorkloadForecasters running in parallel goroutines.
@@ -15,8 +15,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 	"time"
 
@@ -34,6 +36,12 @@ type WorkloadForecaster struct {
 	adapter         adapters.Adapter
 	model           models.Model
 	builder         *features.Builder
+	resampler       *features.Resampler
+	cleaner         *features.Cleaner
+	persistModel    bool
+	newModel        func() models.Model
+	trainInterval   time.Duration
+	trainTimeout    time.Duration
 	store           storage.Store
 	policy          *capacity.Policy
 	horizon         time.Duration
@@ -43,6 +51,43 @@ type WorkloadForecaster struct {
 	logger          *slog.Logger
 	metrics         *metrics.Metrics
 	currentReplicas int
+
+	// signals forecast the additional metrics of a multi-metric workload. Their
+	// forecasts are combined with this forecaster's own by combine, using each
+	// forecaster's policy.TargetPerPod and weight.
+	metric  string
+	signals []*WorkloadForecaster
+	combine string
+	weight  float64
+
+	// depthAdapter collects the current queue depth of a queue-mode workload, which
+	// is planned with capacity.ToReplicasQueue. Nil in throughput mode.
+	depthAdapter adapters.Adapter
+
+	// overrides are scheduled replica bounds, evaluated against each tick's
+	// forecast steps.
+	overrides []capacity.Override
+
+	// budgets shares the replica budgets this workload may belong to with the other
+	// workloads of the process, which priority ranks it against. Nil outside
+	// operator mode.
+	budgets  *budgetCoordinator
+	priority int
+
+	// stabilizer damps replica changes across ticks: a scale-down window, a hold
+	// after scale-ups, and a dead band. Nil when no stabilization is configured.
+	stabilizer *capacity.Stabilizer
+
+	// recommender learns TargetPerPod from observed latency in the background. Nil
+	// when no recommender is configured.
+	recommender *targetRecommender
+
+	// modelMu guards model and the training bookkeeping below, which the background
+	// trainer updates while ticks predict.
+	modelMu      sync.RWMutex
+	modelVersion int
+	trainedAt    time.Time
+	latestFrame  *models.FeatureFrame
 }
 
 // MultiForecaster manages multiple workload forecasters running concurrently.
@@ -224,6 +269,18 @@ func (mf *MultiForecaster) Len() int {
 	return len(mf.running)
 }
 
+// BYOMStatus returns the status of the named workload's BYOM service, and false if
+// the workload is not registered or does not use a BYOM model. Safe for concurrent use.
+func (mf *MultiForecaster) BYOMStatus(name string) (models.BYOMStatus, bool) {
+	mf.mu.Lock()
+	managed, ok := mf.running[name]
+	mf.mu.Unlock()
+	if !ok {
+		return models.BYOMStatus{}, false
+	}
+	return managed.forecaster.byomStatus()
+}
+
 // Run executes the forecast loop for this workload with panic recovery and graceful shutdown.
 func (wf *WorkloadForecaster) Run(ctx context.Context) error {
 	defer func() {
@@ -234,6 +291,15 @@ func (wf *WorkloadForecaster) Run(ctx context.Context) error {
 
 	wf.logger.Info("starting workload forecaster", "interval", wf.interval, "window", wf.window)
 
+	if wf.persistModel {
+		restoreCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		wf.restoreModel(restoreCtx)
+		for _, signal := range wf.signals {
+			signal.restoreModel(restoreCtx)
+		}
+		cancel()
+	}
+
 	ticker := time.NewTicker(wf.interval)
 	defer ticker.Stop()
 
@@ -243,6 +309,17 @@ func (wf *WorkloadForecaster) Run(ctx context.Context) error {
 	}
 	cancel()
 
+	if wf.recommender != nil {
+		go wf.runRecommender(ctx)
+	}
+
+	if wf.trainInterval > 0 {
+		go wf.runTrainer(ctx)
+		for _, signal := range wf.signals {
+			go signal.runTrainer(ctx)
+		}
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -263,44 +340,77 @@ func (wf *WorkloadForecaster) tick(ctx context.Context) error {
 	start := time.Now()
 	wf.logger.Debug("starting forecast tick")
 
-	collectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	df, collectDuration, err := wf.collect(collectCtx)
-	cancel()
+	forecast, collectDuration, predictDuration, err := wf.forecast(ctx)
 	if err != nil {
-		if wf.metrics != nil {
-			wf.metrics.RecordError("adapter", "collect_failed")
-		}
-		return fmt.Errorf("collect: %w", err)
+		return err
 	}
 
-	featureFrame, err := wf.buildFeatures(df)
-	if err != nil {
-		if wf.metrics != nil {
-			wf.metrics.RecordError("features", "build_failed")
+	// A multi-metric workload is planned only when every metric has a forecast;
+	// planning without the binding metric would under-provision.
+	signals := make([]models.Forecast, len(wf.signals))
+	for i, signal := range wf.signals {
+		signals[i], _, _, err = signal.forecast(ctx)
+		if err != nil {
+			return fmt.Errorf("metric %q: %w", signal.metric, err)
 		}
-		return fmt.Errorf("build features: %w", err)
 	}
 
-	trainCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	if err := wf.model.Train(trainCtx, featureFrame); err != nil {
-		wf.logger.Debug("model training skipped or failed", "error", err)
+	wf.applyRecommendation()
+
+	var plan replicaPlan
+	wf.policy.StepLimits = capacity.StepLimits(wf.overrides, start, int(wf.step.Seconds()), len(forecast.Values))
+	plan.overrides = wf.activeOverrides(wf.policy.StepLimits)
+	if wf.depthAdapter != nil {
+		depthCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		plan.queueDepth, err = wf.queueDepth(depthCtx)
+		cancel()
+		if err != nil {
+			if wf.metrics != nil {
+				wf.metrics.RecordError("adapter", "queue_depth_failed")
+			}
+			return fmt.Errorf("queue depth: %w", err)
+		}
 	}
-	cancel()
 
-	predictCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
-	forecast, predictDuration, err := wf.predict(predictCtx, featureFrame)
-	cancel()
+	prevReplicas := wf.currentReplicas
+	desiredReplicas, capacityDuration, err := wf.planReplicas(ctx, forecast, signals, &plan)
 	if err != nil {
-		if wf.metrics != nil {
-			wf.metrics.RecordError("model", "predict_failed")
+		return wf.planFailed(err)
+	}
+
+	// Members of a replica budget claim their plan and are re-planned within their
+	// share when the budget cannot hold every member's plan.
+	if wf.budgets != nil {
+		caps := wf.budgets.claim(wf.name, workloadClaim{
+			start:    start,
+			step:     wf.step,
+			priority: wf.priority,
+			requests: desiredReplicas,
+			floors:   capacity.StepMinimums(*wf.policy, len(desiredReplicas)),
+		})
+		if constrained(caps, desiredReplicas) {
+			requested := desiredReplicas
+			wf.policy.StepLimits = withBudget(wf.policy.StepLimits, caps)
+			wf.currentReplicas = prevReplicas
+			desiredReplicas, capacityDuration, err = wf.planReplicas(ctx, forecast, signals, &plan)
+			if err != nil {
+				return wf.planFailed(err)
+			}
+			wf.logger.Info("plan scaled back to replica budget",
+				"requested_peak", slices.Max(requested),
+				"allocated_peak", slices.Max(desiredReplicas))
 		}
-		return fmt.Errorf("predict: %w", err)
 	}
 
-	desiredReplicas, capacityDuration := wf.calculateReplicas(forecast.Values, forecast.Quantiles)
+	// Stabilization runs once per tick on the final plan, since it remembers what
+	// each tick recommended and published.
+	if wf.stabilizer != nil && len(desiredReplicas) > 0 {
+		desiredReplicas = wf.stabilizer.Stabilize(start, int(wf.step.Seconds()), desiredReplicas, *wf.policy, plan.trace)
+		wf.currentReplicas = desiredReplicas[0]
+	}
 
 	storeCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
-	err = wf.storeSnapshot(storeCtx, forecast, desiredReplicas)
+	err = wf.storeSnapshot(storeCtx, forecast, desiredReplicas, plan)
 	cancel()
 	if err != nil {
 		if wf.metrics != nil {
@@ -310,6 +420,9 @@ func (wf *WorkloadForecaster) tick(ctx context.Context) error {
 	}
 
 	if wf.metrics != nil {
+		if age, ok := wf.trainingAge(); ok {
+			wf.metrics.SetTrainingAge(age.Seconds())
+		}
 		wf.metrics.SetForecastAge(0)
 		wf.metrics.SetDesiredReplicas(wf.currentReplicas)
 		if len(forecast.Values) > 0 {
@@ -330,6 +443,96 @@ func (wf *WorkloadForecaster) tick(ctx context.Context) error {
 	return nil
 }
 
+// planReplicas plans replicas for the forecast with the workload's capacity mode,
+// recording the planner outputs in plan. It fails only when ctx is done before the
+// cost planner finishes.
+func (wf *WorkloadForecaster) planReplicas(ctx context.Context, forecast models.Forecast, signals []models.Forecast, plan *replicaPlan) ([]int, time.Duration, error) {
+	var (
+		desiredReplicas []int
+		duration        time.Duration
+		err             error
+	)
+	switch {
+	case wf.depthAdapter != nil:
+		desiredReplicas, plan.backlog, plan.trace, duration = wf.calculateQueueReplicas(forecast, plan.queueDepth)
+	case len(wf.signals) > 0:
+		desiredReplicas, plan.trace, duration = wf.calculateMultiReplicas(forecast, signals)
+		plan.signals = wf.signalSnapshots(forecast, signals)
+		plan.binding = bindingMetrics(plan.trace)
+	default:
+		desiredReplicas, plan.trace, duration, err = wf.calculateReplicas(ctx, forecast.Values, forecast.Quantiles)
+	}
+	return desiredReplicas, duration, err
+}
+
+// planFailed records a planning failure and returns it as the tick's error.
+func (wf *WorkloadForecaster) planFailed(err error) error {
+	if wf.metrics != nil {
+		wf.metrics.RecordError("capacity", "plan_failed")
+	}
+	return fmt.Errorf("plan: %w", err)
+}
+
+// forecast runs the pipeline from collection through prediction for this
+// forecaster's metric, recording errors by stage.
+func (wf *WorkloadForecaster) forecast(ctx context.Context) (models.Forecast, time.Duration, time.Duration, error) {
+	collectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	df, collectDuration, err := wf.collect(collectCtx)
+	cancel()
+	if err != nil {
+		if wf.metrics != nil {
+			wf.metrics.RecordError("adapter", "collect_failed")
+		}
+		return models.Forecast{}, 0, 0, fmt.Errorf("collect: %w", err)
+	}
+
+	featureFrame, err := wf.buildFeatures(df)
+	if err != nil {
+		if wf.metrics != nil {
+			wf.metrics.RecordError("features", "build_failed")
+		}
+		return models.Forecast{}, 0, 0, fmt.Errorf("build features: %w", err)
+	}
+
+	featureFrame, err = wf.resample(featureFrame)
+	if err != nil {
+		if wf.metrics != nil {
+			wf.metrics.RecordError("features", "resample_failed")
+		}
+		return models.Forecast{}, 0, 0, fmt.Errorf("resample: %w", err)
+	}
+
+	// Only training sees the cleaned frame. The Hampel filter cannot tell the start of
+	// a genuine surge from a spike at the trailing edge of the window, so prediction
+	// keeps working from the raw observations.
+	trainFrame := wf.clean(featureFrame)
+
+	// With a separate training cadence the background trainer picks the frame up;
+	// the tick only trains itself until the first model version exists.
+	if wf.trainInterval > 0 {
+		wf.setLatestFrame(trainFrame)
+	}
+	if wf.trainInterval <= 0 || wf.version() == 0 {
+		wf.train(ctx, trainFrame)
+	}
+
+	predictCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	forecast, predictDuration, err := wf.predict(predictCtx, featureFrame)
+	cancel()
+	if err != nil {
+		if wf.metrics != nil {
+			reason := "predict_failed"
+			if errors.Is(err, models.ErrBYOMCircuitOpen) {
+				reason = "circuit_open"
+			}
+			wf.metrics.RecordError("model", reason)
+		}
+		return models.Forecast{}, 0, 0, fmt.Errorf("predict: %w", err)
+	}
+
+	return forecast, collectDuration, predictDuration, nil
+}
+
 func (wf *WorkloadForecaster) collect(ctx context.Context) (*adapters.DataFrame, time.Duration, error) {
 	start := time.Now()
 
@@ -364,22 +567,155 @@ func (wf *WorkloadForecaster) buildFeatures(df *adapters.DataFrame) (models.Feat
 	return featureFrame, nil
 }
 
+// restoreModel loads persisted model state from the store so the model can predict
+// before its first successful training pass. Missing, incompatible, or unreadable
+// state is logged and ignored; the model then trains from scratch as usual.
+func (wf *WorkloadForecaster) restoreModel(ctx context.Context) {
+	model := wf.currentModel()
+	persistable, ok := model.(models.Persistable)
+	if !ok {
+		wf.logger.Debug("model does not support state persistence", "model", model.Name())
+		return
+	}
+	stateStore, ok := wf.store.(storage.ModelStateStore)
+	if !ok {
+		wf.logger.Debug("store does not support model state")
+		return
+	}
+
+	state, found, err := stateStore.GetModelState(ctx, wf.name)
+	if err != nil {
+		wf.logger.Warn("failed to load model state", "error", err)
+		return
+	}
+	if !found {
+		return
+	}
+
+	if err := persistable.UnmarshalState(state); err != nil {
+		wf.logger.Warn("ignoring persisted model state", "model", model.Name(), "error", err)
+		return
+	}
+	wf.installModel(model, time.Time{})
+	wf.logger.Info("warm-started model from persisted state", "model", model.Name())
+}
+
+// saveModel persists the trained state of model to the store. Failures are logged and
+// counted but do not fail training.
+func (wf *WorkloadForecaster) saveModel(ctx context.Context, model models.Model) {
+	persistable, ok := model.(models.Persistable)
+	if !ok {
+		return
+	}
+	stateStore, ok := wf.store.(storage.ModelStateStore)
+	if !ok {
+		return
+	}
+
+	state, err := persistable.MarshalState()
+	if err != nil {
+		if !errors.Is(err, models.ErrNotTrained) {
+			wf.logger.Warn("failed to serialize model state", "error", err)
+		}
+		return
+	}
+
+	if err := stateStore.PutModelState(ctx, wf.name, state); err != nil {
+		if wf.metrics != nil {
+			wf.metrics.RecordError("store", "model_state_put_failed")
+		}
+		wf.logger.Warn("failed to persist model state", "error", err)
+	}
+}
+
+// resample snaps the feature frame onto the workload step grid when a resampler is
+// configured. It is a no-op otherwise.
+func (wf *WorkloadForecaster) resample(frame models.FeatureFrame) (models.FeatureFrame, error) {
+	if wf.resampler == nil {
+		return frame, nil
+	}
+
+	resampled, stats, err := wf.resampler.Resample(frame)
+	if err != nil {
+		return models.FeatureFrame{}, err
+	}
+
+	wf.logger.Debug("resampled features",
+		"aggregated", stats.Aggregated,
+		"filled", stats.Filled,
+		"dropped", stats.Dropped,
+		"rows", len(resampled.Rows),
+	)
+
+	return resampled, nil
+}
+
+// clean removes outliers and fills short gaps in the feature frame when a cleaner is
+// configured. It is a no-op otherwise.
+func (wf *WorkloadForecaster) clean(frame models.FeatureFrame) models.FeatureFrame {
+	if wf.cleaner == nil {
+		return frame
+	}
+
+	cleaned, stats := wf.cleaner.Clean(frame)
+
+	if wf.metrics != nil {
+		wf.metrics.RecordCleaned("outlier", stats.Outliers)
+		wf.metrics.RecordCleaned("gap_filled", stats.FilledPoints)
+	}
+
+	if stats.UnfilledGaps > 0 {
+		wf.logger.Warn("training window has gaps longer than the fill limit",
+			"gaps", stats.UnfilledGaps,
+		)
+	}
+
+	wf.logger.Debug("cleaned features",
+		"outliers", stats.Outliers,
+		"filled_points", stats.FilledPoints,
+		"rows", len(cleaned.Rows),
+	)
+
+	return cleaned
+}
+
 func (wf *WorkloadForecaster) predict(ctx context.Context, features models.FeatureFrame) (models.Forecast, time.Duration, error) {
 	start := time.Now()
 
-	forecast, err := wf.model.Predict(ctx, features)
+	model := wf.currentModel()
+	forecast, err := model.Predict(ctx, features)
+	if byom := wf.byomModel(); byom != nil && wf.metrics != nil {
+		wf.metrics.SetBYOMCircuitOpen(byom.Name(), byom.Status().CircuitOpen)
+	}
 	if err != nil {
 		return models.Forecast{}, 0, err
 	}
 
+	if forecast.Model == "" {
+		forecast.Model = model.Name()
+	}
+	if chain, ok := unwrapFallback(model); ok && forecast.Model != chain.Inner().Name() {
+		if wf.metrics != nil {
+			wf.metrics.RecordError("model", "fallback_used")
+		}
+		wf.logger.Warn("primary model unavailable; forecast produced by fallback model",
+			"primary", chain.Inner().Name(),
+			"model", forecast.Model,
+		)
+	}
+
 	duration := time.Since(start)
 
 	if wf.metrics != nil {
 		wf.metrics.RecordPredict(duration.Seconds())
+		if calibrated, ok := model.(interface{ Coverage() map[float64]float64 }); ok {
+			wf.metrics.SetConformalCoverage(calibrated.Coverage())
+		}
 	}
 
 	wf.logger.Debug("predicted forecast",
-		"model", wf.model.Name(),
+		"model", model.Name(),
+		"version", wf.version(),
 		"values", len(forecast.Values),
 		"duration_ms", duration.Milliseconds(),
 	)
@@ -387,16 +723,20 @@ func (wf *WorkloadForecaster) predict(ctx context.Context, features models.Featu
 	return forecast, duration, nil
 }
 
-func (wf *WorkloadForecaster) calculateReplicas(values []float64, quantiles map[float64][]float64) ([]int, time.Duration) {
+func (wf *WorkloadForecaster) calculateReplicas(ctx context.Context, values []float64, quantiles map[float64][]float64) ([]int, []capacity.StepTrace, time.Duration, error) {
 	start := time.Now()
 
-	desiredReplicas := capacity.ToReplicas(
+	desiredReplicas, trace, err := capacity.ToReplicasTraceContext(
+		ctx,
 		wf.currentReplicas,
 		values,
 		int(wf.step.Seconds()),
 		*wf.policy,
 		quantiles,
 	)
+	if err != nil {
+		return nil, nil, 0, err
+	}
 
 	if len(desiredReplicas) > 0 {
 		wf.currentReplicas = desiredReplicas[0]
@@ -413,10 +753,178 @@ func (wf *WorkloadForecaster) calculateReplicas(values []float64, quantiles map[
 		"duration_ms", duration.Milliseconds(),
 	)
 
-	return desiredReplicas, duration
+	return desiredReplicas, trace, duration, nil
+}
+
+// calculateMultiReplicas plans replicas for a multi-metric workload from this
+// forecaster's forecast and those of its signals, in order. The trace of each step
+// names its binding metric.
+func (wf *WorkloadForecaster) calculateMultiReplicas(forecast models.Forecast, signals []models.Forecast) ([]int, []capacity.StepTrace, time.Duration) {
+	start := time.Now()
+
+	inputs := make([]capacity.Signal, 0, len(signals)+1)
+	inputs = append(inputs, capacity.Signal{
+		Metric:       wf.metric,
+		Values:       forecast.Values,
+		Quantiles:    forecast.Quantiles,
+		TargetPerPod: wf.policy.TargetPerPod,
+		Weight:       wf.weight,
+	})
+	for i, signal := range wf.signals {
+		inputs = append(inputs, capacity.Signal{
+			Metric:       signal.metric,
+			Values:       signals[i].Values,
+			Quantiles:    signals[i].Quantiles,
+			TargetPerPod: signal.policy.TargetPerPod,
+			Weight:       signal.weight,
+		})
+	}
+
+	desiredReplicas, trace := capacity.ToReplicasMulti(
+		wf.currentReplicas,
+		inputs,
+		int(wf.step.Seconds()),
+		*wf.policy,
+		wf.combine,
+	)
+
+	if len(desiredReplicas) > 0 {
+		wf.currentReplicas = desiredReplicas[0]
+	}
+
+	duration := time.Since(start)
+
+	if wf.metrics != nil {
+		wf.metrics.RecordCapacity(duration.Seconds())
+	}
+
+	if len(trace) > 0 {
+		wf.logger.Debug("calculated replicas",
+			"current", wf.currentReplicas,
+			"binding_metric", trace[0].Metric,
+			"duration_ms", duration.Milliseconds(),
+		)
+	}
+
+	return desiredReplicas, trace, duration
+}
+
+// bindingMetrics lists the binding metric of each step of a multi-metric plan.
+func bindingMetrics(trace []capacity.StepTrace) []string {
+	metrics := make([]string, len(trace))
+	for i, t := range trace {
+		metrics[i] = t.Metric
+	}
+	return metrics
+}
+
+// queueDepth returns the newest queue depth reported by the depth adapter.
+func (wf *WorkloadForecaster) queueDepth(ctx context.Context) (float64, error) {
+	df, err := wf.depthAdapter.Collect(ctx, int(5*wf.step.Seconds()))
+	if err != nil {
+		return 0, err
+	}
+	frame, err := wf.builder.BuildFeatures(*df)
+	if err != nil {
+		return 0, err
+	}
+	return frame.Rows[len(frame.Rows)-1]["value"], nil
+}
+
+// calculateQueueReplicas plans replicas that drain the queue from depth, returning
+// the simulated backlog at the end of each step.
+func (wf *WorkloadForecaster) calculateQueueReplicas(forecast models.Forecast, depth float64) ([]int, []float64, []capacity.StepTrace, time.Duration) {
+	start := time.Now()
+
+	desiredReplicas, backlog, trace := capacity.ToReplicasQueue(
+		wf.currentReplicas,
+		forecast.Values,
+		depth,
+		int(wf.step.Seconds()),
+		*wf.policy,
+		forecast.Quantiles,
+	)
+
+	if len(desiredReplicas) > 0 {
+		wf.currentReplicas = desiredReplicas[0]
+	}
+
+	duration := time.Since(start)
+
+	if wf.metrics != nil {
+		wf.metrics.RecordCapacity(duration.Seconds())
+	}
+
+	wf.logger.Debug("calculated replicas",
+		"current", wf.currentReplicas,
+		"queue_depth", depth,
+		"duration_ms", duration.Milliseconds(),
+	)
+
+	return desiredReplicas, backlog, trace, duration
+}
+
+// signalSnapshots records the forecast of every metric of a multi-metric workload,
+// this forecaster's first. It returns nil for single-metric workloads.
+func (wf *WorkloadForecaster) signalSnapshots(forecast models.Forecast, signals []models.Forecast) []storage.SignalForecast {
+	if len(wf.signals) == 0 {
+		return nil
+	}
+
+	snapshots := []storage.SignalForecast{{
+		Metric:       wf.metric,
+		Model:        forecast.Model,
+		TargetPerPod: wf.policy.TargetPerPod,
+		Values:       forecast.Values,
+	}}
+	for i, signal := range wf.signals {
+		snapshots = append(snapshots, storage.SignalForecast{
+			Metric:       signal.metric,
+			Model:        signals[i].Model,
+			TargetPerPod: signal.policy.TargetPerPod,
+			Values:       signals[i].Values,
+		})
+	}
+	return snapshots
+}
+
+// activeOverrides lists the overrides active at any of the given steps, in the
+// order they are configured.
+func (wf *WorkloadForecaster) activeOverrides(limits []capacity.StepLimit) []storage.ActiveOverride {
+	var active []storage.ActiveOverride
+	for _, o := range wf.overrides {
+		var steps []int
+		for i, limit := range limits {
+			if slices.Contains(limit.Overrides, o.Name) {
+				steps = append(steps, i)
+			}
+		}
+		if len(steps) == 0 {
+			continue
+		}
+		active = append(active, storage.ActiveOverride{
+			Name:            o.Name,
+			MinReplicas:     o.MinReplicas,
+			MaxReplicas:     o.MaxReplicas,
+			FreezeScaleDown: o.FreezeScaleDown,
+			Steps:           steps,
+		})
+	}
+	return active
+}
+
+// replicaPlan carries the planner outputs recorded in a snapshot besides the desired
+// replicas; fields not produced by the workload's capacity mode are empty.
+type replicaPlan struct {
+	trace      []capacity.StepTrace
+	signals    []storage.SignalForecast
+	binding    []string
+	queueDepth float64
+	backlog    []float64
+	overrides  []storage.ActiveOverride
 }
 
-func (wf *WorkloadForecaster) storeSnapshot(ctx context.Context, forecast models.Forecast, desiredReplicas []int) error {
+func (wf *WorkloadForecaster) storeSnapshot(ctx context.Context, forecast models.Forecast, desiredReplicas []int, plan replicaPlan) error {
 	snapshot := storage.Snapshot{
 		Workload:        wf.name,
 		Metric:          forecast.Metric,
@@ -425,7 +933,15 @@ func (wf *WorkloadForecaster) storeSnapshot(ctx context.Context, forecast models
 		HorizonSeconds:  int(wf.horizon.Seconds()),
 		Values:          forecast.Values,
 		DesiredReplicas: desiredReplicas,
+		IntervalSeconds: int(wf.interval.Seconds()),
 		Quantiles:       forecast.Quantiles,
+		Model:           forecast.Model,
+		Signals:         plan.signals,
+		BindingMetrics:  plan.binding,
+		QueueDepth:      plan.queueDepth,
+		Backlog:         plan.backlog,
+		Overrides:       plan.overrides,
+		Trace:           plan.trace,
 	}
 
 	if err := wf.store.Put(ctx, snapshot); err != nil {
@@ -2,14 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log/slog"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/HatiCode/kedastral/cmd/forecaster/metrics"
 	"github.com/HatiCode/kedastral/pkg/adapters"
 	"github.com/HatiCode/kedastral/pkg/capacity"
+	"github.com/HatiCode/kedastral/pkg/durationx"
 	"github.com/HatiCode/kedastral/pkg/features"
 	"github.com/HatiCode/kedastral/pkg/models"
 	"github.com/HatiCode/kedastral/pkg/storage"
@@ -183,11 +186,17 @@ func TestForecaster_CalculateReplicas(t *testing.T) {
 	}
 
 	values := []float64{200, 300, 400}
-	desiredReplicas, duration := f.calculateReplicas(values, nil)
+	desiredReplicas, trace, duration, err := f.calculateReplicas(context.Background(), values, nil)
+	if err != nil {
+		t.Fatalf("calculateReplicas() error = %v", err)
+	}
 
 	if len(desiredReplicas) != len(values) {
 		t.Errorf("len(desiredReplicas) = %d, want %d", len(desiredReplicas), len(values))
 	}
+	if len(trace) != len(values) {
+		t.Errorf("len(trace) = %d, want %d", len(trace), len(values))
+	}
 
 	if duration == 0 {
 		t.Error("duration should be greater than 0")
@@ -199,6 +208,54 @@ func TestForecaster_CalculateReplicas(t *testing.T) {
 	}
 }
 
+func TestForecaster_CalculateMultiReplicas(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	policy := func(target float64) *capacity.Policy {
+		return &capacity.Policy{
+			TargetPerPod:          target,
+			Headroom:              1.0,
+			MinReplicas:           1,
+			MaxReplicas:           20,
+			UpMaxFactorPerStep:    10,
+			DownMaxPercentPerStep: 100,
+		}
+	}
+
+	f := &WorkloadForecaster{
+		metric:          "http_rps",
+		policy:          policy(100),
+		step:            time.Minute,
+		currentReplicas: 1,
+		logger:          logger,
+		signals: []*WorkloadForecaster{
+			{metric: "ws_connections", policy: policy(50), logger: logger},
+		},
+	}
+
+	forecast := models.Forecast{Metric: "http_rps", Model: "baseline", Values: []float64{400, 100}}
+	signals := []models.Forecast{{Metric: "ws_connections", Model: "arima", Values: []float64{100, 300}}}
+
+	desiredReplicas, trace, _ := f.calculateMultiReplicas(forecast, signals)
+	binding := bindingMetrics(trace)
+	if want := []int{4, 6}; !reflect.DeepEqual(desiredReplicas, want) {
+		t.Errorf("desiredReplicas = %v, want %v", desiredReplicas, want)
+	}
+	if want := []string{"http_rps", "ws_connections"}; !reflect.DeepEqual(binding, want) {
+		t.Errorf("binding = %v, want %v", binding, want)
+	}
+	if f.currentReplicas != 4 {
+		t.Errorf("currentReplicas = %d, want 4", f.currentReplicas)
+	}
+
+	snapshots := f.signalSnapshots(forecast, signals)
+	if len(snapshots) != 2 || snapshots[0].Metric != "http_rps" || snapshots[1].Metric != "ws_connections" {
+		t.Fatalf("signalSnapshots() = %+v, want http_rps then ws_connections", snapshots)
+	}
+	if snapshots[1].Model != "arima" || snapshots[1].TargetPerPod != 50 {
+		t.Errorf("signalSnapshots()[1] = %+v, want model arima and target 50", snapshots[1])
+	}
+}
+
 func TestForecaster_BuildFeatures(t *testing.T) {
 	builder := features.NewBuilder()
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
@@ -245,6 +302,97 @@ func TestForecaster_BuildFeatures_Error(t *testing.T) {
 	}
 }
 
+func TestForecaster_Clean(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	f := &WorkloadForecaster{
+		cleaner: features.NewCleaner(features.CleanOptions{OutlierWindow: 3}),
+		logger:  logger,
+		metrics: metrics.New("test-clean"),
+	}
+
+	frame := models.FeatureFrame{Rows: []map[string]float64{
+		{"value": 100}, {"value": 102}, {"value": 98}, {"value": 5000},
+		{"value": 101}, {"value": 99}, {"value": 100},
+	}}
+
+	cleaned := f.clean(frame)
+	if cleaned.Rows[3]["value"] > 200 {
+		t.Errorf("spike not cleaned: value = %v", cleaned.Rows[3]["value"])
+	}
+	if frame.Rows[3]["value"] != 5000 {
+		t.Error("clean() should not modify the raw frame used for prediction")
+	}
+}
+
+func TestForecaster_Clean_Disabled(t *testing.T) {
+	f := &WorkloadForecaster{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	frame := models.FeatureFrame{Rows: []map[string]float64{{"value": 1}, {"value": 1000}, {"value": 1}}}
+	if cleaned := f.clean(frame); cleaned.Rows[1]["value"] != 1000 {
+		t.Error("clean() should be a no-op without a cleaner")
+	}
+}
+
+func TestForecaster_Resample(t *testing.T) {
+	f := &WorkloadForecaster{
+		resampler: features.NewResampler(features.ResampleOptions{StepSeconds: 60, MaxGapSteps: 2}),
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	frame := models.FeatureFrame{Rows: []map[string]float64{
+		{"timestamp": 0, "value": 1},
+		{"timestamp": 180, "value": 4},
+	}}
+	out, err := f.resample(frame)
+	if err != nil {
+		t.Fatalf("resample() error = %v", err)
+	}
+	if len(out.Rows) != 4 {
+		t.Errorf("rows = %d, want 4", len(out.Rows))
+	}
+
+	frame.Rows[1]["timestamp"] = 3600
+	if _, err := f.resample(frame); !errors.Is(err, features.ErrGapTooLong) {
+		t.Errorf("resample() error = %v, want ErrGapTooLong", err)
+	}
+}
+
+func TestForecaster_PersistAndRestoreModel(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	store := storage.NewMemoryStore()
+	ctx := context.Background()
+
+	rows := make([]map[string]float64, 30)
+	for i := range rows {
+		rows[i] = map[string]float64{"value": float64(100 + i%5)}
+	}
+
+	trained := models.NewARIMAModel("rps", 60, 600, 1, 1, 1)
+	if err := trained.Train(ctx, models.FeatureFrame{Rows: rows}); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+
+	first := &WorkloadForecaster{name: "warm", model: trained, store: store, logger: logger}
+	first.saveModel(ctx, trained)
+
+	fresh := models.NewARIMAModel("rps", 60, 600, 1, 1, 1)
+	second := &WorkloadForecaster{name: "warm", model: fresh, store: store, logger: logger}
+	second.restoreModel(ctx)
+
+	if _, err := fresh.Predict(ctx, models.FeatureFrame{}); err != nil {
+		t.Errorf("restored model cannot predict: %v", err)
+	}
+
+	mismatched := models.NewARIMAModel("rps", 60, 600, 2, 1, 1)
+	third := &WorkloadForecaster{name: "warm", model: mismatched, store: store, logger: logger}
+	third.restoreModel(ctx)
+
+	if _, err := mismatched.Predict(ctx, models.FeatureFrame{}); err == nil {
+		t.Error("state for arima(1,1,1) should not be restored into arima(2,1,1)")
+	}
+}
+
 func TestForecaster_StoreSnapshot(t *testing.T) {
 	store := storage.NewMemoryStore()
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
@@ -263,7 +411,7 @@ func TestForecaster_StoreSnapshot(t *testing.T) {
 	}
 	desiredReplicas := []int{2, 3, 3}
 
-	err := f.storeSnapshot(context.Background(), forecast, desiredReplicas)
+	err := f.storeSnapshot(context.Background(), forecast, desiredReplicas, replicaPlan{})
 	if err != nil {
 		t.Fatalf("storeSnapshot() error = %v", err)
 	}
@@ -356,3 +504,194 @@ func TestForecaster_Tick_WithoutMetrics(t *testing.T) {
 		t.Errorf("buildFeatures should work without metrics: %v", err)
 	}
 }
+
+// staticAdapter returns the same rows on every collection.
+type staticAdapter struct {
+	rows []adapters.Row
+}
+
+func (a *staticAdapter) Collect(ctx context.Context, windowSeconds int) (*adapters.DataFrame, error) {
+	return &adapters.DataFrame{Rows: a.rows}, nil
+}
+
+func (a *staticAdapter) Name() string { return "static" }
+
+func TestForecaster_Tick_QueueMode(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Minute)
+	rows := func(values ...float64) []adapters.Row {
+		out := make([]adapters.Row, len(values))
+		for i, v := range values {
+			out[i] = adapters.Row{"ts": now.Add(time.Duration(i-len(values)) * time.Minute).Format(time.RFC3339), "value": v}
+		}
+		return out
+	}
+
+	store := storage.NewMemoryStore()
+	f := &WorkloadForecaster{
+		name:         "worker",
+		adapter:      &staticAdapter{rows: rows(2, 2, 2)},
+		depthAdapter: &staticAdapter{rows: rows(900, 600)},
+		model:        models.NewLastValueModel("arrivals", 60, 180),
+		builder:      features.NewBuilder(),
+		store:        store,
+		policy: &capacity.Policy{
+			Headroom:              1.0,
+			MinReplicas:           1,
+			MaxReplicas:           50,
+			UpMaxFactorPerStep:    10,
+			DownMaxPercentPerStep: 100,
+			ServiceRatePerPod:     1,
+			DrainSeconds:          300,
+		},
+		step:            time.Minute,
+		horizon:         3 * time.Minute,
+		window:          3 * time.Minute,
+		currentReplicas: 1,
+		logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	if err := f.tick(context.Background()); err != nil {
+		t.Fatalf("tick() error = %v", err)
+	}
+
+	snapshot, found, err := store.GetLatest(context.Background(), "worker")
+	if err != nil || !found {
+		t.Fatalf("GetLatest() = %v, %v", found, err)
+	}
+	if snapshot.QueueDepth != 600 {
+		t.Errorf("QueueDepth = %v, want the newest depth 600", snapshot.QueueDepth)
+	}
+	if want := []int{4, 4, 4}; !reflect.DeepEqual(snapshot.DesiredReplicas, want) {
+		t.Errorf("DesiredReplicas = %v, want %v", snapshot.DesiredReplicas, want)
+	}
+	if want := []float64{480, 360, 240}; !reflect.DeepEqual(snapshot.Backlog, want) {
+		t.Errorf("Backlog = %v, want %v", snapshot.Backlog, want)
+	}
+}
+
+func TestForecaster_Tick_Overrides(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Minute)
+	rows := make([]adapters.Row, 3)
+	for i := range rows {
+		rows[i] = adapters.Row{"ts": now.Add(time.Duration(i-3) * time.Minute).Format(time.RFC3339), "value": 100.0}
+	}
+	allDay, err := durationx.ParseSchedule("00:00-00:00", nil)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	store := storage.NewMemoryStore()
+	f := &WorkloadForecaster{
+		name:    "web",
+		adapter: &staticAdapter{rows: rows},
+		model:   models.NewLastValueModel("rps", 60, 180),
+		builder: features.NewBuilder(),
+		store:   store,
+		policy: &capacity.Policy{
+			TargetPerPod:          100,
+			Headroom:              1.0,
+			MinReplicas:           1,
+			MaxReplicas:           50,
+			UpMaxFactorPerStep:    10,
+			DownMaxPercentPerStep: 100,
+		},
+		overrides: []capacity.Override{
+			{Name: "launch", Schedule: allDay, MinReplicas: 7},
+		},
+		step:            time.Minute,
+		horizon:         3 * time.Minute,
+		window:          3 * time.Minute,
+		currentReplicas: 1,
+		logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	if err := f.tick(context.Background()); err != nil {
+		t.Fatalf("tick() error = %v", err)
+	}
+
+	snapshot, _, err := store.GetLatest(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("GetLatest() error = %v", err)
+	}
+	if want := []int{7, 7, 7}; !reflect.DeepEqual(snapshot.DesiredReplicas, want) {
+		t.Errorf("DesiredReplicas = %v, want %v", snapshot.DesiredReplicas, want)
+	}
+	want := []storage.ActiveOverride{{Name: "launch", MinReplicas: 7, Steps: []int{0, 1, 2}}}
+	if !reflect.DeepEqual(snapshot.Overrides, want) {
+		t.Errorf("Overrides = %+v, want %+v", snapshot.Overrides, want)
+	}
+	if len(snapshot.Trace) != 3 {
+		t.Fatalf("len(Trace) = %d, want 3", len(snapshot.Trace))
+	}
+	// The override minimum raises the one pod needed at every step.
+	wantTrace := capacity.StepTrace{
+		Source:   capacity.SourceHeadroom,
+		Forecast: 100,
+		Load:     100,
+		Need:     1,
+		Rounded:  1,
+		Clamps:   []capacity.Clamp{{Rule: capacity.ClampOverrideMin, From: 1, To: 7}},
+		Replicas: 7,
+	}
+	if !reflect.DeepEqual(snapshot.Trace[0], wantTrace) {
+		t.Errorf("Trace[0] = %+v, want %+v", snapshot.Trace[0], wantTrace)
+	}
+}
+
+func TestForecaster_Tick_Stabilization(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Minute)
+	rows := func(value float64) []adapters.Row {
+		out := make([]adapters.Row, 3)
+		for i := range out {
+			out[i] = adapters.Row{"ts": now.Add(time.Duration(i-3) * time.Minute).Format(time.RFC3339), "value": value}
+		}
+		return out
+	}
+
+	adapter := &staticAdapter{rows: rows(800)}
+	store := storage.NewMemoryStore()
+	f := &WorkloadForecaster{
+		name:    "web",
+		adapter: adapter,
+		model:   models.NewLastValueModel("rps", 60, 180),
+		builder: features.NewBuilder(),
+		store:   store,
+		policy: &capacity.Policy{
+			TargetPerPod:          100,
+			Headroom:              1.0,
+			MinReplicas:           1,
+			MaxReplicas:           50,
+			UpMaxFactorPerStep:    10,
+			DownMaxPercentPerStep: 100,
+		},
+		stabilizer:      capacity.NewStabilizer(capacity.Stabilization{ScaleDownWindowSeconds: 600}),
+		step:            time.Minute,
+		horizon:         3 * time.Minute,
+		window:          3 * time.Minute,
+		currentReplicas: 1,
+		logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	if err := f.tick(context.Background()); err != nil {
+		t.Fatalf("first tick() error = %v", err)
+	}
+	// The load drops, but the scale-down window keeps the replicas of the last tick.
+	adapter.rows = rows(300)
+	if err := f.tick(context.Background()); err != nil {
+		t.Fatalf("second tick() error = %v", err)
+	}
+
+	snapshot, _, err := store.GetLatest(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("GetLatest() error = %v", err)
+	}
+	if want := []int{8, 8, 8}; !reflect.DeepEqual(snapshot.DesiredReplicas, want) {
+		t.Errorf("DesiredReplicas = %v, want %v", snapshot.DesiredReplicas, want)
+	}
+	if want := []capacity.Clamp{{Rule: capacity.ClampScaleDownWindow, From: 3, To: 8}}; !reflect.DeepEqual(snapshot.Trace[0].Clamps, want) {
+		t.Errorf("Trace[0].Clamps = %v, want %v", snapshot.Trace[0].Clamps, want)
+	}
+	if f.currentReplicas != 8 {
+		t.Errorf("currentReplicas = %d, want 8", f.currentReplicas)
+	}
+}
@@ -8,16 +8,25 @@
 // Metrics exposed:
 //   - kedastral_adapter_collect_seconds: Histogram of metric collection duration
 //   - kedastral_model_predict_seconds: Histogram of forecast prediction duration
+//   - kedastral_model_train_seconds: Histogram of successful model training duration
+//   - kedastral_model_version: Gauge of the model version used for prediction
+//   - kedastral_model_training_age_seconds: Gauge of time since the model was last trained
 //   - kedastral_capacity_compute_seconds: Histogram of capacity planning duration
 //   - kedastral_forecast_age_seconds: Gauge of current forecast age
 //   - kedastral_desired_replicas: Gauge of current desired replica count
 //   - kedastral_predicted_value: Gauge of current predicted metric value
 //   - kedastral_errors_total: Counter of errors by component and reason
+//   - kedastral_cleaned_points_total: Counter of training points cleaned by reason
+//   - kedastral_conformal_coverage: Gauge of observed coverage per quantile level
+//   - kedastral_byom_circuit_open: Gauge set to 1 while a BYOM service's circuit breaker is open
+//   - kedastral_recommended_target_per_pod: Gauge of the targetPerPod learned from latency
+//   - kedastral_recommended_target_confidence: Gauge of the confidence in that recommendation
 //
 // All metrics include the workload label for multi-workload deployments.
 package metrics
 
 import (
+	"strconv"
 	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -50,11 +59,19 @@ func GetOrCreate(workload string) *Metrics {
 type Metrics struct {
 	AdapterCollectSeconds  prometheus.Histogram
 	ModelPredictSeconds    prometheus.Histogram
+	ModelTrainSeconds      prometheus.Histogram
+	ModelVersion           prometheus.Gauge
+	ModelTrainingAge       prometheus.Gauge
 	CapacityComputeSeconds prometheus.Histogram
 	ForecastAgeSeconds     prometheus.Gauge
 	DesiredReplicas        prometheus.Gauge
 	PredictedValue         prometheus.Gauge
 	ErrorsTotal            *prometheus.CounterVec
+	CleanedPointsTotal     *prometheus.CounterVec
+	ConformalCoverage      *prometheus.GaugeVec
+	BYOMCircuitOpen        *prometheus.GaugeVec
+	RecommendedTarget      prometheus.Gauge
+	RecommendedConfidence  prometheus.Gauge
 }
 
 // New creates and registers all Prometheus metrics.
@@ -80,6 +97,31 @@ func New(workload string) *Metrics {
 			Buckets: prometheus.DefBuckets,
 		}),
 
+		ModelTrainSeconds: promauto.NewHistogram(prometheus.HistogramOpts{
+			Name: "kedastral_model_train_seconds",
+			Help: "Time spent in successful model training passes",
+			ConstLabels: prometheus.Labels{
+				"workload": workload,
+			},
+			Buckets: []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 120, 300},
+		}),
+
+		ModelVersion: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "kedastral_model_version",
+			Help: "Version of the model used for prediction, incremented on every successful training pass",
+			ConstLabels: prometheus.Labels{
+				"workload": workload,
+			},
+		}),
+
+		ModelTrainingAge: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "kedastral_model_training_age_seconds",
+			Help: "Seconds since the model used for prediction was trained",
+			ConstLabels: prometheus.Labels{
+				"workload": workload,
+			},
+		}),
+
 		CapacityComputeSeconds: promauto.NewHistogram(prometheus.HistogramOpts{
 			Name: "kedastral_capacity_compute_seconds",
 			Help: "Time spent computing desired replicas",
@@ -120,6 +162,46 @@ func New(workload string) *Metrics {
 				"workload": workload,
 			},
 		}, []string{"component", "reason"}),
+
+		CleanedPointsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "kedastral_cleaned_points_total",
+			Help: "Total number of training points cleaned before model training, by reason",
+			ConstLabels: prometheus.Labels{
+				"workload": workload,
+			},
+		}, []string{"reason"}),
+
+		ConformalCoverage: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "kedastral_conformal_coverage",
+			Help: "Fraction of recent observations at or below the forecast quantile, by quantile level",
+			ConstLabels: prometheus.Labels{
+				"workload": workload,
+			},
+		}, []string{"level"}),
+
+		BYOMCircuitOpen: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "kedastral_byom_circuit_open",
+			Help: "1 while the BYOM service's circuit breaker is open and calls are rejected, by model",
+			ConstLabels: prometheus.Labels{
+				"workload": workload,
+			},
+		}, []string{"model"}),
+
+		RecommendedTarget: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "kedastral_recommended_target_per_pod",
+			Help: "TargetPerPod recommended from the observed load at which latency breaches its SLO",
+			ConstLabels: prometheus.Labels{
+				"workload": workload,
+			},
+		}),
+
+		RecommendedConfidence: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "kedastral_recommended_target_confidence",
+			Help: "Confidence (0-1) in the recommended targetPerPod",
+			ConstLabels: prometheus.Labels{
+				"workload": workload,
+			},
+		}),
 	}
 }
 
@@ -133,6 +215,21 @@ func (m *Metrics) RecordPredict(seconds float64) {
 	m.ModelPredictSeconds.Observe(seconds)
 }
 
+// RecordTrain records the time spent in a successful training pass.
+func (m *Metrics) RecordTrain(seconds float64) {
+	m.ModelTrainSeconds.Observe(seconds)
+}
+
+// SetModelVersion sets the version of the model used for prediction.
+func (m *Metrics) SetModelVersion(version int) {
+	m.ModelVersion.Set(float64(version))
+}
+
+// SetTrainingAge sets the age of the model used for prediction.
+func (m *Metrics) SetTrainingAge(seconds float64) {
+	m.ModelTrainingAge.Set(seconds)
+}
+
 // RecordCapacity records the time spent computing capacity.
 func (m *Metrics) RecordCapacity(seconds float64) {
 	m.CapacityComputeSeconds.Observe(seconds)
@@ -157,3 +254,32 @@ func (m *Metrics) SetPredictedValue(value float64) {
 func (m *Metrics) RecordError(component, reason string) {
 	m.ErrorsTotal.WithLabelValues(component, reason).Inc()
 }
+
+// RecordCleaned adds n to the cleaned-points counter for the given reason.
+func (m *Metrics) RecordCleaned(reason string, n int) {
+	if n > 0 {
+		m.CleanedPointsTotal.WithLabelValues(reason).Add(float64(n))
+	}
+}
+
+// SetConformalCoverage sets the observed coverage for each quantile level.
+func (m *Metrics) SetConformalCoverage(coverage map[float64]float64) {
+	for level, value := range coverage {
+		m.ConformalCoverage.WithLabelValues(strconv.FormatFloat(level, 'f', -1, 64)).Set(value)
+	}
+}
+
+// SetBYOMCircuitOpen records whether the circuit breaker of the named BYOM model is open.
+func (m *Metrics) SetBYOMCircuitOpen(model string, open bool) {
+	value := 0.0
+	if open {
+		value = 1
+	}
+	m.BYOMCircuitOpen.WithLabelValues(model).Set(value)
+}
+
+// SetRecommendation records the latest recommended targetPerPod and its confidence.
+func (m *Metrics) SetRecommendation(target, confidence float64) {
+	m.RecommendedTarget.Set(target)
+	m.RecommendedConfidence.Set(confidence)
+}
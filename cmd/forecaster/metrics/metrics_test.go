@@ -192,6 +192,19 @@ func TestRecordError_Increment(t *testing.T) {
 	}
 }
 
+func TestSetConformalCoverage(t *testing.T) {
+	m := New("test-conformal-coverage")
+
+	m.SetConformalCoverage(map[float64]float64{0.5: 0.48, 0.9: 0.91})
+
+	if got := testutil.ToFloat64(m.ConformalCoverage.WithLabelValues("0.9")); got != 0.91 {
+		t.Errorf("coverage{level=0.9} = %v, want 0.91", got)
+	}
+	if count := testutil.CollectAndCount(m.ConformalCoverage); count != 2 {
+		t.Errorf("expected 2 coverage series, got %d", count)
+	}
+}
+
 func TestMetrics_MultipleObservations(t *testing.T) {
 	m := New("test-metrics-multiple-observations")
 
@@ -218,3 +231,17 @@ func TestMetrics_MultipleObservations(t *testing.T) {
 		t.Error("expected capacity metric to be present")
 	}
 }
+
+func TestSetBYOMCircuitOpen(t *testing.T) {
+	m := New("test-byom-circuit")
+
+	m.SetBYOMCircuitOpen("byom(prophet:8000)", true)
+	if got := testutil.ToFloat64(m.BYOMCircuitOpen.WithLabelValues("byom(prophet:8000)")); got != 1 {
+		t.Errorf("circuit open = %v, want 1", got)
+	}
+
+	m.SetBYOMCircuitOpen("byom(prophet:8000)", false)
+	if got := testutil.ToFloat64(m.BYOMCircuitOpen.WithLabelValues("byom(prophet:8000)")); got != 0 {
+		t.Errorf("circuit open = %v, want 0", got)
+	}
+}
@@ -3,8 +3,10 @@ package models
 import (
 	"log/slog"
 	"os"
+	"slices"
 
 	"github.com/HatiCode/kedastral/cmd/forecaster/config"
+	"github.com/HatiCode/kedastral/pkg/capacity"
 	"github.com/HatiCode/kedastral/pkg/models"
 )
 
@@ -42,7 +44,10 @@ func New(cfg *config.Config, logger *slog.Logger) models.Model {
 
 	case "byom":
 		logger.Info("initializing BYOM model", "url", cfg.BYOMURL)
-		return models.NewBYOMModel(cfg.BYOMURL, cfg.Metric, stepSec, horizonSec)
+		return models.NewBYOMModel(cfg.BYOMURL, cfg.Metric, stepSec, horizonSec).
+			WithTLS(cfg.BYOMTLS).
+			WithAuth(cfg.BYOMAuth).
+			WithResilience(cfg.BYOMResilience)
 
 	default:
 		logger.Error("invalid model type", "model", cfg.Model)
@@ -52,8 +57,45 @@ func New(cfg *config.Config, logger *slog.Logger) models.Model {
 	return nil
 }
 
-// NewForWorkload creates a forecasting model from a workload config (multi-workload mode).
+// NewForWorkload creates a forecasting model from a workload config (multi-workload mode),
+// chained with its fallback models and wrapped in a conformal calibrator when enabled.
 func NewForWorkload(wc config.WorkloadConfig, logger *slog.Logger) models.Model {
+	model := newBaseForWorkload(wc, logger)
+	if len(wc.FallbackModels) > 0 {
+		chain := []models.Model{model}
+		for _, name := range wc.FallbackModels {
+			fallback := wc
+			fallback.Model = name
+			chain = append(chain, newBaseForWorkload(fallback, logger))
+		}
+
+		logger.Info("chaining fallback models",
+			"workload", wc.Name,
+			"fallback", wc.FallbackModels,
+			"max_jump", wc.FallbackMaxJump,
+		)
+		model = models.NewFallbackModel(chain, models.FallbackOptions{MaxJump: wc.FallbackMaxJump})
+	}
+	if !wc.ConformalEnabled {
+		return model
+	}
+
+	levels := append([]float64(nil), models.DefaultConformalLevels...)
+	if level, err := capacity.ParseQuantileLevel(wc.QuantileLevel); err == nil && level > 0 && !slices.Contains(levels, level) {
+		levels = append(levels, level)
+	}
+
+	logger.Info("wrapping model with conformal prediction intervals",
+		"workload", wc.Name,
+		"window", wc.ConformalWindow,
+	)
+	return models.NewConformalModel(model, int(wc.Step.Seconds()), models.ConformalOptions{
+		Window: wc.ConformalWindow,
+		Levels: levels,
+	})
+}
+
+func newBaseForWorkload(wc config.WorkloadConfig, logger *slog.Logger) models.Model {
 	stepSec := int(wc.Step.Seconds())
 	horizonSec := int(wc.Horizon.Seconds())
 
@@ -86,9 +128,17 @@ func NewForWorkload(wc config.WorkloadConfig, logger *slog.Logger) models.Model
 		logger.Info("initializing baseline model", "workload", wc.Name)
 		return models.NewBaselineModel(wc.Metric, stepSec, horizonSec)
 
+	case "last-value":
+		logger.Info("initializing last-value model", "workload", wc.Name)
+		return models.NewLastValueModel(wc.Metric, stepSec, horizonSec)
+
 	case "byom":
 		logger.Info("initializing BYOM model", "workload", wc.Name, "url", wc.BYOMURL)
-		return models.NewBYOMModel(wc.BYOMURL, wc.Metric, stepSec, horizonSec)
+		return models.NewBYOMModel(wc.BYOMURL, wc.Metric, stepSec, horizonSec).
+			WithIdentity(wc.Name, wc.Policy).
+			WithTLS(wc.BYOMTLS).
+			WithAuth(wc.BYOMAuth).
+			WithResilience(wc.BYOMResilience)
 
 	default:
 		logger.Error("invalid model type", "model", wc.Model, "workload", wc.Name)
@@ -4,42 +4,162 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"reflect"
+	"sync"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	"github.com/HatiCode/kedastral/cmd/forecaster/config"
 	"github.com/HatiCode/kedastral/cmd/forecaster/controller"
 	kedastralv1alpha1 "github.com/HatiCode/kedastral/pkg/api/v1alpha1"
+	"github.com/HatiCode/kedastral/pkg/capacity"
+	"github.com/HatiCode/kedastral/pkg/models"
 	"github.com/HatiCode/kedastral/pkg/storage"
+	"github.com/HatiCode/kedastral/pkg/tls"
 )
 
 // forecasterManager adapts the dynamic MultiForecaster to the controller's
 // ForecasterManager interface, building a workload forecaster on each upsert.
+// Process-wide settings that ForecastPolicies cannot express, such as the BYOM
+// client certificates mounted into the forecaster, are applied here.
+//
+// The BYOM bearer token flags are deliberately not applied: a policy could point
+// byomURL at any service and receive the token. Policies reference their own
+// credentials with model.byomAuth instead.
+//
+// Every workload it builds shares budgets, which the ReplicaBudget reconciler
+// configures, and keeps its targetPerPod recommendations in recommendations and its
+// replica stabilizer in stabilizers, which outlive the rebuilds of a workload's
+// forecaster.
+//
+// Policies are reconciled every interval and on their own status updates, so an
+// upsert whose configuration matches the running forecaster's is a no-op. Rebuilding
+// it would cancel its background trainer before it ever fires and discard its model
+// versions and calibration.
 type forecasterManager struct {
 	multiForecaster *MultiForecaster
+	budgets         *budgetCoordinator
+	recommendations *recommendations
+	stabilizers     *stabilizers
 	store           storage.Store
+	byomTLS         tls.Config
+	byomResilience  models.BYOMResilience
 	logger          *slog.Logger
+
+	// mu guards configs, the configurations of the running forecasters.
+	mu      sync.Mutex
+	configs map[string]config.WorkloadConfig
+}
+
+func newForecasterManager(multiForecaster *MultiForecaster, budgets *budgetCoordinator, store storage.Store, cfg *config.Config, logger *slog.Logger) *forecasterManager {
+	return &forecasterManager{
+		multiForecaster: multiForecaster,
+		budgets:         budgets,
+		recommendations: newRecommendations(),
+		stabilizers:     newStabilizers(),
+		store:           store,
+		byomTLS:         cfg.BYOMTLS,
+		byomResilience:  cfg.BYOMResilience,
+		logger:          logger,
+		configs:         make(map[string]config.WorkloadConfig),
+	}
 }
 
 func (m *forecasterManager) Upsert(_ context.Context, workloadConfig config.WorkloadConfig) error {
+	if workloadConfig.UsesBYOM() {
+		workloadConfig.BYOMTLS = m.byomTLS
+		if err := workloadConfig.BYOMTLS.Validate(); err != nil {
+			return fmt.Errorf("byom tls: %w", err)
+		}
+		workloadConfig.BYOMResilience = m.byomResilience
+		if err := config.ValidateBYOMResilience(workloadConfig.BYOMResilience); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if running, ok := m.configs[workloadConfig.Name]; ok && reflect.DeepEqual(running, workloadConfig) {
+		return nil
+	}
+
 	forecaster, err := buildWorkloadForecaster(workloadConfig, m.store, m.logger)
 	if err != nil {
 		return err
 	}
+	forecaster.budgets = m.budgets
+	if forecaster.recommender != nil {
+		forecaster.recommender.results = m.recommendations
+	}
+	if forecaster.stabilizer != nil {
+		forecaster.stabilizer = m.stabilizers.get(workloadConfig.Name, forecaster.stabilizer.Stabilization)
+	} else {
+		m.stabilizers.remove(workloadConfig.Name)
+	}
 	m.multiForecaster.Upsert(forecaster)
+	m.configs[workloadConfig.Name] = workloadConfig
 	return nil
 }
 
 func (m *forecasterManager) Remove(name string) {
+	m.mu.Lock()
+	delete(m.configs, name)
+	m.mu.Unlock()
+
 	m.multiForecaster.Remove(name)
+	m.budgets.remove(name)
+	m.recommendations.remove(name)
+	m.stabilizers.remove(name)
 }
 
-// runOperator starts the controller-runtime manager that reconciles ForecastPolicy
-// and DataSource resources. It blocks until the context is canceled.
+func (m *forecasterManager) TargetRecommendation(name string) (capacity.TargetRecommendation, time.Time, bool) {
+	return m.recommendations.latest(name)
+}
+
+func (m *forecasterManager) BYOMStatus(name string) (models.BYOMStatus, bool) {
+	return m.multiForecaster.BYOMStatus(name)
+}
+
+// stabilizers keeps the replica stabilizer of each workload across the rebuilds of
+// its forecaster, so a policy edit does not forget the recommendations and scale-ups
+// that its scale-down window and scale-up hold are based on. Safe for concurrent use.
+type stabilizers struct {
+	mu         sync.Mutex
+	byWorkload map[string]*capacity.Stabilizer
+}
+
+func newStabilizers() *stabilizers {
+	return &stabilizers{byWorkload: make(map[string]*capacity.Stabilizer)}
+}
+
+// get returns the stabilizer of a workload with settings s, starting one with no
+// history when the workload has none or its settings changed.
+func (st *stabilizers) get(workload string, s capacity.Stabilization) *capacity.Stabilizer {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if stabilizer, ok := st.byWorkload[workload]; ok && stabilizer.Stabilization == s {
+		return stabilizer
+	}
+	stabilizer := capacity.NewStabilizer(s)
+	st.byWorkload[workload] = stabilizer
+	return stabilizer
+}
+
+func (st *stabilizers) remove(workload string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	delete(st.byWorkload, workload)
+}
+
+// runOperator starts the controller-runtime manager that reconciles ForecastPolicy,
+// DataSource, and ReplicaBudget resources. It blocks until the context is canceled.
 func runOperator(ctx context.Context, cfg *config.Config, store storage.Store, multiForecaster *MultiForecaster, logger *slog.Logger) error {
 	scheme := runtime.NewScheme()
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
@@ -51,18 +171,24 @@ func runOperator(ctx context.Context, cfg *config.Config, store storage.Store, m
 	}
 
 	// The forecaster already serves /metrics, so the manager's metrics server is disabled.
+	// Secrets are read from the API server: only the BYOM auth Secret of a policy is
+	// needed, and caching would watch and hold every Secret in the cluster.
 	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:  scheme,
 		Metrics: metricsserver.Options{BindAddress: "0"},
+		Client: client.Options{
+			Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.Secret{}}},
+		},
 	})
 	if err != nil {
 		return fmt.Errorf("create controller manager: %w", err)
 	}
 
+	budgets := newBudgetCoordinator()
 	reconciler := &controller.ForecastPolicyReconciler{
 		Client:        mgr.GetClient(),
 		Scheme:        mgr.GetScheme(),
-		Manager:       &forecasterManager{multiForecaster: multiForecaster, store: store, logger: logger},
+		Manager:       newForecasterManager(multiForecaster, budgets, store, cfg, logger),
 		Store:         store,
 		ScalerAddress: cfg.ScalerAddress,
 		Logger:        logger,
@@ -71,6 +197,15 @@ func runOperator(ctx context.Context, cfg *config.Config, store storage.Store, m
 		return fmt.Errorf("setup reconciler: %w", err)
 	}
 
+	budgetReconciler := &controller.ReplicaBudgetReconciler{
+		Client:  mgr.GetClient(),
+		Budgets: budgets,
+		Logger:  logger,
+	}
+	if err := budgetReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("setup replicabudget reconciler: %w", err)
+	}
+
 	logger.Info("controller manager started")
 	return mgr.Start(ctx)
 }
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral/cmd/forecaster/config"
+	"github.com/HatiCode/kedastral/pkg/capacity"
+	"github.com/HatiCode/kedastral/pkg/storage"
+)
+
+// newSeriesServer serves a per-minute series ending now in the shape read by the
+// http adapter with valuePath data.#.value and timestampPath data.#.timestamp.
+func newSeriesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now().UTC().Truncate(time.Minute)
+		points := make([]string, 0, 30)
+		for i := 29; i >= 0; i-- {
+			ts := now.Add(-time.Duration(i) * time.Minute).Format(time.RFC3339)
+			points = append(points, fmt.Sprintf(`{"timestamp":%q,"value":%d}`, ts, 100+i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(points, ","))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func operatorWorkloadConfig(url string) config.WorkloadConfig {
+	return config.WorkloadConfig{
+		Name:    "shop-web",
+		Metric:  "value",
+		Adapter: "http",
+		AdapterConfig: map[string]string{
+			"url":           url,
+			"valuePath":     "data.#.value",
+			"timestampPath": "data.#.timestamp",
+		},
+		Horizon:       10 * time.Minute,
+		Step:          time.Minute,
+		Interval:      20 * time.Millisecond,
+		Window:        30 * time.Minute,
+		TrainInterval: 100 * time.Millisecond,
+		Model:         "baseline",
+		TargetPerPod:  10,
+		MinReplicas:   1,
+		MaxReplicas:   50,
+	}
+}
+
+func TestForecasterManager_UnchangedUpsertKeepsTrainer(t *testing.T) {
+	server := newSeriesServer(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mf := NewMultiForecaster(nil, storage.NewMemoryStore(), logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		mf.wg.Wait()
+	}()
+	mf.Start(ctx)
+
+	manager := newForecasterManager(mf, newBudgetCoordinator(), mf.GetStore(), &config.Config{}, logger)
+	wc := operatorWorkloadConfig(server.URL)
+
+	// The reconciler upserts the unchanged policy far more often than it trains.
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if err := manager.Upsert(ctx, wc); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	mf.mu.Lock()
+	wf := mf.running[wc.Name].forecaster
+	mf.mu.Unlock()
+	if v := wf.version(); v < 2 {
+		t.Errorf("version() = %d, want the background trainer to have installed a version after the first", v)
+	}
+
+	// A changed configuration still replaces the forecaster.
+	wc.TargetPerPod = 20
+	if err := manager.Upsert(ctx, wc); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	mf.mu.Lock()
+	replaced := mf.running[wc.Name].forecaster != wf
+	mf.mu.Unlock()
+	if !replaced {
+		t.Error("a changed configuration should rebuild the forecaster")
+	}
+}
+
+func TestForecasterManager_RebuildKeepsStabilizer(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	// The multi-forecaster is not started, so no tick stabilizes a plan of its own.
+	mf := NewMultiForecaster(nil, storage.NewMemoryStore(), logger)
+	manager := newForecasterManager(mf, newBudgetCoordinator(), mf.GetStore(), &config.Config{}, logger)
+	stabilizer := func() *capacity.Stabilizer {
+		mf.mu.Lock()
+		defer mf.mu.Unlock()
+		return mf.running["shop-web"].forecaster.stabilizer
+	}
+
+	wc := operatorWorkloadConfig("http://localhost:9090")
+	wc.ScaleDownWindow = 10 * time.Minute
+	policy := capacity.Policy{MinReplicas: 1, MaxReplicas: 50}
+	start := time.Now()
+
+	for range 2 {
+		if err := manager.Upsert(context.Background(), wc); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+	}
+	stabilizer().Stabilize(start, 60, []int{10}, policy, nil)
+
+	// A policy edit that leaves stabilization alone rebuilds the forecaster but
+	// keeps the scale-down window's history.
+	wc.TargetPerPod = 20
+	if err := manager.Upsert(context.Background(), wc); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if got := stabilizer().Stabilize(start.Add(time.Minute), 60, []int{2}, policy, nil); got[0] != 10 {
+		t.Errorf("replicas = %d, want the scale-down held at 10", got[0])
+	}
+
+	wc.ScaleDownWindow = 5 * time.Minute
+	if err := manager.Upsert(context.Background(), wc); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if got := stabilizer().Stabilize(start.Add(2*time.Minute), 60, []int{2}, policy, nil); got[0] != 2 {
+		t.Errorf("replicas = %d, want a fresh stabilizer after its settings changed", got[0])
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/HatiCode/kedastral/cmd/forecaster/config"
+	"github.com/HatiCode/kedastral/pkg/adapters"
+	"github.com/HatiCode/kedastral/pkg/capacity"
+	"github.com/HatiCode/kedastral/pkg/features"
+)
+
+// recommendTimeout bounds one recommender run, which collects days of history.
+const recommendTimeout = 2 * time.Minute
+
+// targetRecommender learns a workload's TargetPerPod from its observed load,
+// replicas, and latency, and optionally plans with it.
+type targetRecommender struct {
+	latency  adapters.Adapter
+	replicas adapters.Adapter
+	options  capacity.RecommendOptions
+	lookback time.Duration
+	interval time.Duration
+
+	// key identifies the settings a result was computed with, so a result from
+	// before a policy change is recomputed rather than reused.
+	key string
+
+	// apply plans with recommendations of at least minConfidence, and with the
+	// configured target otherwise.
+	apply         bool
+	minConfidence float64
+	configured    float64
+
+	// results holds the latest result of every workload. The operator shares one
+	// across forecaster rebuilds, so a policy update does not rerun the recommender.
+	results *recommendations
+}
+
+// newTargetRecommender builds the recommender of a workload configured with one.
+func newTargetRecommender(wc config.WorkloadConfig) (*targetRecommender, error) {
+	rc := wc.Recommendation
+	stepSeconds := int(wc.Step.Seconds())
+	latency, err := adapters.New(rc.LatencyAdapter, rc.LatencyAdapterConfig, stepSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("create latency adapter for workload %q: %w", wc.Name, err)
+	}
+	replicas, err := adapters.New(rc.ReplicasAdapter, rc.ReplicasAdapterConfig, stepSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("create replicas adapter for workload %q: %w", wc.Name, err)
+	}
+	return &targetRecommender{
+		latency:       latency,
+		replicas:      replicas,
+		options:       capacity.RecommendOptions{LatencySLO: rc.LatencySLO},
+		lookback:      rc.Lookback,
+		interval:      rc.Interval,
+		key:           fmt.Sprintf("%s %v %s %v %v %v %v", rc.LatencyAdapter, rc.LatencyAdapterConfig, rc.ReplicasAdapter, rc.ReplicasAdapterConfig, wc.AdapterConfig, rc.LatencySLO, rc.Lookback),
+		apply:         rc.Apply,
+		minConfidence: rc.MinConfidence,
+		configured:    wc.TargetPerPod,
+		results:       newRecommendations(),
+	}, nil
+}
+
+// recommendations holds the latest TargetPerPod recommendation of each workload.
+// Safe for concurrent use.
+type recommendations struct {
+	mu         sync.Mutex
+	byWorkload map[string]recommendationResult
+}
+
+// recommendationResult is the outcome of the latest recommender run of a workload.
+type recommendationResult struct {
+	key         string
+	attemptedAt time.Time
+
+	// recommendation and computedAt are those of the latest successful run.
+	recommendation capacity.TargetRecommendation
+	computedAt     time.Time
+}
+
+func newRecommendations() *recommendations {
+	return &recommendations{byWorkload: make(map[string]recommendationResult)}
+}
+
+// get returns the latest result of a workload computed with the settings key.
+func (r *recommendations) get(workload, key string) (recommendationResult, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result, ok := r.byWorkload[workload]
+	return result, ok && result.key == key
+}
+
+// record stores the outcome of a run at, keeping the previous recommendation when
+// the run failed.
+func (r *recommendations) record(workload, key string, at time.Time, rec capacity.TargetRecommendation, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := r.byWorkload[workload]
+	if result.key != key {
+		result = recommendationResult{key: key}
+	}
+	result.attemptedAt = at
+	if err == nil {
+		result.recommendation, result.computedAt = rec, at
+	}
+	r.byWorkload[workload] = result
+}
+
+// latest returns the latest successful recommendation of a workload and when it
+// was computed.
+func (r *recommendations) latest(workload string) (capacity.TargetRecommendation, time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result, ok := r.byWorkload[workload]
+	if !ok || result.computedAt.IsZero() {
+		return capacity.TargetRecommendation{}, time.Time{}, false
+	}
+	return result.recommendation, result.computedAt, true
+}
+
+func (r *recommendations) remove(workload string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.byWorkload, workload)
+}
+
+// runRecommender runs the recommender every interval until ctx is canceled,
+// starting when the interval since the last run with the same settings has passed.
+func (wf *WorkloadForecaster) runRecommender(ctx context.Context) {
+	r := wf.recommender
+	wf.logger.Info("starting targetPerPod recommender", "interval", r.interval, "lookback", r.lookback, "latency_slo", r.options.LatencySLO)
+
+	for {
+		var wait time.Duration
+		if result, ok := r.results.get(wf.name, r.key); ok {
+			wait = time.Until(result.attemptedAt.Add(r.interval))
+		}
+		timer := time.NewTimer(max(wait, 0))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		wf.recommend(ctx)
+	}
+}
+
+// recommend collects the lookback and records a new recommendation.
+func (wf *WorkloadForecaster) recommend(ctx context.Context) {
+	r := wf.recommender
+	start := time.Now()
+
+	recCtx, cancel := context.WithTimeout(ctx, recommendTimeout)
+	samples, err := r.collect(recCtx, wf.adapter, wf.builder, wf.step)
+	cancel()
+	var rec capacity.TargetRecommendation
+	if err == nil {
+		rec, err = capacity.RecommendTargetPerPod(samples, r.options)
+	}
+	r.results.record(wf.name, r.key, start, rec, err)
+
+	if err != nil {
+		if wf.metrics != nil {
+			wf.metrics.RecordError("recommender", "recommend_failed")
+		}
+		wf.logger.Warn("targetPerPod recommendation failed", "error", err)
+		return
+	}
+	if wf.metrics != nil {
+		wf.metrics.SetRecommendation(rec.TargetPerPod, rec.Confidence)
+	}
+	wf.logger.Info("targetPerPod recommended",
+		"target_per_pod", rec.TargetPerPod,
+		"confidence", rec.Confidence,
+		"samples", rec.Samples,
+		"breach_observed", rec.BreachObserved,
+		"duration_ms", time.Since(start).Milliseconds())
+}
+
+// collect gathers the lookback of load, replicas, and latency, joined on the step
+// grid. Steps missing any of the three are left out.
+func (r *targetRecommender) collect(ctx context.Context, load adapters.Adapter, builder *features.Builder, step time.Duration) ([]capacity.LoadSample, error) {
+	seconds := int(r.lookback.Seconds())
+	names := []string{"load", "replicas", "latency"}
+	series := make([]map[int64]float64, len(names))
+	for i, adapter := range []adapters.Adapter{load, r.replicas, r.latency} {
+		df, err := adapter.Collect(ctx, seconds)
+		if err != nil {
+			return nil, fmt.Errorf("collect %s: %w", names[i], err)
+		}
+		frame, err := builder.BuildFeatures(*df)
+		if err != nil {
+			return nil, fmt.Errorf("collect %s: %w", names[i], err)
+		}
+		series[i] = make(map[int64]float64, len(frame.Rows))
+		for _, row := range frame.Rows {
+			if ts, ok := row["timestamp"]; ok {
+				series[i][int64(ts)/int64(step.Seconds())] = row["value"]
+			}
+		}
+	}
+
+	samples := make([]capacity.LoadSample, 0, len(series[0]))
+	for slot, value := range series[0] {
+		replicas, ok := series[1][slot]
+		if !ok {
+			continue
+		}
+		latency, ok := series[2][slot]
+		if !ok {
+			continue
+		}
+		samples = append(samples, capacity.LoadSample{Load: value, Replicas: replicas, Latency: latency})
+	}
+	return samples, nil
+}
+
+// applyRecommendation plans with the recommended TargetPerPod while it is confident
+// enough, and with the configured one otherwise, when the recommender applies its
+// recommendations.
+func (wf *WorkloadForecaster) applyRecommendation() {
+	r := wf.recommender
+	if r == nil || !r.apply {
+		return
+	}
+	target := r.configured
+	if result, ok := r.results.get(wf.name, r.key); ok && !result.computedAt.IsZero() && result.recommendation.Confidence >= r.minConfidence {
+		target = result.recommendation.TargetPerPod
+	}
+	if target != wf.policy.TargetPerPod {
+		wf.logger.Info("planning with new targetPerPod", "previous", wf.policy.TargetPerPod, "target_per_pod", target)
+		wf.policy.TargetPerPod = target
+	}
+}
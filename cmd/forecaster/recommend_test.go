@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/adapters"
+	"github.com/HatiCode/kedastral/pkg/capacity"
+	"github.com/HatiCode/kedastral/pkg/features"
+)
+
+func TestForecaster_Recommend(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Minute)
+	var load, replicas, latency []adapters.Row
+	for i := range 200 {
+		ts := now.Add(time.Duration(i-200) * time.Minute).Format(time.RFC3339)
+		perPod := float64(i + 1)
+		p99 := 0.1
+		if perPod > 150 {
+			p99 = 0.4
+		}
+		load = append(load, adapters.Row{"ts": ts, "value": 4 * perPod})
+		replicas = append(replicas, adapters.Row{"ts": ts, "value": 4.0})
+		latency = append(latency, adapters.Row{"ts": ts, "value": p99})
+	}
+	// A step without a replica count is left out.
+	load = append(load, adapters.Row{"ts": now.Format(time.RFC3339), "value": 9999.0})
+
+	f := &WorkloadForecaster{
+		name:    "web",
+		adapter: &staticAdapter{rows: load},
+		builder: features.NewBuilder(),
+		policy:  &capacity.Policy{TargetPerPod: 100},
+		step:    time.Minute,
+		recommender: &targetRecommender{
+			latency:       &staticAdapter{rows: latency},
+			replicas:      &staticAdapter{rows: replicas},
+			options:       capacity.RecommendOptions{LatencySLO: 0.25},
+			lookback:      200 * time.Minute,
+			interval:      time.Hour,
+			apply:         true,
+			minConfidence: 0.1,
+			configured:    100,
+			results:       newRecommendations(),
+		},
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	f.recommend(context.Background())
+	rec, _, ok := f.recommender.results.latest("web")
+	if !ok || rec.TargetPerPod != 150 || rec.Samples != 200 || !rec.BreachObserved {
+		t.Fatalf("recommendation = %+v (%v), want target 150 from 200 samples", rec, ok)
+	}
+
+	f.applyRecommendation()
+	if f.policy.TargetPerPod != 150 {
+		t.Errorf("TargetPerPod = %v, want the recommended 150", f.policy.TargetPerPod)
+	}
+
+	// Below the minimum confidence the configured target applies again.
+	f.recommender.minConfidence = 0.99
+	f.applyRecommendation()
+	if f.policy.TargetPerPod != 100 {
+		t.Errorf("TargetPerPod = %v, want the configured 100", f.policy.TargetPerPod)
+	}
+}
@@ -115,6 +115,29 @@ func handleGetSnapshot(store storage.Store, staleAfter time.Duration, logger *sl
 			"values":          snapshot.Values,
 			"desiredReplicas": snapshot.DesiredReplicas,
 		}
+		if snapshot.IntervalSeconds > 0 {
+			resp["intervalSeconds"] = snapshot.IntervalSeconds
+		}
+		if len(snapshot.Quantiles) > 0 {
+			resp["quantiles"] = snapshot.Quantiles
+		}
+		if snapshot.Model != "" {
+			resp["model"] = snapshot.Model
+		}
+		if len(snapshot.Signals) > 0 {
+			resp["signals"] = snapshot.Signals
+			resp["bindingMetrics"] = snapshot.BindingMetrics
+		}
+		if len(snapshot.Overrides) > 0 {
+			resp["overrides"] = snapshot.Overrides
+		}
+		if len(snapshot.Backlog) > 0 {
+			resp["queueDepth"] = snapshot.QueueDepth
+			resp["backlog"] = snapshot.Backlog
+		}
+		if len(snapshot.Trace) > 0 {
+			resp["trace"] = snapshot.Trace
+		}
 
 		if err := httpx.WriteJSON(w, http.StatusOK, resp); err != nil {
 			logger.Error("failed to write JSON response", "error", err)
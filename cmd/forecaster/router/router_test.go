@@ -2,6 +2,7 @@ package router
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"log/slog"
 	"net/http"
@@ -223,6 +224,37 @@ func TestGetSnapshot_JSONResponse(t *testing.T) {
 	}
 }
 
+func TestGetSnapshot_Quantiles(t *testing.T) {
+	store := storage.NewMemoryStore()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	snapshot := storage.Snapshot{
+		Workload:        "test-api",
+		GeneratedAt:     time.Now(),
+		StepSeconds:     60,
+		Values:          []float64{100, 110},
+		DesiredReplicas: []int{2, 3},
+		Quantiles:       map[float64][]float64{0.9: {120, 135}},
+	}
+	if err := store.Put(context.Background(), snapshot); err != nil {
+		t.Fatalf("failed to put snapshot: %v", err)
+	}
+
+	mux := SetupRoutes(store, 2*time.Minute, logger)
+	req := httptest.NewRequest(http.MethodGet, "/forecast/current?workload=test-api", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	// The scaler decodes the response into a Snapshot.
+	var got storage.Snapshot
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if q := got.Quantiles[0.9]; len(q) != 2 || q[1] != 135 {
+		t.Errorf("quantiles = %v, want the p90 forecast", got.Quantiles)
+	}
+}
+
 func TestListWorkloads_Empty(t *testing.T) {
 	store := storage.NewMemoryStore()
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/models"
+)
+
+// defaultTrainTimeout bounds a training pass when no timeout is configured.
+const defaultTrainTimeout = 5 * time.Second
+
+// runTrainer retrains the model every trainInterval on the most recent training frame
+// published by tick, until ctx is canceled. It runs alongside the forecast loop so
+// expensive models do not hold up predictions.
+func (wf *WorkloadForecaster) runTrainer(ctx context.Context) {
+	wf.logger.Info("starting background trainer", "train_interval", wf.trainInterval, "train_timeout", wf.trainTimeout)
+
+	ticker := time.NewTicker(wf.trainInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			frame, ok := wf.takeLatestFrame()
+			if !ok {
+				wf.logger.Debug("no new training data since last training pass")
+				continue
+			}
+			wf.train(ctx, frame)
+		}
+	}
+}
+
+// train fits a model on frame and, on success, installs it as the version used for
+// prediction. With a model factory the candidate is a fresh instance seeded with the
+// current model's state and carrying its BYOM breaker and status, so in-flight
+// predictions never observe a half-trained model; otherwise the current model is
+// trained in place. It reports whether training succeeded.
+func (wf *WorkloadForecaster) train(ctx context.Context, frame models.FeatureFrame) bool {
+	current := wf.currentModel()
+	candidate := current
+	if wf.newModel != nil && wf.trainInterval > 0 {
+		candidate = wf.newModel()
+		seedModel(candidate, current)
+		models.CarryState(candidate, current)
+	}
+
+	timeout := wf.trainTimeout
+	if timeout <= 0 {
+		timeout = defaultTrainTimeout
+	}
+
+	start := time.Now()
+	trainCtx, cancel := context.WithTimeout(ctx, timeout)
+	err := candidate.Train(trainCtx, frame)
+	cancel()
+	duration := time.Since(start)
+
+	if err != nil {
+		if wf.metrics != nil {
+			wf.metrics.RecordError("model", "train_failed")
+		}
+		wf.logger.Warn("model training failed; predicting with the last trained version",
+			"model", candidate.Name(),
+			"version", wf.version(),
+			"rows", len(frame.Rows),
+			"duration_ms", duration.Milliseconds(),
+			"error", err,
+		)
+		return false
+	}
+
+	version := wf.installModel(candidate, time.Now())
+
+	if wf.metrics != nil {
+		wf.metrics.RecordTrain(duration.Seconds())
+	}
+	wf.logger.Debug("trained model",
+		"model", candidate.Name(),
+		"version", version,
+		"rows", len(frame.Rows),
+		"duration_ms", duration.Milliseconds(),
+	)
+
+	if wf.persistModel {
+		saveCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		wf.saveModel(saveCtx, candidate)
+		cancel()
+	}
+	return true
+}
+
+// seedModel copies the trained state of src into dst when both support persistence,
+// so a fresh candidate keeps what the current version has learned (for example
+// baseline seasonal buckets outside the training window).
+func seedModel(dst, src models.Model) {
+	from, ok := src.(models.Persistable)
+	if !ok {
+		return
+	}
+	to, ok := dst.(models.Persistable)
+	if !ok {
+		return
+	}
+
+	state, err := from.MarshalState()
+	if err != nil {
+		return
+	}
+	_ = to.UnmarshalState(state)
+}
+
+// installModel makes model the version used for prediction and returns its version
+// number. trainedAt is the zero time for restored models whose training time is unknown.
+// A candidate replacing the current model takes over its calibration, which kept
+// recording forecast errors while the candidate trained.
+func (wf *WorkloadForecaster) installModel(model models.Model, trainedAt time.Time) int {
+	wf.modelMu.Lock()
+	if model != wf.model {
+		models.CarryCalibration(model, wf.model)
+	}
+	wf.model = model
+	wf.modelVersion++
+	version := wf.modelVersion
+	if !trainedAt.IsZero() {
+		wf.trainedAt = trainedAt
+	}
+	wf.modelMu.Unlock()
+
+	if wf.metrics != nil {
+		wf.metrics.SetModelVersion(version)
+		if !trainedAt.IsZero() {
+			wf.metrics.SetTrainingAge(0)
+		}
+	}
+	return version
+}
+
+// currentModel returns the model version used for prediction.
+func (wf *WorkloadForecaster) currentModel() models.Model {
+	wf.modelMu.RLock()
+	defer wf.modelMu.RUnlock()
+	return wf.model
+}
+
+// version returns the number of model versions installed so far; 0 means no
+// trained model is available yet.
+func (wf *WorkloadForecaster) version() int {
+	wf.modelMu.RLock()
+	defer wf.modelMu.RUnlock()
+	return wf.modelVersion
+}
+
+// trainingAge returns how long ago the current version was trained, and false if it
+// has not been trained in this process.
+func (wf *WorkloadForecaster) trainingAge() (time.Duration, bool) {
+	wf.modelMu.RLock()
+	defer wf.modelMu.RUnlock()
+	if wf.trainedAt.IsZero() {
+		return 0, false
+	}
+	return time.Since(wf.trainedAt), true
+}
+
+// setLatestFrame publishes the newest training frame for the background trainer.
+func (wf *WorkloadForecaster) setLatestFrame(frame models.FeatureFrame) {
+	wf.modelMu.Lock()
+	defer wf.modelMu.Unlock()
+	wf.latestFrame = &frame
+}
+
+// takeLatestFrame returns the newest training frame and clears it, so the trainer
+// does not refit on the same data twice.
+func (wf *WorkloadForecaster) takeLatestFrame() (models.FeatureFrame, bool) {
+	wf.modelMu.Lock()
+	defer wf.modelMu.Unlock()
+	if wf.latestFrame == nil {
+		return models.FeatureFrame{}, false
+	}
+	frame := *wf.latestFrame
+	wf.latestFrame = nil
+	return frame, true
+}
+
+// byomStatus returns the status of the current model's BYOM service, and false if
+// the model is not BYOM.
+func (wf *WorkloadForecaster) byomStatus() (models.BYOMStatus, bool) {
+	if byom := wf.byomModel(); byom != nil {
+		return byom.Status(), true
+	}
+	return models.BYOMStatus{}, false
+}
+
+// byomModel returns the current model's BYOM model, unwrapping wrappers such as the
+// conformal calibrator, or nil if the model is not BYOM.
+func (wf *WorkloadForecaster) byomModel() *models.BYOMModel {
+	model := wf.currentModel()
+	for {
+		switch m := model.(type) {
+		case *models.BYOMModel:
+			return m
+		case interface{ Inner() models.Model }:
+			model = m.Inner()
+		default:
+			return nil
+		}
+	}
+}
+
+// unwrapFallback returns the fallback chain inside model, unwrapping wrappers such as
+// the conformal calibrator, and false if model has no fallback chain.
+func unwrapFallback(model models.Model) (*models.FallbackModel, bool) {
+	for {
+		switch m := model.(type) {
+		case *models.FallbackModel:
+			return m, true
+		case interface{ Inner() models.Model }:
+			model = m.Inner()
+		default:
+			return nil, false
+		}
+	}
+}
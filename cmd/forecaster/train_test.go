@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral/cmd/forecaster/metrics"
+	"github.com/HatiCode/kedastral/pkg/models"
+)
+
+// stubModel records training calls, runs onTrain during training when set, and fails
+// training when err is set.
+type stubModel struct {
+	err     error
+	trains  int
+	onTrain func()
+}
+
+func (m *stubModel) Train(ctx context.Context, history models.FeatureFrame) error {
+	m.trains++
+	if m.onTrain != nil {
+		m.onTrain()
+	}
+	return m.err
+}
+
+func (m *stubModel) Predict(ctx context.Context, features models.FeatureFrame) (models.Forecast, error) {
+	return models.Forecast{Values: []float64{1}}, nil
+}
+
+func (m *stubModel) Name() string { return "stub" }
+
+func trainFrame() models.FeatureFrame {
+	return models.FeatureFrame{Rows: []map[string]float64{{"value": 1}, {"value": 2}}}
+}
+
+func TestForecaster_Train_InstallsCandidate(t *testing.T) {
+	initial := &stubModel{}
+	var built []*stubModel
+
+	wf := &WorkloadForecaster{
+		model:         initial,
+		trainInterval: time.Minute,
+		newModel: func() models.Model {
+			m := &stubModel{}
+			built = append(built, m)
+			return m
+		},
+		logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		metrics: metrics.New("test-train-install"),
+	}
+
+	if !wf.train(context.Background(), trainFrame()) {
+		t.Fatal("train() = false, want true")
+	}
+
+	if len(built) != 1 || wf.currentModel() != built[0] {
+		t.Fatal("a successful training pass should install the fresh candidate")
+	}
+	if initial.trains != 0 {
+		t.Error("background training must not mutate the model serving predictions")
+	}
+	if wf.version() != 1 {
+		t.Errorf("version() = %d, want 1", wf.version())
+	}
+	if _, ok := wf.trainingAge(); !ok {
+		t.Error("trainingAge() should be known after training")
+	}
+}
+
+func TestForecaster_Train_KeepsBYOMBreakerOpen(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	newModel := func() models.Model {
+		return models.NewBYOMModel(server.URL+"/predict", "rps", 60, 1800).WithResilience(models.BYOMResilience{
+			BreakerThreshold: 1,
+			BreakerCooldown:  time.Hour,
+		})
+	}
+	wf := &WorkloadForecaster{
+		model:         newModel(),
+		trainInterval: time.Minute,
+		newModel:      newModel,
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		metrics:       metrics.New("test-train-byom-breaker"),
+	}
+
+	if wf.train(context.Background(), trainFrame()) {
+		t.Fatal("train() = true, want the failing service to fail training")
+	}
+	contacted := calls.Load()
+	wf.train(context.Background(), trainFrame())
+	if got := calls.Load(); got != contacted {
+		t.Errorf("service called %d more times, want the open circuit to carry over to the next pass", got-contacted)
+	}
+	if status, _ := wf.byomStatus(); !status.CircuitOpen {
+		t.Error("byomStatus().CircuitOpen = false, want true")
+	}
+}
+
+func TestForecaster_Train_FailureKeepsLastVersion(t *testing.T) {
+	good := &stubModel{}
+	wf := &WorkloadForecaster{
+		model:         good,
+		modelVersion:  3,
+		trainInterval: time.Minute,
+		newModel:      func() models.Model { return &stubModel{err: errors.New("boom")} },
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		metrics:       metrics.New("test-train-failure"),
+	}
+
+	if wf.train(context.Background(), trainFrame()) {
+		t.Fatal("train() = true, want false")
+	}
+	if wf.currentModel() != good || wf.version() != 3 {
+		t.Errorf("failed training replaced the model: version = %d", wf.version())
+	}
+}
+
+func TestForecaster_Train_InPlaceWithoutTrainInterval(t *testing.T) {
+	model := &stubModel{}
+	wf := &WorkloadForecaster{
+		model:    model,
+		newModel: func() models.Model { t.Fatal("factory should not be used"); return nil },
+		logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	wf.train(context.Background(), trainFrame())
+	wf.train(context.Background(), trainFrame())
+
+	if model.trains != 2 || wf.version() != 2 {
+		t.Errorf("trains = %d, version = %d; want 2, 2", model.trains, wf.version())
+	}
+}
+
+func TestForecaster_RunTrainer(t *testing.T) {
+	wf := &WorkloadForecaster{
+		model:         &stubModel{},
+		modelVersion:  1,
+		trainInterval: 10 * time.Millisecond,
+		newModel:      func() models.Model { return &stubModel{} },
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go wf.runTrainer(ctx)
+
+	time.Sleep(50 * time.Millisecond)
+	if wf.version() != 1 {
+		t.Fatalf("trainer retrained without new data: version = %d", wf.version())
+	}
+
+	wf.setLatestFrame(trainFrame())
+
+	deadline := time.Now().Add(time.Second)
+	for wf.version() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("background trainer did not install a new version")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if wf.version() != 2 {
+		t.Errorf("version() = %d, want 2 (one pass per published frame)", wf.version())
+	}
+}
+
+func TestForecaster_Train_KeepsCalibrationRecordedDuringTraining(t *testing.T) {
+	conformal := func(inner models.Model) *models.ConformalModel {
+		return models.NewConformalModel(inner, 60, models.ConformalOptions{MinSamples: 5})
+	}
+	current := conformal(&stubModel{})
+
+	// The live model keeps forecasting, and recording its errors, while the
+	// candidate trains.
+	var rows []map[string]float64
+	observe := func() {
+		rows = append(rows, map[string]float64{"timestamp": float64(len(rows) * 60), "value": 3})
+		if _, err := current.Predict(context.Background(), models.FeatureFrame{Rows: rows}); err != nil {
+			t.Fatalf("Predict() error = %v", err)
+		}
+	}
+	wf := &WorkloadForecaster{
+		model:         current,
+		trainInterval: time.Minute,
+		newModel: func() models.Model {
+			return conformal(&stubModel{onTrain: func() {
+				for range 10 {
+					observe()
+				}
+			}})
+		},
+		logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		metrics: metrics.New("test-train-calibration"),
+	}
+
+	if !wf.train(context.Background(), trainFrame()) {
+		t.Fatal("train() = false, want true")
+	}
+	rows = append(rows, map[string]float64{"timestamp": float64(len(rows) * 60), "value": 3})
+	forecast, err := wf.currentModel().Predict(context.Background(), models.FeatureFrame{Rows: rows})
+	if err != nil {
+		t.Fatalf("Predict() error = %v", err)
+	}
+	if len(forecast.Quantiles) == 0 {
+		t.Error("the installed candidate lost the residuals recorded while it trained")
+	}
+}
@@ -98,6 +98,20 @@ func registerPolicyTools(s *server.MCPServer, reader PolicyReader, log *slog.Log
 		),
 		handleGetForecastPolicy(reader, log),
 	)
+
+	s.AddTool(
+		mcp.NewTool("recommend_target_per_pod",
+			mcp.WithDescription("Get the targetPerPod a ForecastPolicy's recommender learned from the load at which latency breaches its SLO, with its confidence, compared to the configured targetPerPod. Requires the operator and capacity.targetRecommendation."),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the ForecastPolicy"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace of the ForecastPolicy (default: default)"),
+			),
+		),
+		handleRecommendTargetPerPod(reader, log),
+	)
 }
 
 func handleListForecastPolicies(reader PolicyReader, log *slog.Logger) server.ToolHandlerFunc {
@@ -185,6 +199,56 @@ func handleGetForecastPolicy(reader PolicyReader, log *slog.Logger) server.ToolH
 	}
 }
 
+func handleRecommendTargetPerPod(reader PolicyReader, log *slog.Logger) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, err := req.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError("name parameter is required"), nil
+		}
+		namespace := req.GetString("namespace", "default")
+
+		policy, err := reader.Get(ctx, namespace, name)
+		if err != nil {
+			log.Error("recommend_target_per_pod failed", "namespace", namespace, "name", name, "error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get forecast policy %s/%s: %v", namespace, name, err)), nil
+		}
+
+		spec := policy.Spec.Capacity.TargetRecommendation
+		if spec == nil {
+			return mcp.NewToolResultText(fmt.Sprintf("ForecastPolicy %s/%s has no targetPerPod recommender; set capacity.targetRecommendation to enable it.", namespace, name)), nil
+		}
+		rec := policy.Status.TargetRecommendation
+		if rec == nil {
+			return mcp.NewToolResultText(fmt.Sprintf("ForecastPolicy %s/%s has no recommendation yet; the recommender runs every %s.", namespace, name, spec.Interval)), nil
+		}
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "targetPerPod recommendation for %s/%s\n", policy.Namespace, policy.Name)
+		fmt.Fprintf(&sb, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+		fmt.Fprintf(&sb, "Recommended targetPerPod: %.2f (configured %.2f)\n", rec.TargetPerPod, policy.Spec.Capacity.TargetPerPod)
+		fmt.Fprintf(&sb, "Confidence:               %.0f%% (applied at %.0f%%)\n", rec.Confidence*100, spec.MinConfidence*100)
+		fmt.Fprintf(&sb, "Samples:                  %d over %s\n", rec.Samples, spec.Lookback)
+		fmt.Fprintf(&sb, "Latency SLO:              %g\n", spec.LatencySLO)
+		fmt.Fprintf(&sb, "Computed:                 %s (%s ago)\n\n", rec.ComputedAt.Format(time.RFC3339), time.Since(rec.ComputedAt.Time).Round(time.Second))
+
+		if rec.BreachObserved {
+			fmt.Fprintf(&sb, "Latency breached the SLO above %.2f per pod, so this is the highest per-pod load observed to stay within it.\n", rec.TargetPerPod)
+		} else {
+			fmt.Fprintf(&sb, "Latency never breached the SLO, so %.2f per pod, the highest load observed, is only a lower bound; the workload may sustain more.\n", rec.TargetPerPod)
+		}
+		switch {
+		case rec.Applied:
+			fmt.Fprintf(&sb, "The forecaster plans with the recommended targetPerPod.\n")
+		case spec.Apply:
+			fmt.Fprintf(&sb, "The recommendation is not confident enough to apply; the forecaster plans with the configured targetPerPod.\n")
+		default:
+			fmt.Fprintf(&sb, "The recommendation is advisory; set capacity.targetRecommendation.apply to plan with it.\n")
+		}
+
+		return mcp.NewToolResultText(sb.String()), nil
+	}
+}
+
 func readyState(policy *kedastralv1alpha1.ForecastPolicy) (status, reason string) {
 	cond := meta.FindStatusCondition(policy.Status.Conditions, "Ready")
 	if cond == nil {
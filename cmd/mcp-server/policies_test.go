@@ -161,3 +161,42 @@ func TestHandleGetForecastPolicy_NotFound(t *testing.T) {
 		t.Fatal("expected tool error when policy not found")
 	}
 }
+
+func TestHandleRecommendTargetPerPod(t *testing.T) {
+	policy := samplePolicy()
+	policy.Spec.Capacity.TargetRecommendation = &kedastralv1alpha1.TargetRecommendationSpec{
+		LatencySLO:    0.25,
+		Lookback:      "7d",
+		Interval:      "1h",
+		MinConfidence: 0.8,
+	}
+	reader := &fakePolicyReader{policies: []kedastralv1alpha1.ForecastPolicy{policy}}
+	handler := handleRecommendTargetPerPod(reader, discardLogger())
+	args := map[string]any{"name": "web-api", "namespace": "shop"}
+
+	result, err := handler(context.Background(), callToolRequest(args))
+	if err != nil || result.IsError {
+		t.Fatalf("handler error = %v, %v", err, result)
+	}
+	if text := extractText(t, result); !containsStr(text, "no recommendation yet") {
+		t.Errorf("expected pending recommendation, got:\n%s", text)
+	}
+
+	reader.policies[0].Status.TargetRecommendation = &kedastralv1alpha1.TargetRecommendationStatus{
+		TargetPerPod:   140,
+		Confidence:     0.62,
+		Samples:        10080,
+		BreachObserved: true,
+		ComputedAt:     metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+	}
+	result, err = handler(context.Background(), callToolRequest(args))
+	if err != nil || result.IsError {
+		t.Fatalf("handler error = %v, %v", err, result)
+	}
+	text := extractText(t, result)
+	for _, want := range []string{"Recommended targetPerPod: 140.00 (configured 100.00)", "Confidence:               62%", "advisory"} {
+		if !containsStr(text, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, text)
+		}
+	}
+}
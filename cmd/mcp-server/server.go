@@ -12,7 +12,9 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/HatiCode/kedastral/pkg/capacity"
 	"github.com/HatiCode/kedastral/pkg/client"
+	"github.com/HatiCode/kedastral/pkg/storage"
 )
 
 func buildMCPServer(forecasterClient *client.ForecasterClient, policyReader PolicyReader, staleAfter time.Duration, version string, log *slog.Logger) *server.MCPServer {
@@ -97,11 +99,24 @@ func handleGetForecast(fc *client.ForecasterClient, staleAfter time.Duration, lo
 		var sb strings.Builder
 		fmt.Fprintf(&sb, "Workload:        %s\n", snap.Workload)
 		fmt.Fprintf(&sb, "Metric:          %s\n", snap.Metric)
+		if snap.Model != "" {
+			fmt.Fprintf(&sb, "Model:           %s\n", snap.Model)
+		}
 		fmt.Fprintf(&sb, "Generated:       %s (%s, %s)\n", snap.GeneratedAt.Format(time.RFC3339), age, freshness)
 		fmt.Fprintf(&sb, "Step:            %ds\n", snap.StepSeconds)
 		fmt.Fprintf(&sb, "Horizon:         %ds (%d steps)\n", snap.HorizonSeconds, len(snap.Values))
 		fmt.Fprintf(&sb, "\nForecast values (metric):\n  %s\n", formatFloats(snap.Values))
+		for _, signal := range snap.Signals[min(1, len(snap.Signals)):] {
+			fmt.Fprintf(&sb, "\nForecast values (%s, %.2f per pod):\n  %s\n", signal.Metric, signal.TargetPerPod, formatFloats(signal.Values))
+		}
 		fmt.Fprintf(&sb, "\nDesired replicas:\n  %s\n", formatInts(snap.DesiredReplicas))
+		if len(snap.BindingMetrics) > 0 {
+			fmt.Fprintf(&sb, "\nBinding metric:\n  %s\n", strings.Join(snap.BindingMetrics, ", "))
+		}
+		if len(snap.Backlog) > 0 {
+			fmt.Fprintf(&sb, "\nQueue depth:     %.2f\n", snap.QueueDepth)
+			fmt.Fprintf(&sb, "Simulated backlog:\n  %s\n", formatFloats(snap.Backlog))
+		}
 
 		return mcp.NewToolResultText(sb.String()), nil
 	}
@@ -147,6 +162,31 @@ func handleExplainDecision(fc *client.ForecasterClient, staleAfter time.Duration
 		fmt.Fprintf(&sb, "Peak over horizon:      %d replicas (at T+%s)\n", peakReplicas, peakTime)
 		fmt.Fprintf(&sb, "Floor over horizon:     %d replicas\n\n", minReplicas)
 
+		if len(snap.BindingMetrics) > 0 && peakStep < len(snap.BindingMetrics) {
+			fmt.Fprintf(&sb, "Binding metric now:     %s\n", snap.BindingMetrics[0])
+			fmt.Fprintf(&sb, "Binding metric at peak: %s\n\n", snap.BindingMetrics[peakStep])
+		}
+
+		if len(snap.Overrides) > 0 {
+			fmt.Fprintf(&sb, "Scheduled overrides:\n")
+			for _, o := range snap.Overrides {
+				state := "active now"
+				if len(o.Steps) > 0 && o.Steps[0] > 0 {
+					state = fmt.Sprintf("from T+%s", time.Duration(o.Steps[0]*snap.StepSeconds)*time.Second)
+				}
+				fmt.Fprintf(&sb, "  %s (%s): %s\n", o.Name, state, describeOverride(o))
+			}
+			sb.WriteString("\n")
+		}
+
+		if len(snap.Trace) == len(snap.DesiredReplicas) && len(snap.Trace) > 0 {
+			fmt.Fprintf(&sb, "Why %d replicas now:\n%s", currentReplicas, describeStep(snap.Trace[0], 0, snap.StepSeconds))
+			if peakStep > 0 {
+				fmt.Fprintf(&sb, "Why %d replicas at peak (T+%s):\n%s", peakReplicas, peakTime, describeStep(snap.Trace[peakStep], peakStep, snap.StepSeconds))
+			}
+			sb.WriteString("\n")
+		}
+
 		trend := analyzeTrend(snap.DesiredReplicas)
 		fmt.Fprintf(&sb, "Trend: %s\n\n", trend)
 
@@ -155,6 +195,10 @@ func handleExplainDecision(fc *client.ForecasterClient, staleAfter time.Duration
 			currentMetric = snap.Values[0]
 		}
 		fmt.Fprintf(&sb, "Current %s: %.2f\n", snap.Metric, currentMetric)
+		if len(snap.Backlog) > 0 {
+			fmt.Fprintf(&sb, "Current queue depth: %.2f\n", snap.QueueDepth)
+			fmt.Fprintf(&sb, "Backlog at horizon end: %.2f\n", snap.Backlog[len(snap.Backlog)-1])
+		}
 		fmt.Fprintf(&sb, "Forecast horizon: %d minutes ahead\n", snap.HorizonSeconds/60)
 
 		return mcp.NewToolResultText(sb.String()), nil
@@ -207,6 +251,54 @@ func analyzeTrend(replicas []int) string {
 	}
 }
 
+// describeOverride summarizes the bounds a scheduled override applies.
+func describeOverride(o storage.ActiveOverride) string {
+	var rules []string
+	if o.MinReplicas > 0 {
+		rules = append(rules, fmt.Sprintf("at least %d replicas", o.MinReplicas))
+	}
+	if o.MaxReplicas > 0 {
+		rules = append(rules, fmt.Sprintf("at most %d replicas", o.MaxReplicas))
+	}
+	if o.FreezeScaleDown {
+		rules = append(rules, "scale-down frozen")
+	}
+	return strings.Join(rules, ", ")
+}
+
+// describeStep explains step i of the planner trace: where the need came from, how it
+// was rounded, and each clamp that changed it.
+func describeStep(t capacity.StepTrace, i, stepSeconds int) string {
+	var sb strings.Builder
+	switch t.Source {
+	case capacity.SourceQuantile:
+		fmt.Fprintf(&sb, "  load %.2f from the forecast quantile (point forecast %.2f)", t.Load, t.Forecast)
+	case capacity.SourceHeadroom:
+		fmt.Fprintf(&sb, "  load %.2f from the point forecast %.2f with headroom", t.Load, t.Forecast)
+	case capacity.SourceQueue:
+		fmt.Fprintf(&sb, "  %.2f items to drain (arrival rate %.2f/s)", t.Load, t.Forecast)
+	case capacity.SourceCost:
+		fmt.Fprintf(&sb, "  expected demand %.2f pods (forecast %.2f), chosen by the cost planner: %d replicas\n", t.Need, t.Forecast, t.Replicas)
+		return sb.String()
+	}
+	if t.Metric != "" {
+		fmt.Fprintf(&sb, " of %s", t.Metric)
+	}
+	fmt.Fprintf(&sb, " needs %.2f pods", t.Need)
+	if t.NeedStep != i {
+		fmt.Fprintf(&sb, ", planned ahead for the load at T+%s", time.Duration(t.NeedStep*stepSeconds)*time.Second)
+	}
+	fmt.Fprintf(&sb, "\n  rounded to %d\n", t.Rounded)
+	for _, c := range t.Clamps {
+		verb := "capped"
+		if c.To > c.From {
+			verb = "raised"
+		}
+		fmt.Fprintf(&sb, "  %s by %s: %d → %d\n", verb, c.Rule, c.From, c.To)
+	}
+	return sb.String()
+}
+
 func formatFloats(vals []float64) string {
 	if len(vals) == 0 {
 		return "(none)"
@@ -12,6 +12,7 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 
+	"github.com/HatiCode/kedastral/pkg/capacity"
 	"github.com/HatiCode/kedastral/pkg/client"
 	"github.com/HatiCode/kedastral/pkg/storage"
 )
@@ -39,6 +40,8 @@ func makeSnapshotServer(t *testing.T, workload string, snap storage.Snapshot, st
 				HorizonSeconds:  snap.HorizonSeconds,
 				Values:          snap.Values,
 				DesiredReplicas: snap.DesiredReplicas,
+				Overrides:       snap.Overrides,
+				Trace:           snap.Trace,
 			}
 			if err := json.NewEncoder(w).Encode(resp); err != nil {
 				t.Errorf("encode snapshot: %v", err)
@@ -197,6 +200,80 @@ func TestHandleExplainDecision_ScalingUp(t *testing.T) {
 	}
 }
 
+func TestHandleExplainDecision_Overrides(t *testing.T) {
+	snap := storage.Snapshot{
+		Workload:        "my-api",
+		Metric:          "http_rps",
+		GeneratedAt:     time.Now(),
+		StepSeconds:     60,
+		HorizonSeconds:  180,
+		Values:          []float64{100, 100, 100},
+		DesiredReplicas: []int{20, 20, 20},
+		Overrides: []storage.ActiveOverride{
+			{Name: "business-hours", MinReplicas: 20, Steps: []int{0, 1, 2}},
+			{Name: "deploy", FreezeScaleDown: true, Steps: []int{2}},
+		},
+	}
+	srv := makeSnapshotServer(t, "my-api", snap, false)
+	defer srv.Close()
+
+	handler := handleExplainDecision(client.NewForecasterClient(srv.URL), 5*time.Minute, discardLogger())
+	result, err := handler(context.Background(), callToolRequest(map[string]any{"workload": "my-api"}))
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	text := extractText(t, result)
+	for _, want := range []string{
+		"business-hours (active now): at least 20 replicas",
+		"deploy (from T+2m0s): scale-down frozen",
+	} {
+		if !containsStr(text, want) {
+			t.Errorf("expected %q in response, got %q", want, text)
+		}
+	}
+}
+
+func TestHandleExplainDecision_Trace(t *testing.T) {
+	snap := storage.Snapshot{
+		Workload:        "my-api",
+		Metric:          "http_rps",
+		GeneratedAt:     time.Now(),
+		StepSeconds:     60,
+		HorizonSeconds:  120,
+		Values:          []float64{600, 1200},
+		DesiredReplicas: []int{4, 8},
+		Trace: []capacity.StepTrace{
+			{Source: capacity.SourceHeadroom, NeedStep: 1, Forecast: 1200, Load: 1200, Need: 12, Rounded: 12,
+				Clamps: []capacity.Clamp{{Rule: capacity.ClampMaxReplicas, From: 12, To: 8}, {Rule: capacity.ClampUpMaxFactor, From: 8, To: 4}}, Replicas: 4},
+			{Source: capacity.SourceQuantile, NeedStep: 1, Forecast: 1200, Load: 1500, Need: 15, Rounded: 15,
+				Clamps: []capacity.Clamp{{Rule: capacity.ClampMaxReplicas, From: 15, To: 8}}, Replicas: 8},
+		},
+	}
+	srv := makeSnapshotServer(t, "my-api", snap, false)
+	defer srv.Close()
+
+	handler := handleExplainDecision(client.NewForecasterClient(srv.URL), 5*time.Minute, discardLogger())
+	result, err := handler(context.Background(), callToolRequest(map[string]any{"workload": "my-api"}))
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	text := extractText(t, result)
+	for _, want := range []string{
+		"Why 4 replicas now:",
+		"load 1200.00 from the point forecast 1200.00 with headroom needs 12.00 pods, planned ahead for the load at T+1m0s",
+		"capped by upMaxFactorPerStep: 8 → 4",
+		"Why 8 replicas at peak (T+1m0s):",
+		"load 1500.00 from the forecast quantile (point forecast 1200.00) needs 15.00 pods\n",
+		"capped by maxReplicas: 15 → 8",
+	} {
+		if !containsStr(text, want) {
+			t.Errorf("expected %q in response, got %q", want, text)
+		}
+	}
+}
+
 func TestHandleExplainDecision_MissingWorkload(t *testing.T) {
 	fc := client.NewForecasterClient("http://127.0.0.1:1")
 	handler := handleExplainDecision(fc, 5*time.Minute, discardLogger())
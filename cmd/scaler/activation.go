@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	pb "github.com/HatiCode/kedastral/pkg/api/externalscaler"
+	"github.com/HatiCode/kedastral/pkg/capacity"
+	"github.com/HatiCode/kedastral/pkg/durationx"
+	"github.com/HatiCode/kedastral/pkg/storage"
+)
+
+// Trigger metadata keys configuring forecast-driven activation.
+const (
+	metadataActivationThreshold   = "activationThreshold"
+	metadataDeactivationThreshold = "deactivationThreshold"
+	metadataActivationQuantile    = "activationQuantile"
+	metadataStartupTime           = "startupTime"
+)
+
+// activation configures forecast-driven activation of a ScaledObject. With it,
+// IsActive reports false while the forecast predicts no load, so KEDA can scale a
+// workload with minReplicaCount 0 to zero and back.
+type activation struct {
+	// threshold is the forecast value at or above which the workload is activated.
+	threshold float64
+
+	// deactivationThreshold is the forecast value below which an active workload is
+	// deactivated. It is at most threshold; a lower value keeps a workload whose
+	// forecast hovers around threshold from flapping.
+	deactivationThreshold float64
+
+	// quantile selects the quantile forecast compared with the thresholds instead of
+	// the point forecast. Zero uses the point forecast.
+	quantile float64
+
+	// startupTime extends the window past the lead time, so the workload is activated
+	// early enough for its first pod to be ready when the predicted load arrives.
+	startupTime time.Duration
+}
+
+// parseActivation reads the activation settings from a ScaledObject's trigger
+// metadata. It returns nil when activationThreshold is unset, in which case only
+// forecast freshness decides activation.
+func parseActivation(metadata map[string]string) (*activation, error) {
+	raw, ok := metadata[metadataActivationThreshold]
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	threshold, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", metadataActivationThreshold, raw, err)
+	}
+	a := &activation{threshold: threshold, deactivationThreshold: threshold}
+
+	if raw := metadata[metadataDeactivationThreshold]; raw != "" {
+		a.deactivationThreshold, err = strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", metadataDeactivationThreshold, raw, err)
+		}
+		if a.deactivationThreshold > a.threshold {
+			return nil, fmt.Errorf("%s (%v) must not exceed %s (%v)", metadataDeactivationThreshold, a.deactivationThreshold, metadataActivationThreshold, a.threshold)
+		}
+	}
+	if raw := metadata[metadataActivationQuantile]; raw != "" {
+		a.quantile, err = capacity.ParseQuantileLevel(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", metadataActivationQuantile, err)
+		}
+	}
+	if raw := metadata[metadataStartupTime]; raw != "" {
+		a.startupTime, err = durationx.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", metadataStartupTime, raw, err)
+		}
+		if a.startupTime < 0 {
+			return nil, fmt.Errorf("%s must be >= 0", metadataStartupTime)
+		}
+	}
+	return a, nil
+}
+
+// activations remembers which ScaledObjects were last reported active, to apply
+// the deactivation threshold to them. Safe for concurrent use.
+type activations struct {
+	mu     sync.Mutex
+	active map[string]bool
+}
+
+func newActivations() *activations {
+	return &activations{active: make(map[string]bool)}
+}
+
+// set records whether a ScaledObject was reported active.
+func (a *activations) set(key string, active bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if active {
+		a.active[key] = true
+	} else {
+		delete(a.active, key)
+	}
+}
+
+func (a *activations) isActive(key string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.active[key]
+}
+
+// activationKey identifies a ScaledObject across IsActive calls.
+func activationKey(ref *pb.ScaledObjectRef) string {
+	return ref.Namespace + "/" + ref.Name
+}
+
+// peakForecast returns the highest forecast value from now to now+leadTime+startupTime,
+// reading the configured quantile when the snapshot has it. The window starts at the
+// snapshot's age, since the forecast starts when it was generated. It returns false
+// when the snapshot has no forecast values.
+func (s *Scaler) peakForecast(snapshot *storage.Snapshot, a *activation, age time.Duration) (float64, bool) {
+	series := snapshot.Values
+	if a.quantile > 0 {
+		if q := snapshot.Quantiles[a.quantile]; len(q) > 0 {
+			series = q
+		} else {
+			s.logger.Debug("activation quantile not in forecast, using point forecast",
+				"workload", snapshot.Workload,
+				"quantile", capacity.FormatQuantileLevel(a.quantile),
+			)
+		}
+	}
+	if len(series) == 0 || snapshot.StepSeconds <= 0 {
+		return 0, false
+	}
+
+	step := time.Duration(snapshot.StepSeconds) * time.Second
+	from := min(int(max(age, 0)/step), len(series)-1)
+	to := min(int((max(age, 0)+s.leadTime+a.startupTime)/step), len(series)-1)
+
+	peak := series[from]
+	for _, v := range series[from+1 : to+1] {
+		peak = max(peak, v)
+	}
+	return peak, true
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/HatiCode/kedastral/cmd/scaler/metrics"
+	"github.com/HatiCode/kedastral/pkg/storage"
+)
+
+// cacheIdleTime is how long a cached workload may go unrequested before it is no
+// longer refreshed in the background and is dropped.
+const cacheIdleTime = 5 * time.Minute
+
+// snapshotCache caches the latest snapshot of each workload until the forecaster is
+// expected to have produced the next one. Concurrent fetches of a workload are
+// coalesced into one request, and recently requested workloads are refreshed in the
+// background when their snapshot expires, so KEDA's calls rarely wait on the
+// forecaster. Safe for concurrent use.
+type snapshotCache struct {
+	fetch func(ctx context.Context, workload string) (*storage.Snapshot, error)
+
+	// interval is the forecaster interval assumed for snapshots that do not carry
+	// theirs, and minTTL the least time a snapshot is cached, which bounds the
+	// request rate while the next snapshot is overdue.
+	interval time.Duration
+	minTTL   time.Duration
+
+	group   singleflight.Group
+	logger  *slog.Logger
+	metrics *metrics.Metrics
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	closed  bool
+}
+
+type cacheEntry struct {
+	snapshot     *storage.Snapshot
+	expires      time.Time
+	lastAccessed time.Time
+	refresh      *time.Timer
+}
+
+func newSnapshotCache(fetch func(context.Context, string) (*storage.Snapshot, error), interval, minTTL time.Duration, logger *slog.Logger, m *metrics.Metrics) *snapshotCache {
+	return &snapshotCache{
+		fetch:    fetch,
+		interval: interval,
+		minTTL:   minTTL,
+		logger:   logger,
+		metrics:  m,
+		entries:  make(map[string]*cacheEntry),
+	}
+}
+
+// get returns the cached snapshot of workload, fetching it when missing or expired.
+// The snapshot is shared between callers and must not be modified.
+func (c *snapshotCache) get(ctx context.Context, workload string) (*storage.Snapshot, error) {
+	now := time.Now()
+	c.mu.Lock()
+	if e, ok := c.entries[workload]; ok && now.Before(e.expires) {
+		e.lastAccessed = now
+		c.mu.Unlock()
+		if c.metrics != nil {
+			c.metrics.RecordCacheHit()
+		}
+		return e.snapshot, nil
+	}
+	c.mu.Unlock()
+
+	if c.metrics != nil {
+		c.metrics.RecordCacheMiss()
+	}
+	return c.load(ctx, workload, now)
+}
+
+// load fetches the snapshot of workload, sharing one request between concurrent
+// callers, and caches it. accessed is recorded as the entry's last access; a
+// background refresh passes the zero time to keep the previous one.
+func (c *snapshotCache) load(ctx context.Context, workload string, accessed time.Time) (*storage.Snapshot, error) {
+	ch := c.group.DoChan(workload, func() (any, error) {
+		// The request is shared, so it must not fail because its first caller gave up.
+		snapshot, err := c.fetch(context.WithoutCancel(ctx), workload)
+		if err != nil {
+			return nil, err
+		}
+		c.store(workload, snapshot)
+		return snapshot, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		if !accessed.IsZero() {
+			c.touch(workload, accessed)
+		}
+		return res.Val.(*storage.Snapshot), nil
+	}
+}
+
+// store caches snapshot until the next one is expected: its generation time plus
+// the forecaster interval, but at least minTTL and at most one interval from now.
+// It schedules the entry's background refresh at expiry.
+func (c *snapshotCache) store(workload string, snapshot *storage.Snapshot) {
+	interval := c.interval
+	if snapshot.IntervalSeconds > 0 {
+		interval = time.Duration(snapshot.IntervalSeconds) * time.Second
+	}
+	now := time.Now()
+	expires := snapshot.GeneratedAt.Add(interval)
+	expires = maxTime(expires, now.Add(c.minTTL))
+	expires = minTime(expires, now.Add(max(interval, c.minTTL)))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	e, ok := c.entries[workload]
+	if !ok {
+		e = &cacheEntry{lastAccessed: now}
+		c.entries[workload] = e
+	} else if e.refresh != nil {
+		e.refresh.Stop()
+	}
+	e.snapshot, e.expires = snapshot, expires
+	e.refresh = time.AfterFunc(time.Until(expires), func() { c.refresh(workload) })
+}
+
+func (c *snapshotCache) touch(workload string, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[workload]; ok && at.After(e.lastAccessed) {
+		e.lastAccessed = at
+	}
+}
+
+// refresh fetches the next snapshot of an expired entry still in use, and drops
+// the entry otherwise. A failed refresh leaves the expired entry, to be fetched
+// again on the next request.
+func (c *snapshotCache) refresh(workload string) {
+	c.mu.Lock()
+	e, ok := c.entries[workload]
+	if c.closed || !ok {
+		c.mu.Unlock()
+		return
+	}
+	if time.Since(e.lastAccessed) > cacheIdleTime {
+		delete(c.entries, workload)
+		c.mu.Unlock()
+		return
+	}
+	c.mu.Unlock()
+
+	if _, err := c.load(context.Background(), workload, time.Time{}); err != nil {
+		c.logger.Debug("background snapshot refresh failed", "workload", workload, "error", err)
+	}
+}
+
+// close stops the background refreshes.
+func (c *snapshotCache) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for _, e := range c.entries {
+		if e.refresh != nil {
+			e.refresh.Stop()
+		}
+	}
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
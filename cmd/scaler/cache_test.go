@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/storage"
+)
+
+// countingFetch serves snapshots generated at generatedAt() and counts the fetches.
+type countingFetch struct {
+	calls       atomic.Int32
+	generatedAt func() time.Time
+	release     chan struct{}
+	err         error
+}
+
+func (f *countingFetch) fetch(ctx context.Context, workload string) (*storage.Snapshot, error) {
+	f.calls.Add(1)
+	if f.release != nil {
+		<-f.release
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &storage.Snapshot{Workload: workload, GeneratedAt: f.generatedAt(), IntervalSeconds: 60}, nil
+}
+
+func newTestCache(f *countingFetch, minTTL time.Duration) *snapshotCache {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return newSnapshotCache(f.fetch, 30*time.Second, minTTL, logger, scalerTestMetrics)
+}
+
+func TestSnapshotCache_Hit(t *testing.T) {
+	f := &countingFetch{generatedAt: time.Now}
+	c := newTestCache(f, time.Second)
+	defer c.close()
+
+	for range 3 {
+		if _, err := c.get(context.Background(), "web"); err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+	}
+	if got := f.calls.Load(); got != 1 {
+		t.Errorf("fetches = %d, want 1 while the snapshot is fresh", got)
+	}
+}
+
+func TestSnapshotCache_Coalesces(t *testing.T) {
+	f := &countingFetch{generatedAt: time.Now, release: make(chan struct{})}
+	c := newTestCache(f, time.Second)
+	defer c.close()
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			if _, err := c.get(context.Background(), "web"); err != nil {
+				t.Errorf("get() error = %v", err)
+			}
+		})
+	}
+	// Let every caller reach the shared fetch before it completes.
+	time.Sleep(50 * time.Millisecond)
+	close(f.release)
+	wg.Wait()
+
+	if got := f.calls.Load(); got != 1 {
+		t.Errorf("fetches = %d, want 1 for concurrent requests", got)
+	}
+}
+
+func TestSnapshotCache_BackgroundRefresh(t *testing.T) {
+	// The next snapshot is expected 50ms from now.
+	start := time.Now()
+	f := &countingFetch{generatedAt: func() time.Time { return start.Add(-time.Minute + 50*time.Millisecond) }}
+	c := newTestCache(f, 20*time.Millisecond)
+	defer c.close()
+
+	if _, err := c.get(context.Background(), "web"); err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for f.calls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := f.calls.Load(); got < 2 {
+		t.Fatalf("fetches = %d, want a background refresh at expiry", got)
+	}
+}
+
+func TestSnapshotCache_ErrorNotCached(t *testing.T) {
+	f := &countingFetch{generatedAt: time.Now, err: errors.New("forecaster down")}
+	c := newTestCache(f, time.Second)
+	defer c.close()
+
+	for range 2 {
+		if _, err := c.get(context.Background(), "web"); err == nil {
+			t.Fatal("get() should return the fetch error")
+		}
+	}
+	if got := f.calls.Load(); got != 2 {
+		t.Errorf("fetches = %d, want 2: errors are not cached", got)
+	}
+}
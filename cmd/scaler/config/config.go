@@ -19,7 +19,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/HatiCode/kedastral/pkg/durationx"
@@ -27,23 +29,62 @@ import (
 )
 
 type Config struct {
-	Listen        string
-	ForecasterURL string
-	LeadTime      time.Duration
-	LogFormat     string
-	LogLevel      string
-	TLS           tls.Config
+	Listen string
+
+	// ForecasterURL is a comma-separated list of forecaster endpoints, replaced by
+	// the targets of the DNS SRV records of ForecasterSRV, resolved every
+	// ForecasterResolveInterval, when set. An endpoint failing EjectionFailures
+	// consecutive requests is skipped for EjectionTime.
+	ForecasterURL             string
+	ForecasterSRV             string
+	ForecasterResolveInterval time.Duration
+	EjectionFailures          int
+	EjectionTime              time.Duration
+
+	LeadTime  time.Duration
+	LogFormat string
+	LogLevel  string
+	TLS       tls.Config
+
+	// SnapshotCache caches snapshots in the scaler until the next one is expected.
+	// ForecastInterval is the forecaster interval assumed for snapshots that do not
+	// report theirs, and CacheMinTTL the least time a snapshot is cached.
+	SnapshotCache    bool
+	ForecastInterval time.Duration
+	CacheMinTTL      time.Duration
+
+	// SnapshotSources lists where snapshots are read from, in order of preference:
+	// "http" for the forecaster API and "redis" for the forecaster's Redis storage,
+	// reached with the Redis* settings.
+	SnapshotSources []string
+	RedisAddr       string
+	RedisPassword   string
+	RedisDB         int
 }
 
 func ParseFlags() *Config {
 	cfg := &Config{}
 
 	flag.StringVar(&cfg.Listen, "listen", getEnv("SCALER_LISTEN", ":50051"), "gRPC listen address")
-	flag.StringVar(&cfg.ForecasterURL, "forecaster-url", getEnv("FORECASTER_URL", "http://localhost:8081"), "Forecaster HTTP endpoint")
+	flag.StringVar(&cfg.ForecasterURL, "forecaster-url", getEnv("FORECASTER_URL", "http://localhost:8081"), "Comma-separated forecaster HTTP endpoints")
+	flag.StringVar(&cfg.ForecasterSRV, "forecaster-srv", getEnv("FORECASTER_SRV", ""), "DNS SRV name resolving to the forecaster endpoints, replacing -forecaster-url")
+	durationx.Var(&cfg.ForecasterResolveInterval, "forecaster-resolve-interval", getEnvDuration("FORECASTER_RESOLVE_INTERVAL", 30*time.Second), "Interval between DNS SRV lookups of the forecaster endpoints")
+	flag.IntVar(&cfg.EjectionFailures, "forecaster-ejection-failures", getEnvInt("FORECASTER_EJECTION_FAILURES", 3), "Consecutive failures that eject a forecaster endpoint")
+	durationx.Var(&cfg.EjectionTime, "forecaster-ejection-time", getEnvDuration("FORECASTER_EJECTION_TIME", 30*time.Second), "How long an ejected forecaster endpoint is skipped")
 	durationx.Var(&cfg.LeadTime, "lead-time", getEnvDuration("LEAD_TIME", 5*time.Minute), "Lead time for forecast selection")
 	flag.StringVar(&cfg.LogFormat, "log-format", getEnv("LOG_FORMAT", "text"), "Log format (text|json)")
 	flag.StringVar(&cfg.LogLevel, "log-level", getEnv("LOG_LEVEL", "info"), "Log level (debug|info|warn|error)")
 
+	flag.BoolVar(&cfg.SnapshotCache, "snapshot-cache", getEnvBool("SNAPSHOT_CACHE", true), "Cache snapshots until the next one is expected, coalescing concurrent fetches")
+	durationx.Var(&cfg.ForecastInterval, "forecast-interval", getEnvDuration("FORECAST_INTERVAL", 30*time.Second), "Forecaster interval assumed for snapshots that do not report theirs")
+	durationx.Var(&cfg.CacheMinTTL, "snapshot-cache-min-ttl", getEnvDuration("SNAPSHOT_CACHE_MIN_TTL", 5*time.Second), "Least time a snapshot is cached, bounding requests while the next one is overdue")
+
+	var sources string
+	flag.StringVar(&sources, "snapshot-sources", getEnv("SNAPSHOT_SOURCES", "http"), "Comma-separated snapshot sources in order of preference: http, redis")
+	flag.StringVar(&cfg.RedisAddr, "redis-addr", getEnv("REDIS_ADDR", "localhost:6379"), "Redis server address of the forecaster storage")
+	flag.StringVar(&cfg.RedisPassword, "redis-password", getEnv("REDIS_PASSWORD", ""), "Redis password")
+	flag.IntVar(&cfg.RedisDB, "redis-db", getEnvInt("REDIS_DB", 0), "Redis database number")
+
 	flag.BoolVar(&cfg.TLS.Enabled, "tls-enabled", getEnvBool("TLS_ENABLED", false), "Enable TLS for HTTP client")
 	flag.StringVar(&cfg.TLS.CertFile, "tls-cert-file", getEnv("TLS_CERT_FILE", ""), "TLS certificate file")
 	flag.StringVar(&cfg.TLS.KeyFile, "tls-key-file", getEnv("TLS_KEY_FILE", ""), "TLS private key file")
@@ -51,15 +92,52 @@ func ParseFlags() *Config {
 
 	flag.Parse()
 
+	for source := range strings.SplitSeq(sources, ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			cfg.SnapshotSources = append(cfg.SnapshotSources, source)
+		}
+	}
+
 	if cfg.ForecasterURL == "" {
 		fmt.Fprintln(os.Stderr, "Error: -forecaster-url is required")
 		flag.Usage()
 		os.Exit(1)
 	}
+	if cfg.ForecasterSRV != "" && cfg.ForecasterResolveInterval <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: -forecaster-resolve-interval must be > 0")
+		os.Exit(1)
+	}
+	if cfg.EjectionFailures < 1 || cfg.EjectionTime <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: -forecaster-ejection-failures must be >= 1 and -forecaster-ejection-time > 0")
+		os.Exit(1)
+	}
+	if cfg.SnapshotCache && (cfg.ForecastInterval <= 0 || cfg.CacheMinTTL <= 0) {
+		fmt.Fprintln(os.Stderr, "Error: -forecast-interval and -snapshot-cache-min-ttl must be > 0")
+		os.Exit(1)
+	}
+	if len(cfg.SnapshotSources) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: -snapshot-sources must list at least one of http, redis")
+		os.Exit(1)
+	}
+	for _, source := range cfg.SnapshotSources {
+		if source != "http" && source != "redis" {
+			fmt.Fprintf(os.Stderr, "Error: invalid snapshot source %q: must be http or redis\n", source)
+			os.Exit(1)
+		}
+	}
+	if cfg.UsesRedis() && cfg.RedisAddr == "" {
+		fmt.Fprintln(os.Stderr, "Error: -redis-addr is required with the redis snapshot source")
+		os.Exit(1)
+	}
 
 	return cfg
 }
 
+// UsesRedis reports whether snapshots are read from the forecaster's Redis storage.
+func (c *Config) UsesRedis() bool {
+	return slices.Contains(c.SnapshotSources, "redis")
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -31,6 +31,15 @@ func TestConfig_Defaults(t *testing.T) {
 	if cfg.LogLevel != "info" {
 		t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, "info")
 	}
+	if !cfg.SnapshotCache || cfg.ForecastInterval != 30*time.Second || cfg.CacheMinTTL != 5*time.Second {
+		t.Errorf("snapshot cache = %v, %v, %v, want enabled with 30s interval and 5s min TTL", cfg.SnapshotCache, cfg.ForecastInterval, cfg.CacheMinTTL)
+	}
+	if cfg.ForecasterSRV != "" || cfg.EjectionFailures != 3 || cfg.EjectionTime != 30*time.Second {
+		t.Errorf("ForecasterSRV = %q, ejection = %d, %v, want no SRV and 3 failures for 30s", cfg.ForecasterSRV, cfg.EjectionFailures, cfg.EjectionTime)
+	}
+	if len(cfg.SnapshotSources) != 1 || cfg.SnapshotSources[0] != "http" || cfg.UsesRedis() {
+		t.Errorf("SnapshotSources = %v, want [http]", cfg.SnapshotSources)
+	}
 }
 
 func TestConfig_CustomValues(t *testing.T) {
@@ -44,6 +53,8 @@ func TestConfig_CustomValues(t *testing.T) {
 		"-lead-time=10m",
 		"-log-format=json",
 		"-log-level=debug",
+		"-snapshot-sources=redis, http",
+		"-redis-addr=redis:6379",
 	}
 
 	cfg := ParseFlags()
@@ -63,6 +74,12 @@ func TestConfig_CustomValues(t *testing.T) {
 	if cfg.LogLevel != "debug" {
 		t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, "debug")
 	}
+	if len(cfg.SnapshotSources) != 2 || cfg.SnapshotSources[0] != "redis" || cfg.SnapshotSources[1] != "http" {
+		t.Errorf("SnapshotSources = %v, want [redis http]", cfg.SnapshotSources)
+	}
+	if !cfg.UsesRedis() || cfg.RedisAddr != "redis:6379" {
+		t.Errorf("UsesRedis() = %v, RedisAddr = %q, want true and %q", cfg.UsesRedis(), cfg.RedisAddr, "redis:6379")
+	}
 }
 
 func TestGetEnv(t *testing.T) {
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HatiCode/kedastral/cmd/scaler/metrics"
+	"github.com/HatiCode/kedastral/pkg/storage"
+)
+
+// Default passive health tracking of forecaster endpoints.
+const (
+	// defaultEjectionFailures is the number of consecutive failures that eject an
+	// endpoint.
+	defaultEjectionFailures = 3
+
+	// defaultEjectionTime is how long an ejected endpoint is skipped before it is
+	// tried again.
+	defaultEjectionTime = 30 * time.Second
+)
+
+// statusError is a non-200 response from a forecaster.
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("forecaster returned status %d", e.code)
+}
+
+// endpointFailed reports whether err from a forecaster counts against its health:
+// a workload it has no snapshot of, or a request it rejects, does not.
+func endpointFailed(err error) bool {
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.code >= http.StatusInternalServerError
+	}
+	return err != nil
+}
+
+// parseEndpoints splits a comma-separated list of forecaster URLs.
+func parseEndpoints(urls string) []string {
+	var endpoints []string
+	for url := range strings.SplitSeq(urls, ",") {
+		if url = strings.TrimSuffix(strings.TrimSpace(url), "/"); url != "" {
+			endpoints = append(endpoints, url)
+		}
+	}
+	return endpoints
+}
+
+// endpoint is a forecaster replica and its passive health.
+type endpoint struct {
+	url string
+
+	// failures counts consecutive failed requests. An endpoint with failures at the
+	// ejection threshold is skipped until ejectedUntil, then given one request: a
+	// success restores it and a failure ejects it again.
+	failures     int
+	ejectedUntil time.Time
+}
+
+// endpointPool tracks the health of the forecaster endpoints from the outcome of
+// the requests sent to them, ejecting those that keep failing. Safe for concurrent
+// use.
+type endpointPool struct {
+	failureThreshold int
+	ejectionTime     time.Duration
+	metrics          *metrics.Metrics
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+}
+
+func newEndpointPool(urls []string, m *metrics.Metrics) *endpointPool {
+	p := &endpointPool{
+		failureThreshold: defaultEjectionFailures,
+		ejectionTime:     defaultEjectionTime,
+		metrics:          m,
+	}
+	p.set(urls)
+	return p
+}
+
+// set replaces the endpoints with urls, keeping the health of those already known.
+func (p *endpointPool) set(urls []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	endpoints := make([]*endpoint, 0, len(urls))
+	for _, url := range urls {
+		i := slices.IndexFunc(p.endpoints, func(e *endpoint) bool { return e.url == url })
+		if i >= 0 {
+			endpoints = append(endpoints, p.endpoints[i])
+		} else {
+			endpoints = append(endpoints, &endpoint{url: url})
+		}
+	}
+	p.endpoints = endpoints
+	p.updateHealthy(time.Now())
+}
+
+// available returns the endpoints not ejected at now or, when all are ejected,
+// every endpoint: a request that may fail beats none.
+func (p *endpointPool) available(now time.Time) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var urls []string
+	for _, e := range p.endpoints {
+		if !now.Before(e.ejectedUntil) {
+			urls = append(urls, e.url)
+		}
+	}
+	if len(urls) == 0 {
+		for _, e := range p.endpoints {
+			urls = append(urls, e.url)
+		}
+	}
+	return urls
+}
+
+// report records the outcome of a request to url at now. It returns true when the
+// failure ejected the endpoint.
+func (p *endpointPool) report(url string, failed bool, now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	i := slices.IndexFunc(p.endpoints, func(e *endpoint) bool { return e.url == url })
+	if i < 0 {
+		return false
+	}
+	e := p.endpoints[i]
+	defer p.updateHealthy(now)
+	if !failed {
+		e.failures, e.ejectedUntil = 0, time.Time{}
+		return false
+	}
+	e.failures++
+	if e.failures < p.failureThreshold {
+		return false
+	}
+	e.ejectedUntil = now.Add(p.ejectionTime)
+	if p.metrics != nil {
+		p.metrics.RecordEndpointEjection(url)
+	}
+	return true
+}
+
+// updateHealthy exports the number of endpoints not ejected. p.mu must be held.
+func (p *endpointPool) updateHealthy(now time.Time) {
+	if p.metrics == nil {
+		return
+	}
+	healthy := 0
+	for _, e := range p.endpoints {
+		if !now.Before(e.ejectedUntil) {
+			healthy++
+		}
+	}
+	p.metrics.SetHealthyEndpoints(healthy)
+}
+
+// SetEjection configures passive health tracking of the forecaster endpoints: an
+// endpoint failing failures consecutive requests is skipped for ejectionTime.
+func (s *Scaler) SetEjection(failures int, ejectionTime time.Duration) {
+	s.forecasters.mu.Lock()
+	defer s.forecasters.mu.Unlock()
+	s.forecasters.failureThreshold = failures
+	s.forecasters.ejectionTime = ejectionTime
+}
+
+// ResolveForecasters replaces the forecaster endpoints with the targets of the DNS
+// SRV records of name, such as the _http._tcp records of a headless Service, and
+// resolves them again every interval until Close. scheme is the scheme of the
+// endpoint URLs. A failed lookup keeps the previous endpoints.
+func (s *Scaler) ResolveForecasters(name, scheme string, interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopResolve = cancel
+	s.resolveForecasters(ctx, name, scheme)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.resolveForecasters(ctx, name, scheme)
+			}
+		}
+	}()
+}
+
+func (s *Scaler) resolveForecasters(ctx context.Context, name, scheme string) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, records, err := s.lookupSRV(ctx, "", "", name)
+	if err == nil && len(records) == 0 {
+		err = errors.New("no records")
+	}
+	if err != nil {
+		s.logger.Warn("failed to resolve forecaster endpoints", "name", name, "error", err)
+		return
+	}
+
+	urls := make([]string, 0, len(records))
+	for _, r := range records {
+		host := strings.TrimSuffix(r.Target, ".")
+		urls = append(urls, scheme+"://"+net.JoinHostPort(host, strconv.Itoa(int(r.Port))))
+	}
+	slices.Sort(urls)
+	s.forecasters.set(slices.Compact(urls))
+	s.logger.Debug("resolved forecaster endpoints", "name", name, "endpoints", urls)
+}
+
+// fetchForecast fetches the latest forecast snapshot of workload from every
+// available forecaster endpoint and returns the freshest. It returns as soon as
+// a fresh snapshot arrives instead of waiting for slow endpoints, and fails only
+// when no endpoint returns one.
+func (s *Scaler) fetchForecast(ctx context.Context, workload string) (*storage.Snapshot, error) {
+	urls := s.forecasters.available(time.Now())
+	if len(urls) == 0 {
+		return nil, errors.New("no forecaster endpoints")
+	}
+
+	// Requests outlive an early return, bounded by the client timeout, so that
+	// the health of every endpoint, slow ones included, is still reported.
+	fetchCtx := context.WithoutCancel(ctx)
+	type result struct {
+		i        int
+		snapshot *storage.Snapshot
+		err      error
+	}
+	results := make(chan result, len(urls))
+	for i, url := range urls {
+		go func() {
+			snapshot, err := s.fetchFrom(fetchCtx, url, workload)
+			if s.forecasters.report(url, endpointFailed(err), time.Now()) {
+				s.logger.Warn("ejecting failing forecaster endpoint",
+					"endpoint", url,
+					"error", err,
+				)
+			}
+			results <- result{i: i, snapshot: snapshot, err: err}
+		}()
+	}
+
+	var freshest *storage.Snapshot
+	errs := make([]error, len(urls))
+	for range urls {
+		select {
+		case <-ctx.Done():
+			if freshest != nil {
+				return freshest, nil
+			}
+			return nil, ctx.Err()
+		case r := <-results:
+			errs[r.i] = r.err
+			if r.snapshot == nil {
+				continue
+			}
+			if freshest == nil || r.snapshot.GeneratedAt.After(freshest.GeneratedAt) {
+				freshest = r.snapshot
+			}
+			if s.fresh(freshest, time.Now()) {
+				return freshest, nil
+			}
+		}
+	}
+	if freshest != nil {
+		return freshest, nil
+	}
+	if len(urls) == 1 {
+		return nil, errs[0]
+	}
+	for i, url := range urls {
+		errs[i] = fmt.Errorf("%s: %w", url, errs[i])
+	}
+	return nil, errors.Join(errs...)
+}
+
+// fresh reports whether snapshot was generated within the last forecaster
+// interval, so that no endpoint is expected to have a newer one. Snapshots that
+// do not report their interval fall back to the one assumed by the snapshot
+// cache, and are never fresh without it.
+func (s *Scaler) fresh(snapshot *storage.Snapshot, now time.Time) bool {
+	var interval time.Duration
+	switch {
+	case snapshot.IntervalSeconds > 0:
+		interval = time.Duration(snapshot.IntervalSeconds) * time.Second
+	case s.cache != nil:
+		interval = s.cache.interval
+	}
+	return now.Sub(snapshot.GeneratedAt) < interval
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/storage"
+	"github.com/HatiCode/kedastral/pkg/tls"
+)
+
+// newForecasterServer serves a snapshot generated age ago with the given replicas,
+// or fails with status when it is not 200.
+func newForecasterServer(t *testing.T, status int, age time.Duration, replicas int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != http.StatusOK {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		snapshot := storage.Snapshot{
+			Workload:        "api",
+			GeneratedAt:     time.Now().Add(-age),
+			StepSeconds:     60,
+			DesiredReplicas: []int{replicas},
+		}
+		if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+			t.Errorf("failed to encode snapshot: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newEndpointsTestScaler(t *testing.T, urls ...string) *Scaler {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s, err := New(strings.Join(urls, ","), time.Minute, tls.Config{}, logger, scalerTestMetrics)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return s
+}
+
+func TestScaler_FetchForecast_Freshest(t *testing.T) {
+	old := newForecasterServer(t, http.StatusOK, 2*time.Minute, 2)
+	fresh := newForecasterServer(t, http.StatusOK, 10*time.Second, 5)
+	down := newForecasterServer(t, http.StatusInternalServerError, 0, 0)
+	s := newEndpointsTestScaler(t, old.URL, down.URL, fresh.URL)
+
+	snapshot, err := s.fetchForecast(context.Background(), "api")
+	if err != nil {
+		t.Fatalf("fetchForecast() error = %v", err)
+	}
+	if got := snapshot.DesiredReplicas[0]; got != 5 {
+		t.Errorf("replicas = %d, want 5 from the freshest snapshot", got)
+	}
+
+	// The failing endpoint is ejected after the default number of failures.
+	for range defaultEjectionFailures - 1 {
+		if _, err := s.fetchForecast(context.Background(), "api"); err != nil {
+			t.Fatalf("fetchForecast() error = %v", err)
+		}
+	}
+	if got := s.forecasters.available(time.Now()); slices.Contains(got, down.URL) || len(got) != 2 {
+		t.Errorf("available = %v, want the two healthy endpoints", got)
+	}
+}
+
+func TestScaler_FetchForecast_SlowEndpoint(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(slow.Close)
+	var once sync.Once
+	t.Cleanup(func() { once.Do(func() { close(release) }) })
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		snapshot := storage.Snapshot{
+			Workload:        "api",
+			GeneratedAt:     time.Now().Add(-10 * time.Second),
+			StepSeconds:     60,
+			IntervalSeconds: 30,
+			DesiredReplicas: []int{4},
+		}
+		if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+			t.Errorf("failed to encode snapshot: %v", err)
+		}
+	}))
+	t.Cleanup(fast.Close)
+	s := newEndpointsTestScaler(t, slow.URL, fast.URL)
+
+	// A fresh snapshot is returned without waiting for the slow endpoint.
+	for range defaultEjectionFailures {
+		start := time.Now()
+		snapshot, err := s.fetchForecast(context.Background(), "api")
+		if err != nil {
+			t.Fatalf("fetchForecast() error = %v", err)
+		}
+		if got := snapshot.DesiredReplicas[0]; got != 4 {
+			t.Errorf("replicas = %d, want 4", got)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Fatalf("fetchForecast() took %v, want it to return with the fresh snapshot", elapsed)
+		}
+	}
+
+	// The slow endpoint's failures are still reported once they complete.
+	once.Do(func() { close(release) })
+	deadline := time.Now().Add(2 * time.Second)
+	for slices.Contains(s.forecasters.available(time.Now()), slow.URL) {
+		if time.Now().After(deadline) {
+			t.Fatal("the slow failing endpoint should have been ejected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestScaler_FetchForecast_AllFail(t *testing.T) {
+	down := newForecasterServer(t, http.StatusBadGateway, 0, 0)
+	missing := newForecasterServer(t, http.StatusNotFound, 0, 0)
+	s := newEndpointsTestScaler(t, down.URL, missing.URL)
+
+	_, err := s.fetchForecast(context.Background(), "api")
+	var se *statusError
+	if !errors.As(err, &se) {
+		t.Fatalf("fetchForecast() error = %v, want a status error", err)
+	}
+	if !strings.Contains(err.Error(), down.URL) || !strings.Contains(err.Error(), missing.URL) {
+		t.Errorf("error %q should name both endpoints", err)
+	}
+}
+
+func TestScaler_FetchFrom_EscapesWorkload(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query().Get("workload")
+		if err := json.NewEncoder(w).Encode(storage.Snapshot{Workload: got}); err != nil {
+			t.Errorf("failed to encode snapshot: %v", err)
+		}
+	}))
+	defer server.Close()
+	s := newEndpointsTestScaler(t, server.URL)
+
+	const workload = "api&step=1 #2"
+	if _, err := s.fetchFrom(context.Background(), server.URL, workload); err != nil {
+		t.Fatalf("fetchFrom() error = %v", err)
+	}
+	if got != workload {
+		t.Errorf("workload = %q, want %q", got, workload)
+	}
+}
+
+func TestEndpointPool_Ejection(t *testing.T) {
+	p := newEndpointPool([]string{"http://a", "http://b"}, scalerTestMetrics)
+	p.ejectionTime = time.Minute
+	now := time.Now()
+
+	// Failures below the threshold, or broken by a success, do not eject.
+	p.report("http://a", true, now)
+	p.report("http://a", true, now)
+	p.report("http://a", false, now)
+	p.report("http://a", true, now)
+	if got := p.available(now); len(got) != 2 {
+		t.Fatalf("available = %v, want both endpoints", got)
+	}
+
+	p.report("http://a", true, now)
+	if !p.report("http://a", true, now) {
+		t.Fatal("report() = false, want the third consecutive failure to eject")
+	}
+	if got := p.available(now); !slices.Equal(got, []string{"http://b"}) {
+		t.Errorf("available = %v, want [http://b]", got)
+	}
+
+	// Once the ejection expires the endpoint gets one request, and a failure ejects
+	// it again.
+	later := now.Add(time.Minute)
+	if got := p.available(later); len(got) != 2 {
+		t.Errorf("available after ejection = %v, want both endpoints", got)
+	}
+	if !p.report("http://a", true, later) {
+		t.Error("report() = false, want a failure after ejection to eject again")
+	}
+
+	// With every endpoint ejected, all are tried.
+	for range defaultEjectionFailures {
+		p.report("http://b", true, later)
+	}
+	if got := p.available(later); len(got) != 2 {
+		t.Errorf("available with all ejected = %v, want both endpoints", got)
+	}
+
+	// Endpoints kept across set keep their health.
+	p.set([]string{"http://b", "http://c"})
+	if got := p.available(later); !slices.Equal(got, []string{"http://c"}) {
+		t.Errorf("available after set = %v, want [http://c]", got)
+	}
+}
+
+func TestEndpointFailed(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{&statusError{code: http.StatusNotFound}, false},
+		{&statusError{code: http.StatusBadRequest}, false},
+		{&statusError{code: http.StatusServiceUnavailable}, true},
+		{errors.New("connection refused"), true},
+	}
+	for _, tt := range tests {
+		if got := endpointFailed(tt.err); got != tt.want {
+			t.Errorf("endpointFailed(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestScaler_ResolveForecasters(t *testing.T) {
+	s := newEndpointsTestScaler(t, "http://static:8081")
+	records := []*net.SRV{
+		{Target: "forecaster-1.forecaster.ns.svc.", Port: 8081},
+		{Target: "forecaster-0.forecaster.ns.svc.", Port: 8081},
+	}
+	fail := false
+	s.lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		if name != "_http._tcp.forecaster.ns.svc" {
+			t.Errorf("lookup of %q, want _http._tcp.forecaster.ns.svc", name)
+		}
+		if fail {
+			return "", nil, errors.New("no such host")
+		}
+		return "", records, nil
+	}
+
+	s.ResolveForecasters("_http._tcp.forecaster.ns.svc", "http", time.Hour)
+	defer s.Close()
+	want := []string{"http://forecaster-0.forecaster.ns.svc:8081", "http://forecaster-1.forecaster.ns.svc:8081"}
+	if got := s.forecasters.available(time.Now()); !slices.Equal(got, want) {
+		t.Errorf("endpoints = %v, want %v", got, want)
+	}
+
+	// A failed lookup keeps the previous endpoints.
+	fail = true
+	s.resolveForecasters(context.Background(), "_http._tcp.forecaster.ns.svc", "http")
+	if got := s.forecasters.available(time.Now()); !slices.Equal(got, want) {
+		t.Errorf("endpoints after failed lookup = %v, want %v", got, want)
+	}
+}
+
+func TestParseEndpoints(t *testing.T) {
+	got := parseEndpoints(" http://a:8081/, ,http://b:8081")
+	if want := []string{"http://a:8081", "http://b:8081"}; !slices.Equal(got, want) {
+		t.Errorf("parseEndpoints() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/durationx"
+)
+
+// Trigger metadata keys configuring the fallback used while no fresh forecast is
+// available.
+const (
+	metadataFallbackMode      = "fallbackMode"
+	metadataFallbackReplicas  = "fallbackReplicas"
+	metadataFallbackDecayTime = "fallbackDecayTime"
+	metadataMinReplicas       = "minReplicas"
+)
+
+// Fallback modes.
+const (
+	fallbackHold  = "hold"
+	fallbackDecay = "decay"
+	fallbackFixed = "fixed"
+)
+
+// defaultFallbackDecayTime is how long decay takes to reach minReplicas by default.
+const defaultFallbackDecayTime = 30 * time.Minute
+
+// fallback configures the replicas served for a ScaledObject while the forecaster
+// is unreachable or its forecast is stale, instead of failing GetMetrics.
+type fallback struct {
+	// mode is hold (the last good replicas), decay (the last good replicas, falling
+	// linearly to minReplicas over decayTime), or fixed (replicas).
+	mode string
+
+	// replicas is served in fixed mode, and in the other modes before the scaler has
+	// seen a good forecast. Negative when unset.
+	replicas int
+
+	decayTime   time.Duration
+	minReplicas int
+}
+
+// parseFallback reads the fallback settings from a ScaledObject's trigger
+// metadata. It returns nil when fallbackMode is unset.
+func parseFallback(metadata map[string]string) (*fallback, error) {
+	mode := metadata[metadataFallbackMode]
+	if mode == "" {
+		return nil, nil
+	}
+	f := &fallback{mode: mode, replicas: -1, decayTime: defaultFallbackDecayTime}
+	switch mode {
+	case fallbackHold, fallbackDecay, fallbackFixed:
+	default:
+		return nil, fmt.Errorf("invalid %s %q: must be hold, decay, or fixed", metadataFallbackMode, mode)
+	}
+
+	var err error
+	if raw := metadata[metadataFallbackReplicas]; raw != "" {
+		f.replicas, err = strconv.Atoi(raw)
+		if err != nil || f.replicas < 0 {
+			return nil, fmt.Errorf("invalid %s %q: must be an integer >= 0", metadataFallbackReplicas, raw)
+		}
+	}
+	if mode == fallbackFixed && f.replicas < 0 {
+		return nil, fmt.Errorf("%s is required with fallback mode fixed", metadataFallbackReplicas)
+	}
+	if raw := metadata[metadataFallbackDecayTime]; raw != "" {
+		f.decayTime, err = durationx.Parse(raw)
+		if err != nil || f.decayTime <= 0 {
+			return nil, fmt.Errorf("invalid %s %q: must be a duration > 0", metadataFallbackDecayTime, raw)
+		}
+	}
+	if raw := metadata[metadataMinReplicas]; raw != "" {
+		f.minReplicas, err = strconv.Atoi(raw)
+		if err != nil || f.minReplicas < 0 {
+			return nil, fmt.Errorf("invalid %s %q: must be an integer >= 0", metadataMinReplicas, raw)
+		}
+	}
+	return f, nil
+}
+
+// lastKnownGood caches the replicas last selected from a fresh forecast of each
+// workload. Safe for concurrent use.
+type lastKnownGood struct {
+	mu         sync.Mutex
+	byWorkload map[string]goodReplicas
+}
+
+// goodReplicas are replicas selected from a fresh forecast, and when.
+type goodReplicas struct {
+	replicas int
+	at       time.Time
+}
+
+func newLastKnownGood() *lastKnownGood {
+	return &lastKnownGood{byWorkload: make(map[string]goodReplicas)}
+}
+
+func (c *lastKnownGood) store(workload string, replicas int, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byWorkload[workload] = goodReplicas{replicas: replicas, at: at}
+}
+
+func (c *lastKnownGood) load(workload string) (goodReplicas, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	good, ok := c.byWorkload[workload]
+	return good, ok
+}
+
+// fallbackReplicas returns the replicas to serve for workload at now while it has no
+// fresh forecast. It returns false when the fallback has nothing to serve: hold or
+// decay before any good forecast was seen, without fallbackReplicas.
+func (s *Scaler) fallbackReplicas(workload string, f *fallback, now time.Time) (int, bool) {
+	if f.mode == fallbackFixed {
+		return f.replicas, true
+	}
+	good, ok := s.lastGood.load(workload)
+	if !ok {
+		return f.replicas, f.replicas >= 0
+	}
+	if f.mode == fallbackHold || good.replicas <= f.minReplicas {
+		return good.replicas, true
+	}
+
+	elapsed := now.Sub(good.at)
+	if elapsed >= f.decayTime {
+		return f.minReplicas, true
+	}
+	fraction := float64(elapsed) / float64(f.decayTime)
+	decayed := float64(good.replicas) - float64(good.replicas-f.minReplicas)*fraction
+	return max(int(math.Ceil(decayed)), f.minReplicas), true
+}
@@ -11,11 +11,15 @@
 //
 // Environment variables:
 //
-//	FORECASTER_URL - HTTP endpoint of the forecaster service
+//	FORECASTER_URL - HTTP endpoints of the forecaster replicas, comma-separated
+//	FORECASTER_SRV - DNS SRV name resolving to the forecaster replicas
 //	SCALER_LISTEN  - gRPC listen address (default: :50051)
 //	LEAD_TIME      - Lead time for forecast selection (default: 5m)
 //	LOG_LEVEL      - Logging level: debug, info, warn, error (default: info)
 //	LOG_FORMAT     - Logging format: text, json (default: text)
+//	SNAPSHOT_CACHE - Cache snapshots until the next one is expected (default: true)
+//	SNAPSHOT_SOURCES - Snapshot sources in order of preference: http, redis (default: http)
+//	REDIS_ADDR     - Redis address of the forecaster storage, for the redis source
 package main
 
 import (
@@ -31,6 +35,7 @@ import (
 	"github.com/HatiCode/kedastral/cmd/scaler/router"
 	pb "github.com/HatiCode/kedastral/pkg/api/externalscaler"
 	"github.com/HatiCode/kedastral/pkg/httpx"
+	"github.com/HatiCode/kedastral/pkg/storage"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -49,8 +54,11 @@ func main() {
 		"version", version,
 		"listen", cfg.Listen,
 		"forecaster_url", cfg.ForecasterURL,
+		"forecaster_srv", cfg.ForecasterSRV,
 		"lead_time", cfg.LeadTime,
 		"tls_enabled", cfg.TLS.Enabled,
+		"snapshot_cache", cfg.SnapshotCache,
+		"snapshot_sources", cfg.SnapshotSources,
 	)
 
 	if err := cfg.TLS.Validate(); err != nil {
@@ -63,6 +71,34 @@ func main() {
 		log.Error("failed to create scaler", "error", err)
 		os.Exit(1)
 	}
+	scaler.SetEjection(cfg.EjectionFailures, cfg.EjectionTime)
+	if cfg.ForecasterSRV != "" {
+		scheme := "http"
+		if cfg.TLS.Enabled {
+			scheme = "https"
+		}
+		scaler.ResolveForecasters(cfg.ForecasterSRV, scheme, cfg.ForecasterResolveInterval)
+	}
+
+	var store storage.Store
+	if cfg.UsesRedis() {
+		log.Info("connecting to forecaster redis storage", "addr", cfg.RedisAddr, "db", cfg.RedisDB)
+		redisStore, err := storage.NewRedisStore(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB, 0)
+		if err != nil {
+			log.Error("failed to connect to redis", "error", err)
+			os.Exit(1)
+		}
+		defer redisStore.Close()
+		store = redisStore
+	}
+	if err := scaler.UseSources(cfg.SnapshotSources, store); err != nil {
+		log.Error("invalid snapshot sources", "error", err)
+		os.Exit(1)
+	}
+	if cfg.SnapshotCache {
+		scaler.EnableSnapshotCache(cfg.ForecastInterval, cfg.CacheMinTTL)
+	}
+	defer scaler.Close()
 
 	grpcServer := grpc.NewServer()
 
@@ -11,9 +11,20 @@
 //   - kedastral_scaler_forecast_fetch_errors_total: Counter of forecast fetch errors
 //   - kedastral_scaler_desired_replicas_returned: Gauge of last replica count returned to KEDA
 //   - kedastral_scaler_forecast_age_seen_seconds: Gauge of forecast data age
+//   - kedastral_scaler_live_metric_value: Gauge of the last live metric value seen in hybrid scaling
+//   - kedastral_scaler_reactive_errors_total: Counter of failed live metric queries
+//   - kedastral_scaler_forecast_corrections_total: Counter of forecasts corrected for a surprise spike
+//   - kedastral_scaler_fallbacks_total: Counter of fallback replicas served by mode
+//   - kedastral_scaler_snapshot_cache_requests_total: Counter of snapshot cache lookups by result
+//   - kedastral_scaler_snapshot_cache_hit_ratio: Gauge of the fraction of snapshot cache lookups that hit
+//   - kedastral_scaler_snapshot_source_requests_total: Counter of snapshot fetches by source and result
+//   - kedastral_scaler_forecaster_endpoints_healthy: Gauge of forecaster endpoints not ejected
+//   - kedastral_scaler_forecaster_ejections_total: Counter of forecaster endpoint ejections by endpoint
 package metrics
 
 import (
+	"sync/atomic"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -25,6 +36,17 @@ type Metrics struct {
 	ForecastFetchErrors     prometheus.Counter
 	DesiredReplicasReturned prometheus.Gauge
 	ForecastAgeSeen         prometheus.Gauge
+	LiveValue               prometheus.Gauge
+	ReactiveErrors          prometheus.Counter
+	ForecastCorrections     prometheus.Counter
+	Fallbacks               *prometheus.CounterVec
+	CacheRequests           *prometheus.CounterVec
+	CacheHitRatio           prometheus.Gauge
+	SourceRequests          *prometheus.CounterVec
+	HealthyEndpoints        prometheus.Gauge
+	EndpointEjections       *prometheus.CounterVec
+
+	cacheHits, cacheMisses atomic.Uint64
 }
 
 func New() *Metrics {
@@ -60,6 +82,51 @@ func New() *Metrics {
 			Name: "kedastral_scaler_forecast_age_seen_seconds",
 			Help: "Age of forecast data seen from forecaster",
 		}),
+
+		LiveValue: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "kedastral_scaler_live_metric_value",
+			Help: "Last live metric value seen in hybrid scaling",
+		}),
+
+		ReactiveErrors: promauto.NewCounter(prometheus.CounterOpts{
+			Name: "kedastral_scaler_reactive_errors_total",
+			Help: "Total number of failed live metric queries",
+		}),
+
+		ForecastCorrections: promauto.NewCounter(prometheus.CounterOpts{
+			Name: "kedastral_scaler_forecast_corrections_total",
+			Help: "Total number of forecasts corrected because the live metric exceeded them",
+		}),
+
+		Fallbacks: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "kedastral_scaler_fallbacks_total",
+			Help: "Total number of fallback replicas served while the forecast was unavailable, by mode",
+		}, []string{"mode"}),
+
+		CacheRequests: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "kedastral_scaler_snapshot_cache_requests_total",
+			Help: "Total number of snapshot cache lookups by result (hit or miss)",
+		}, []string{"result"}),
+
+		CacheHitRatio: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "kedastral_scaler_snapshot_cache_hit_ratio",
+			Help: "Fraction of snapshot cache lookups served from the cache since start",
+		}),
+
+		SourceRequests: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "kedastral_scaler_snapshot_source_requests_total",
+			Help: "Total number of snapshot fetches by source (http or redis) and result (ok or error)",
+		}, []string{"source", "result"}),
+
+		HealthyEndpoints: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "kedastral_scaler_forecaster_endpoints_healthy",
+			Help: "Number of forecaster endpoints not ejected by passive health tracking",
+		}),
+
+		EndpointEjections: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "kedastral_scaler_forecaster_ejections_total",
+			Help: "Total number of forecaster endpoint ejections after consecutive failures, by endpoint",
+		}, []string{"endpoint"}),
 	}
 }
 
@@ -86,3 +153,48 @@ func (m *Metrics) SetDesiredReplicas(replicas int) {
 func (m *Metrics) SetForecastAge(seconds float64) {
 	m.ForecastAgeSeen.Set(seconds)
 }
+
+func (m *Metrics) SetLiveValue(value float64) {
+	m.LiveValue.Set(value)
+}
+
+func (m *Metrics) RecordReactiveError() {
+	m.ReactiveErrors.Inc()
+}
+
+func (m *Metrics) RecordForecastCorrection() {
+	m.ForecastCorrections.Inc()
+}
+
+func (m *Metrics) RecordFallback(mode string) {
+	m.Fallbacks.WithLabelValues(mode).Inc()
+}
+
+func (m *Metrics) RecordCacheHit() {
+	m.CacheRequests.WithLabelValues("hit").Inc()
+	m.cacheHits.Add(1)
+	m.updateCacheHitRatio()
+}
+
+func (m *Metrics) RecordCacheMiss() {
+	m.CacheRequests.WithLabelValues("miss").Inc()
+	m.cacheMisses.Add(1)
+	m.updateCacheHitRatio()
+}
+
+func (m *Metrics) RecordSourceFetch(source, result string) {
+	m.SourceRequests.WithLabelValues(source, result).Inc()
+}
+
+func (m *Metrics) SetHealthyEndpoints(n int) {
+	m.HealthyEndpoints.Set(float64(n))
+}
+
+func (m *Metrics) RecordEndpointEjection(endpoint string) {
+	m.EndpointEjections.WithLabelValues(endpoint).Inc()
+}
+
+func (m *Metrics) updateCacheHitRatio() {
+	hits, misses := m.cacheHits.Load(), m.cacheMisses.Load()
+	m.CacheHitRatio.Set(float64(hits) / float64(hits+misses))
+}
@@ -30,6 +30,12 @@ func TestNew(t *testing.T) {
 	if m.ForecastAgeSeen == nil {
 		t.Error("ForecastAgeSeen should not be nil")
 	}
+	if m.LiveValue == nil || m.ReactiveErrors == nil || m.ForecastCorrections == nil {
+		t.Error("hybrid scaling metrics should not be nil")
+	}
+	if m.Fallbacks == nil {
+		t.Error("Fallbacks should not be nil")
+	}
 }
 
 func TestRecordGRPCRequest(t *testing.T) {
@@ -106,6 +112,77 @@ func TestSetForecastAge(t *testing.T) {
 	}
 }
 
+func TestReactiveMetrics(t *testing.T) {
+	m := testMetrics
+
+	m.SetLiveValue(950)
+	m.RecordReactiveError()
+	m.RecordForecastCorrection()
+
+	if got := testutil.ToFloat64(m.LiveValue); got != 950 {
+		t.Errorf("live value = %v, want 950", got)
+	}
+	if got := testutil.ToFloat64(m.ForecastCorrections); got < 1 {
+		t.Errorf("forecast corrections = %v, want at least 1", got)
+	}
+}
+
+func TestRecordFallback(t *testing.T) {
+	m := testMetrics
+
+	m.RecordFallback("hold")
+	m.RecordFallback("hold")
+
+	if got := testutil.ToFloat64(m.Fallbacks.WithLabelValues("hold")); got != 2 {
+		t.Errorf("hold fallbacks = %v, want 2", got)
+	}
+}
+
+func TestRecordSourceFetch(t *testing.T) {
+	m := testMetrics
+	redisErrors := m.SourceRequests.WithLabelValues("redis", "error")
+	before := testutil.ToFloat64(redisErrors)
+
+	m.RecordSourceFetch("redis", "error")
+	m.RecordSourceFetch("http", "ok")
+
+	if got := testutil.ToFloat64(redisErrors) - before; got != 1 {
+		t.Errorf("redis errors = %v, want 1 more", got)
+	}
+}
+
+func TestEndpointMetrics(t *testing.T) {
+	m := testMetrics
+
+	m.SetHealthyEndpoints(2)
+	m.RecordEndpointEjection("http://a:8081")
+
+	if got := testutil.ToFloat64(m.HealthyEndpoints); got != 2 {
+		t.Errorf("healthy endpoints = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.EndpointEjections.WithLabelValues("http://a:8081")); got < 1 {
+		t.Errorf("ejections = %v, want at least 1", got)
+	}
+}
+
+func TestCacheHitRatio(t *testing.T) {
+	m := testMetrics
+
+	m.RecordCacheMiss()
+	m.RecordCacheHit()
+	m.RecordCacheHit()
+	m.RecordCacheHit()
+
+	hits, misses := m.cacheHits.Load(), m.cacheMisses.Load()
+	want := float64(hits) / float64(hits+misses)
+	if got := testutil.ToFloat64(m.CacheHitRatio); got != want {
+		t.Errorf("hit ratio = %v, want %v", got, want)
+	}
+	if got := testutil.ToFloat64(m.CacheRequests.WithLabelValues("hit")); got < 3 {
+		t.Errorf("cache hits = %v, want at least 3", got)
+	}
+}
+
 func TestMetrics_MultipleObservations(t *testing.T) {
 	m := testMetrics
 
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/adapters"
+	"github.com/HatiCode/kedastral/pkg/storage"
+)
+
+// Trigger metadata keys configuring hybrid reactive scaling.
+const (
+	metadataReactiveAdapter = "reactiveAdapter"
+	metadataReactiveValue   = "reactiveValue"
+	metadataTargetPerPod    = "targetPerPod"
+	metadataCorrectionSigma = "correctionSigma"
+
+	// metadataReactiveConfigPrefix prefixes the reactive adapter's configuration
+	// keys, e.g. reactive.url and reactive.query.
+	metadataReactiveConfigPrefix = "reactive."
+)
+
+const (
+	// reactiveTimeout bounds one live metric query, well within KEDA's polling interval.
+	reactiveTimeout = 5 * time.Second
+
+	// reactiveWindow is how far back the live metric is queried, at reactiveStep
+	// resolution; its latest point is used.
+	reactiveWindow = 5 * time.Minute
+	reactiveStep   = 60
+
+	// residualAlpha weights the latest residual in a workload's running residual
+	// variance, and minResiduals is the number of residuals it needs to be used.
+	residualAlpha = 0.1
+	minResiduals  = 10
+)
+
+// reactive configures hybrid scaling of a ScaledObject: the replicas needed for the
+// live metric value are returned when they exceed the forecast ones, so a surprise
+// spike is served before the next forecast tick catches up with it.
+type reactive struct {
+	// adapter queries the live metric. Nil when value is set.
+	adapter adapters.Adapter
+
+	// value is a live metric value given in the trigger metadata.
+	value *float64
+
+	// targetPerPod converts the live value to replicas.
+	targetPerPod float64
+
+	// correctionSigma, when positive, shifts the forecast over the lead window by
+	// the gap between the live value and the current forecast once that gap exceeds
+	// this many standard deviations of the forecast error. The shift only sizes the
+	// replicas returned to KEDA; the forecaster's snapshot is left untouched.
+	correctionSigma float64
+}
+
+// parseReactive reads the hybrid scaling settings from a ScaledObject's trigger
+// metadata. It returns nil when neither reactiveAdapter nor reactiveValue is set.
+func parseReactive(metadata map[string]string) (*reactive, error) {
+	r := &reactive{}
+	kind := metadata[metadataReactiveAdapter]
+	if raw := metadata[metadataReactiveValue]; raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", metadataReactiveValue, raw, err)
+		}
+		r.value = &value
+	}
+	if kind == "" && r.value == nil {
+		return nil, nil
+	}
+	if r.value == nil {
+		config := make(map[string]string)
+		for k, v := range metadata {
+			if key, ok := strings.CutPrefix(k, metadataReactiveConfigPrefix); ok {
+				config[key] = v
+			}
+		}
+		adapter, err := adapters.New(kind, config, reactiveStep)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reactive adapter: %w", err)
+		}
+		r.adapter = adapter
+	}
+
+	raw := metadata[metadataTargetPerPod]
+	if raw == "" {
+		return nil, fmt.Errorf("%s is required for reactive scaling", metadataTargetPerPod)
+	}
+	var err error
+	r.targetPerPod, err = strconv.ParseFloat(raw, 64)
+	if err != nil || r.targetPerPod <= 0 {
+		return nil, fmt.Errorf("invalid %s %q: must be a number > 0", metadataTargetPerPod, raw)
+	}
+	if raw := metadata[metadataCorrectionSigma]; raw != "" {
+		r.correctionSigma, err = strconv.ParseFloat(raw, 64)
+		if err != nil || r.correctionSigma < 0 {
+			return nil, fmt.Errorf("invalid %s %q: must be a number >= 0", metadataCorrectionSigma, raw)
+		}
+	}
+	return r, nil
+}
+
+// reactives caches the reactive settings parsed from each ScaledObject's trigger
+// metadata, so its live metric adapter is built once rather than on every poll.
+// An entry is parsed again when the ScaledObject's metadata changes. Safe for
+// concurrent use.
+type reactives struct {
+	mu       sync.Mutex
+	byObject map[string]parsedReactive
+}
+
+type parsedReactive struct {
+	metadata map[string]string
+	reactive *reactive
+	err      error
+}
+
+func newReactives() *reactives {
+	return &reactives{byObject: make(map[string]parsedReactive)}
+}
+
+// get returns the reactive settings of the ScaledObject namespace/name, parsing
+// metadata unless it is unchanged since the last call.
+func (c *reactives) get(namespace, name string, metadata map[string]string) (*reactive, error) {
+	key := namespace + "/" + name
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if parsed, ok := c.byObject[key]; ok && maps.Equal(parsed.metadata, metadata) {
+		return parsed.reactive, parsed.err
+	}
+	r, err := parseReactive(metadata)
+	c.byObject[key] = parsedReactive{metadata: maps.Clone(metadata), reactive: r, err: err}
+	return r, err
+}
+
+// liveValue returns the latest value of the live metric.
+func (r *reactive) liveValue(ctx context.Context) (float64, error) {
+	if r.value != nil {
+		return *r.value, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reactiveTimeout)
+	defer cancel()
+	df, err := r.adapter.Collect(ctx, int(reactiveWindow.Seconds()))
+	if err != nil {
+		return 0, fmt.Errorf("query live metric: %w", err)
+	}
+	for i := len(df.Rows) - 1; i >= 0; i-- {
+		if value, ok := df.Rows[i]["value"].(float64); ok && !math.IsNaN(value) && !math.IsInf(value, 0) {
+			return value, nil
+		}
+	}
+	return 0, fmt.Errorf("live metric returned no value")
+}
+
+// residuals keeps a running variance of each workload's forecast error, used as
+// its sigma when the snapshot has no quantiles. Safe for concurrent use.
+type residuals struct {
+	mu         sync.Mutex
+	byWorkload map[string]residualStats
+}
+
+type residualStats struct {
+	variance float64
+	count    int
+}
+
+func newResiduals() *residuals {
+	return &residuals{byWorkload: make(map[string]residualStats)}
+}
+
+// observe folds a residual into a workload's variance and returns the standard
+// deviation from before it, or false while too few residuals were seen.
+func (r *residuals) observe(workload string, residual float64) (float64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := r.byWorkload[workload]
+	sigma, ok := math.Sqrt(stats.variance), stats.count >= minResiduals
+	if stats.count == 0 {
+		stats.variance = residual * residual
+	} else {
+		stats.variance = (1-residualAlpha)*stats.variance + residualAlpha*residual*residual
+	}
+	stats.count++
+	r.byWorkload[workload] = stats
+	return sigma, ok
+}
+
+// reactiveReplicas combines the forecast replicas with those needed for the live
+// value: it returns the larger of the two and, when the live value exceeds the
+// current forecast by correctionSigma standard deviations, of the replicas for the
+// forecast over the lead window shifted up by that excess. The shifted forecast is
+// not stored; the forecaster's next tick catches up from the collected data.
+// corrected reports whether the correction applied.
+func (s *Scaler) reactiveReplicas(snapshot *storage.Snapshot, r *reactive, live float64, predicted int, age time.Duration) (replicas int, corrected bool) {
+	replicas = max(predicted, int(math.Ceil(live/r.targetPerPod)))
+	if len(snapshot.Values) == 0 || snapshot.StepSeconds <= 0 {
+		return replicas, false
+	}
+
+	step := time.Duration(snapshot.StepSeconds) * time.Second
+	now := min(int(max(age, 0)/step), len(snapshot.Values)-1)
+	excess := live - snapshot.Values[now]
+
+	sigma, ok := quantileSigma(snapshot, now)
+	if tracked, enough := s.residuals.observe(snapshot.Workload, excess); !ok {
+		sigma, ok = tracked, enough && tracked > 0
+	}
+	if r.correctionSigma <= 0 || !ok || excess <= r.correctionSigma*sigma {
+		return replicas, false
+	}
+
+	end := min(int((max(age, 0)+s.leadTime)/step), len(snapshot.Values)-1)
+	peak := snapshot.Values[now]
+	for _, v := range snapshot.Values[now+1 : end+1] {
+		peak = max(peak, v)
+	}
+	s.logger.Info("live metric exceeds forecast, correcting",
+		"workload", snapshot.Workload,
+		"live", live,
+		"forecast", snapshot.Values[now],
+		"sigma", sigma,
+	)
+	return max(replicas, int(math.Ceil((peak+excess)/r.targetPerPod))), true
+}
+
+// quantileSigma estimates the standard deviation of the forecast error at step i
+// from the spread between the point forecast and its highest upper quantile,
+// assuming normal errors. It returns false when the snapshot has no such quantile
+// or it does not spread above the point forecast.
+func quantileSigma(snapshot *storage.Snapshot, i int) (float64, bool) {
+	level := 0.0
+	for q, values := range snapshot.Quantiles {
+		if q > 0.5 && q < 1 && q > level && len(values) > i {
+			level = q
+		}
+	}
+	if level == 0 {
+		return 0, false
+	}
+	z := math.Sqrt2 * math.Erfinv(2*level-1)
+	sigma := (snapshot.Quantiles[level][i] - snapshot.Values[i]) / z
+	return sigma, sigma > 0
+}
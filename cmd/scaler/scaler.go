@@ -4,13 +4,17 @@
 // defined in the KEDA external scaler protocol. It handles four key gRPC methods:
 //
 //   - IsActive: Determines if the scaler should be active based on forecast freshness
+//     and, when configured, whether the forecast predicts load
 //   - GetMetricSpec: Returns metric specifications for KEDA (metric name and target)
-//   - GetMetrics: Returns current predicted replica counts from the forecaster
+//   - GetMetrics: Returns current predicted replica counts from the forecaster, raised
+//     to the replicas needed for the live metric when hybrid scaling is configured
 //   - StreamIsActive: Not implemented (returns error directing to use 'external' type)
 //
 // The scaler fetches forecast snapshots from the Kedastral forecaster via HTTP,
-// selects the appropriate replica count based on configured lead time, and returns
-// this value to KEDA for scaling decisions.
+// taking the freshest across its healthy replicas, or reads them directly from
+// the forecaster's Redis storage. Snapshots can be served from a per-workload
+// cache. The scaler selects the replica count at the configured lead time and
+// returns it to KEDA for scaling decisions.
 package main
 
 import (
@@ -18,7 +22,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/HatiCode/kedastral/cmd/scaler/metrics"
@@ -36,9 +42,22 @@ type Scaler struct {
 	logger        *slog.Logger
 	leadTime      time.Duration
 	metrics       *metrics.Metrics
+	activations   *activations
+	reactives     *reactives
+	residuals     *residuals
+	lastGood      *lastKnownGood
+	cache         *snapshotCache
+	sources       []snapshotSource
+
+	// forecasters are the forecaster endpoints, given in forecasterURL or resolved
+	// from DNS SRV records with lookupSRV until stopResolve is called.
+	forecasters *endpointPool
+	lookupSRV   func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	stopResolve context.CancelFunc
 }
 
-// New creates a new scaler instance with optional mTLS support.
+// New creates a new scaler instance with optional mTLS support. forecasterURL is a
+// comma-separated list of forecaster endpoints.
 func New(forecasterURL string, leadTime time.Duration, tlsCfg tls.Config, logger *slog.Logger, m *metrics.Metrics) (*Scaler, error) {
 	if logger == nil {
 		logger = slog.Default()
@@ -59,10 +78,37 @@ func New(forecasterURL string, leadTime time.Duration, tlsCfg tls.Config, logger
 		logger:        logger,
 		leadTime:      leadTime,
 		metrics:       m,
+		activations:   newActivations(),
+		reactives:     newReactives(),
+		residuals:     newResiduals(),
+		lastGood:      newLastKnownGood(),
+		forecasters:   newEndpointPool(parseEndpoints(forecasterURL), m),
+		lookupSRV:     net.DefaultResolver.LookupSRV,
 	}, nil
 }
 
-// IsActive determines if the scaler is active for the given ScaledObject
+// EnableSnapshotCache caches snapshots until the next one is expected, coalescing
+// concurrent fetches of a workload and refreshing them in the background. interval
+// is the forecaster interval assumed for snapshots that do not report theirs, and
+// minTTL the least time a snapshot is cached. Call it before serving requests.
+func (s *Scaler) EnableSnapshotCache(interval, minTTL time.Duration) {
+	s.cache = newSnapshotCache(s.fetchSnapshot, interval, minTTL, s.logger, s.metrics)
+}
+
+// Close stops the background work of the scaler.
+func (s *Scaler) Close() {
+	if s.stopResolve != nil {
+		s.stopResolve()
+	}
+	if s.cache != nil {
+		s.cache.close()
+	}
+}
+
+// IsActive determines if the scaler is active for the given ScaledObject. It is
+// inactive without a fresh forecast, unless its fallback serves replicas, and, when
+// the ScaledObject configures an activation threshold, while the forecast stays
+// below it for the lead window.
 func (s *Scaler) IsActive(ctx context.Context, ref *pb.ScaledObjectRef) (*pb.IsActiveResponse, error) {
 	start := time.Now()
 	defer func() {
@@ -79,30 +125,63 @@ func (s *Scaler) IsActive(ctx context.Context, ref *pb.ScaledObjectRef) (*pb.IsA
 		"workload", workload,
 	)
 
+	act, err := parseActivation(ref.ScalerMetadata)
+	if err != nil {
+		if s.metrics != nil {
+			s.metrics.RecordGRPCRequest("IsActive", "error")
+		}
+		return nil, fmt.Errorf("invalid activation metadata: %w", err)
+	}
+	fb, err := parseFallback(ref.ScalerMetadata)
+	if err != nil {
+		if s.metrics != nil {
+			s.metrics.RecordGRPCRequest("IsActive", "error")
+		}
+		return nil, fmt.Errorf("invalid fallback metadata: %w", err)
+	}
+	key := activationKey(ref)
+
 	snapshot, err := s.getForecast(ctx, workload)
 	if err != nil {
-		s.logger.Warn("failed to get forecast, marking inactive",
+		s.logger.Warn("failed to get forecast",
 			"workload", workload,
 			"error", err,
 		)
-		if s.metrics != nil {
-			s.metrics.RecordGRPCRequest("IsActive", "inactive_error")
-		}
-		return &pb.IsActiveResponse{Result: false}, nil
+		return s.unavailable(key, workload, fb, "inactive_error"), nil
 	}
 
 	age := time.Since(snapshot.GeneratedAt)
-	staleThreshold := s.leadTime * 2
-	if age > staleThreshold {
-		s.logger.Warn("forecast is stale, marking inactive",
+	if age > s.staleAfter() {
+		s.logger.Warn("forecast is stale",
 			"workload", workload,
 			"age", age,
-			"threshold", staleThreshold,
+			"threshold", s.staleAfter(),
 		)
-		if s.metrics != nil {
-			s.metrics.RecordGRPCRequest("IsActive", "inactive_stale")
+		return s.unavailable(key, workload, fb, "inactive_stale"), nil
+	}
+
+	if act != nil {
+		// An active workload stays active down to the deactivation threshold.
+		threshold := act.threshold
+		if s.activations.isActive(key) {
+			threshold = act.deactivationThreshold
+		}
+		peak, ok := s.peakForecast(snapshot, act, age)
+		active := ok && peak >= threshold
+		s.activations.set(key, active)
+		if !active {
+			s.logger.Debug("no load predicted, marking inactive",
+				"workload", workload,
+				"peak", peak,
+				"threshold", threshold,
+				"window", s.leadTime+act.startupTime,
+			)
+			if s.metrics != nil {
+				s.metrics.RecordGRPCRequest("IsActive", "inactive_no_load")
+				s.metrics.SetForecastAge(age.Seconds())
+			}
+			return &pb.IsActiveResponse{Result: false}, nil
 		}
-		return &pb.IsActiveResponse{Result: false}, nil
 	}
 
 	s.logger.Debug("scaler is active",
@@ -118,6 +197,29 @@ func (s *Scaler) IsActive(ctx context.Context, ref *pb.ScaledObjectRef) (*pb.IsA
 	return &pb.IsActiveResponse{Result: true}, nil
 }
 
+// unavailable answers IsActive for a ScaledObject without a fresh forecast: it is
+// inactive, unless its fallback serves replicas. status is recorded when inactive.
+func (s *Scaler) unavailable(key, workload string, fb *fallback, status string) *pb.IsActiveResponse {
+	s.activations.set(key, false)
+	if fb != nil {
+		if replicas, ok := s.fallbackReplicas(workload, fb, time.Now()); ok && replicas > 0 {
+			if s.metrics != nil {
+				s.metrics.RecordGRPCRequest("IsActive", "active_fallback")
+			}
+			return &pb.IsActiveResponse{Result: true}
+		}
+	}
+	if s.metrics != nil {
+		s.metrics.RecordGRPCRequest("IsActive", status)
+	}
+	return &pb.IsActiveResponse{Result: false}
+}
+
+// staleAfter is the forecast age past which a forecast is no longer used.
+func (s *Scaler) staleAfter() time.Duration {
+	return 2 * s.leadTime
+}
+
 // StreamIsActive streams the active status (not implemented)
 func (s *Scaler) StreamIsActive(ref *pb.ScaledObjectRef, stream pb.ExternalScaler_StreamIsActiveServer) error {
 	s.logger.Debug("StreamIsActive called (not implemented)",
@@ -163,7 +265,10 @@ func (s *Scaler) GetMetricSpec(ctx context.Context, ref *pb.ScaledObjectRef) (*p
 	}, nil
 }
 
-// GetMetrics returns the current metric values
+// GetMetrics returns the current metric values. With hybrid scaling configured in
+// the trigger metadata, the forecast replicas are raised to those needed for the
+// live metric value. With a fallback configured, the fallback replicas are returned
+// while the forecast is unavailable or stale.
 func (s *Scaler) GetMetrics(ctx context.Context, req *pb.GetMetricsRequest) (*pb.GetMetricsResponse, error) {
 	start := time.Now()
 	defer func() {
@@ -181,8 +286,42 @@ func (s *Scaler) GetMetrics(ctx context.Context, req *pb.GetMetricsRequest) (*pb
 		"metricName", req.MetricName,
 	)
 
+	react, err := s.reactives.get(req.ScaledObjectRef.Namespace, req.ScaledObjectRef.Name, req.ScaledObjectRef.ScalerMetadata)
+	if err != nil {
+		if s.metrics != nil {
+			s.metrics.RecordGRPCRequest("GetMetrics", "error")
+		}
+		return nil, fmt.Errorf("invalid reactive metadata: %w", err)
+	}
+	fb, err := parseFallback(req.ScaledObjectRef.ScalerMetadata)
+	if err != nil {
+		if s.metrics != nil {
+			s.metrics.RecordGRPCRequest("GetMetrics", "error")
+		}
+		return nil, fmt.Errorf("invalid fallback metadata: %w", err)
+	}
+
 	snapshot, err := s.getForecast(ctx, workload)
+	if err == nil && fb != nil && time.Since(snapshot.GeneratedAt) > s.staleAfter() {
+		err = fmt.Errorf("forecast is stale (age %s)", time.Since(snapshot.GeneratedAt).Round(time.Second))
+	}
 	if err != nil {
+		if fb != nil {
+			if replicas, ok := s.fallbackReplicas(workload, fb, time.Now()); ok {
+				s.logger.Warn("forecast unavailable, returning fallback replicas",
+					"workload", workload,
+					"mode", fb.mode,
+					"replicas", replicas,
+					"error", err,
+				)
+				if s.metrics != nil {
+					s.metrics.RecordGRPCRequest("GetMetrics", "fallback")
+					s.metrics.RecordFallback(fb.mode)
+					s.metrics.SetDesiredReplicas(replicas)
+				}
+				return metricValue(req.MetricName, replicas), nil
+			}
+		}
 		if s.metrics != nil {
 			s.metrics.RecordGRPCRequest("GetMetrics", "error")
 		}
@@ -190,6 +329,31 @@ func (s *Scaler) GetMetrics(ctx context.Context, req *pb.GetMetricsRequest) (*pb
 	}
 
 	desiredReplicas := s.selectReplicasAtLeadTime(snapshot)
+	if time.Since(snapshot.GeneratedAt) <= s.staleAfter() {
+		s.lastGood.store(workload, desiredReplicas, time.Now())
+	}
+
+	if react != nil {
+		live, err := react.liveValue(ctx)
+		if err != nil {
+			s.logger.Warn("failed to get live metric, returning forecast replicas",
+				"workload", workload,
+				"error", err,
+			)
+			if s.metrics != nil {
+				s.metrics.RecordReactiveError()
+			}
+		} else {
+			var corrected bool
+			desiredReplicas, corrected = s.reactiveReplicas(snapshot, react, live, desiredReplicas, time.Since(snapshot.GeneratedAt))
+			if s.metrics != nil {
+				s.metrics.SetLiveValue(live)
+				if corrected {
+					s.metrics.RecordForecastCorrection()
+				}
+			}
+		}
+	}
 
 	s.logger.Info("returning desired replicas",
 		"workload", workload,
@@ -203,18 +367,32 @@ func (s *Scaler) GetMetrics(ctx context.Context, req *pb.GetMetricsRequest) (*pb
 		s.metrics.SetForecastAge(time.Since(snapshot.GeneratedAt).Seconds())
 	}
 
+	return metricValue(req.MetricName, desiredReplicas), nil
+}
+
+// metricValue is the GetMetrics response returning replicas as metricName.
+func metricValue(metricName string, replicas int) *pb.GetMetricsResponse {
 	return &pb.GetMetricsResponse{
 		MetricValues: []*pb.MetricValue{
 			{
-				MetricName:       req.MetricName,
-				MetricValueFloat: float64(desiredReplicas),
+				MetricName:       metricName,
+				MetricValueFloat: float64(replicas),
 			},
 		},
-	}, nil
+	}
 }
 
-// getForecast fetches the latest forecast snapshot from the forecaster
+// getForecast returns the latest forecast snapshot, from the cache when enabled.
+// The snapshot must not be modified.
 func (s *Scaler) getForecast(ctx context.Context, workload string) (*storage.Snapshot, error) {
+	if s.cache != nil {
+		return s.cache.get(ctx, workload)
+	}
+	return s.fetchSnapshot(ctx, workload)
+}
+
+// fetchFrom fetches the latest forecast snapshot from the forecaster at baseURL
+func (s *Scaler) fetchFrom(ctx context.Context, baseURL, workload string) (*storage.Snapshot, error) {
 	start := time.Now()
 	defer func() {
 		if s.metrics != nil {
@@ -222,9 +400,9 @@ func (s *Scaler) getForecast(ctx context.Context, workload string) (*storage.Sna
 		}
 	}()
 
-	url := fmt.Sprintf("%s/forecast/current?workload=%s", s.forecasterURL, workload)
+	endpoint := baseURL + "/forecast/current?workload=" + url.QueryEscape(workload)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		if s.metrics != nil {
 			s.metrics.RecordForecastFetchError()
@@ -245,7 +423,7 @@ func (s *Scaler) getForecast(ctx context.Context, workload string) (*storage.Sna
 		if s.metrics != nil {
 			s.metrics.RecordForecastFetchError()
 		}
-		return nil, fmt.Errorf("forecaster returned status %d", resp.StatusCode)
+		return nil, &statusError{code: resp.StatusCode}
 	}
 
 	var snapshot storage.Snapshot
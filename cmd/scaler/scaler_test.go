@@ -3,14 +3,18 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/HatiCode/kedastral/cmd/scaler/metrics"
+	"github.com/HatiCode/kedastral/pkg/adapters"
 	pb "github.com/HatiCode/kedastral/pkg/api/externalscaler"
 	"github.com/HatiCode/kedastral/pkg/storage"
 	"github.com/HatiCode/kedastral/pkg/tls"
@@ -307,6 +311,103 @@ func TestScaler_IsActive_Error(t *testing.T) {
 	}
 }
 
+func TestScaler_IsActive_Activation(t *testing.T) {
+	var snapshot storage.Snapshot
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+			t.Errorf("failed to encode snapshot: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s, err := New(server.URL, 5*time.Minute, tls.Config{Enabled: false}, logger, scalerTestMetrics)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// forecast serves values at one-minute steps generated now.
+	forecast := func(values []float64, quantiles map[float64][]float64) {
+		snapshot = storage.Snapshot{
+			Workload:        "test-api",
+			GeneratedAt:     time.Now(),
+			StepSeconds:     60,
+			Values:          values,
+			Quantiles:       quantiles,
+			DesiredReplicas: make([]int, len(values)),
+		}
+	}
+	isActive := func(metadata map[string]string) bool {
+		t.Helper()
+		resp, err := s.IsActive(context.Background(), &pb.ScaledObjectRef{Name: "test-api", Namespace: "default", ScalerMetadata: metadata})
+		if err != nil {
+			t.Fatalf("IsActive() error = %v", err)
+		}
+		return resp.Result
+	}
+
+	// Traffic predicted 8 minutes out: outside the 5m lead window, inside it plus
+	// a 3m startup time.
+	spike := []float64{0, 0, 0, 0, 0, 0, 0, 0, 50, 50}
+	forecast(spike, nil)
+	if isActive(map[string]string{"activationThreshold": "10"}) {
+		t.Error("IsActive() = true with no load predicted within the lead time")
+	}
+	if !isActive(map[string]string{"activationThreshold": "10", "startupTime": "3m"}) {
+		t.Error("IsActive() = false with load predicted within the lead and startup time")
+	}
+
+	// The chosen quantile is compared with the threshold.
+	forecast(make([]float64, 10), map[float64][]float64{0.9: {0, 20, 0, 0, 0, 0, 0, 0, 0, 0}})
+	if !isActive(map[string]string{"activationThreshold": "10", "activationQuantile": "p90"}) {
+		t.Error("IsActive() = false with the p90 forecast above the threshold")
+	}
+
+	// An active workload stays active down to the deactivation threshold.
+	hysteresis := map[string]string{"activationThreshold": "10", "deactivationThreshold": "5"}
+	for i, tt := range []struct {
+		value float64
+		want  bool
+	}{
+		{20, true},
+		{6, true},
+		{3, false},
+		{6, false},
+		{10, true},
+	} {
+		forecast([]float64{tt.value, tt.value}, nil)
+		if got := isActive(hysteresis); got != tt.want {
+			t.Errorf("step %d: IsActive() with forecast %v = %v, want %v", i, tt.value, got, tt.want)
+		}
+	}
+
+	// Without a threshold only freshness matters.
+	forecast([]float64{0, 0}, nil)
+	if !isActive(map[string]string{}) {
+		t.Error("IsActive() = false for a fresh forecast without an activation threshold")
+	}
+}
+
+func TestParseActivation_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]string
+	}{
+		{"invalid threshold", map[string]string{"activationThreshold": "high"}},
+		{"deactivation above activation", map[string]string{"activationThreshold": "10", "deactivationThreshold": "20"}},
+		{"invalid quantile", map[string]string{"activationThreshold": "10", "activationQuantile": "p200"}},
+		{"invalid startup time", map[string]string{"activationThreshold": "10", "startupTime": "soon"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseActivation(tt.metadata); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
 func TestScaler_GetMetricSpec(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	m := scalerTestMetrics
@@ -427,6 +528,339 @@ func TestScaler_GetMetrics_Error(t *testing.T) {
 	}
 }
 
+func TestScaler_GetMetrics_Reactive(t *testing.T) {
+	snapshot := storage.Snapshot{
+		Workload:        "test-api",
+		GeneratedAt:     time.Now(),
+		StepSeconds:     60,
+		Values:          []float64{200, 200, 200},
+		DesiredReplicas: []int{2, 2, 2},
+	}
+	forecaster := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+			t.Errorf("failed to encode snapshot: %v", err)
+		}
+	}))
+	defer forecaster.Close()
+
+	// A surprise spike: the live metric is far above the forecast.
+	prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		now := time.Now().Unix()
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[%d,"210"],[%d,"950"]]}]}}`, now-60, now)
+	}))
+	defer prometheus.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s, err := New(forecaster.URL, 5*time.Minute, tls.Config{Enabled: false}, logger, scalerTestMetrics)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	req := &pb.GetMetricsRequest{
+		ScaledObjectRef: &pb.ScaledObjectRef{
+			Name:      "test-api",
+			Namespace: "default",
+			ScalerMetadata: map[string]string{
+				"reactiveAdapter": "prometheus",
+				"reactive.url":    prometheus.URL,
+				"reactive.query":  "sum(rate(http_requests_total[1m]))",
+				"targetPerPod":    "100",
+			},
+		},
+		MetricName: "kedastral-test-api-desired-replicas",
+	}
+	resp, err := s.GetMetrics(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetMetrics() error = %v", err)
+	}
+	if got := resp.MetricValues[0].MetricValueFloat; got != 10 {
+		t.Errorf("GetMetrics() = %v, want the 10 replicas needed for the live value", got)
+	}
+
+	// The forecast replicas win when the live value needs fewer.
+	req.ScaledObjectRef.ScalerMetadata = map[string]string{"reactiveValue": "50", "targetPerPod": "100"}
+	resp, err = s.GetMetrics(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetMetrics() error = %v", err)
+	}
+	if got := resp.MetricValues[0].MetricValueFloat; got != 2 {
+		t.Errorf("GetMetrics() = %v, want the 2 forecast replicas", got)
+	}
+}
+
+func TestScaler_ReactiveReplicas_Correction(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	values := []float64{100, 100, 120, 100, 100, 100, 300}
+	p90 := make([]float64, len(values))
+	for i, v := range values {
+		p90[i] = v + 1.2815515655446004*10 // sigma 10
+	}
+	r := &reactive{targetPerPod: 50, correctionSigma: 3}
+
+	tests := []struct {
+		name          string
+		quantiles     map[float64][]float64
+		warmup        []float64
+		live          float64
+		want          int
+		wantCorrected bool
+	}{
+		// 100 above a forecast of 100 exceeds 3 sigma: the lead window peak of 120
+		// is shifted to 220, needing 5 replicas.
+		{"quantile sigma spike", map[float64][]float64{0.9: p90}, nil, 200, 5, true},
+		{"quantile sigma within band", map[float64][]float64{0.9: p90}, nil, 120, 3, false},
+		{"too few residuals", nil, nil, 200, 4, false},
+		{"residual sigma spike", nil, []float64{95, 105, 95, 105, 95, 105, 95, 105, 95, 105}, 200, 5, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scaler{leadTime: 5 * time.Minute, logger: logger, residuals: newResiduals()}
+			snapshot := &storage.Snapshot{Workload: "test-api", StepSeconds: 60, Values: values, Quantiles: tt.quantiles}
+			for _, live := range tt.warmup {
+				s.reactiveReplicas(snapshot, r, live, 3, 0)
+			}
+
+			got, corrected := s.reactiveReplicas(snapshot, r, tt.live, 3, 0)
+			if got != tt.want || corrected != tt.wantCorrected {
+				t.Errorf("reactiveReplicas() = %d, %v, want %d, %v", got, corrected, tt.want, tt.wantCorrected)
+			}
+		})
+	}
+}
+
+func TestParseReactive(t *testing.T) {
+	if r, err := parseReactive(map[string]string{"targetPerPod": "10"}); r != nil || err != nil {
+		t.Errorf("parseReactive() = %v, %v, want nil without a live metric", r, err)
+	}
+
+	r, err := parseReactive(map[string]string{
+		"reactiveAdapter": "prometheus",
+		"reactive.query":  "up",
+		"targetPerPod":    "10",
+		"correctionSigma": "3",
+	})
+	if err != nil {
+		t.Fatalf("parseReactive() error = %v", err)
+	}
+	if prom, ok := r.adapter.(*adapters.PrometheusAdapter); !ok || prom.Query != "up" || r.targetPerPod != 10 || r.correctionSigma != 3 {
+		t.Errorf("parseReactive() = %+v", r)
+	}
+
+	for name, metadata := range map[string]map[string]string{
+		"missing targetPerPod": {"reactiveValue": "5"},
+		"invalid adapter":      {"reactiveAdapter": "kafka", "targetPerPod": "10"},
+		"missing query":        {"reactiveAdapter": "prometheus", "targetPerPod": "10"},
+		"negative sigma":       {"reactiveValue": "5", "targetPerPod": "10", "correctionSigma": "-1"},
+	} {
+		if _, err := parseReactive(metadata); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestReactives_Get(t *testing.T) {
+	c := newReactives()
+	metadata := map[string]string{
+		"reactiveAdapter": "prometheus",
+		"reactive.url":    "http://prometheus:9090",
+		"reactive.query":  "sum(rate(http_requests_total[1m]))",
+		"targetPerPod":    "100",
+	}
+
+	first, err := c.get("default", "api", metadata)
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	// Polls with unchanged metadata reuse the parsed settings and their adapter.
+	again, err := c.get("default", "api", maps.Clone(metadata))
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	if again != first {
+		t.Error("unchanged metadata should not be parsed again")
+	}
+	if other, _ := c.get("shop", "api", metadata); other == first {
+		t.Error("another ScaledObject should be parsed on its own")
+	}
+
+	// Changed metadata is parsed again, even when the caller edits its own map.
+	metadata["targetPerPod"] = "50"
+	changed, err := c.get("default", "api", metadata)
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	if changed == first || changed.targetPerPod != 50 {
+		t.Errorf("targetPerPod = %v, want 50 from the changed metadata", changed.targetPerPod)
+	}
+	metadata["targetPerPod"] = "-1"
+	if _, err := c.get("default", "api", metadata); err == nil {
+		t.Error("get() should fail for the invalid targetPerPod")
+	}
+}
+
+func TestScaler_GetMetrics_Fallback(t *testing.T) {
+	snapshot := storage.Snapshot{
+		Workload:        "test-api",
+		GeneratedAt:     time.Now(),
+		StepSeconds:     60,
+		Values:          []float64{500, 500},
+		DesiredReplicas: []int{8, 8},
+	}
+	down := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+			t.Errorf("failed to encode snapshot: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s, err := New(server.URL, 5*time.Minute, tls.Config{Enabled: false}, logger, scalerTestMetrics)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	getMetrics := func(metadata map[string]string) (float64, error) {
+		resp, err := s.GetMetrics(context.Background(), &pb.GetMetricsRequest{
+			ScaledObjectRef: &pb.ScaledObjectRef{Name: "test-api", Namespace: "default", ScalerMetadata: metadata},
+			MetricName:      "kedastral-test-api-desired-replicas",
+		})
+		if err != nil {
+			return 0, err
+		}
+		return resp.MetricValues[0].MetricValueFloat, nil
+	}
+
+	hold := map[string]string{"fallbackMode": "hold"}
+	fixed := map[string]string{"fallbackMode": "fixed", "fallbackReplicas": "3"}
+
+	// Before any good forecast, hold has nothing to serve.
+	down = true
+	if _, err := getMetrics(hold); err == nil {
+		t.Error("GetMetrics() with hold and no good forecast should return an error")
+	}
+
+	down = false
+	if got, err := getMetrics(hold); err != nil || got != 8 {
+		t.Fatalf("GetMetrics() = %v, %v, want the 8 forecast replicas", got, err)
+	}
+
+	down = true
+	if got, err := getMetrics(hold); err != nil || got != 8 {
+		t.Errorf("GetMetrics() with hold = %v, %v, want the last good 8", got, err)
+	}
+	if got, err := getMetrics(fixed); err != nil || got != 3 {
+		t.Errorf("GetMetrics() with fixed = %v, %v, want 3", got, err)
+	}
+	if _, err := getMetrics(map[string]string{}); err == nil {
+		t.Error("GetMetrics() without a fallback should return an error")
+	}
+
+	// A stale forecast falls back too.
+	down = false
+	snapshot.GeneratedAt = time.Now().Add(-time.Hour)
+	if got, err := getMetrics(fixed); err != nil || got != 3 {
+		t.Errorf("GetMetrics() with a stale forecast = %v, %v, want the fixed 3", got, err)
+	}
+
+	// IsActive stays active while the fallback serves replicas.
+	down = true
+	resp, err := s.IsActive(context.Background(), &pb.ScaledObjectRef{Name: "test-api", Namespace: "default", ScalerMetadata: hold})
+	if err != nil || !resp.Result {
+		t.Errorf("IsActive() with hold = %v, %v, want active", resp, err)
+	}
+}
+
+func TestScaler_FallbackReplicas_Decay(t *testing.T) {
+	s := &Scaler{lastGood: newLastKnownGood()}
+	goodAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	s.lastGood.store("test-api", 12, goodAt)
+	f := &fallback{mode: fallbackDecay, replicas: -1, decayTime: 40 * time.Minute, minReplicas: 2}
+
+	for _, tt := range []struct {
+		elapsed time.Duration
+		want    int
+	}{
+		{0, 12},
+		{10 * time.Minute, 10}, // 12 - 10*0.25 = 9.5, rounded up
+		{20 * time.Minute, 7},
+		{40 * time.Minute, 2},
+		{2 * time.Hour, 2},
+	} {
+		got, ok := s.fallbackReplicas("test-api", f, goodAt.Add(tt.elapsed))
+		if !ok || got != tt.want {
+			t.Errorf("fallbackReplicas() after %v = %d, %v, want %d", tt.elapsed, got, ok, tt.want)
+		}
+	}
+
+	// Without a good forecast, fallbackReplicas is served when set.
+	f.replicas = 4
+	if got, ok := s.fallbackReplicas("other", f, goodAt); !ok || got != 4 {
+		t.Errorf("fallbackReplicas() without a good forecast = %d, %v, want 4", got, ok)
+	}
+}
+
+func TestParseFallback_Errors(t *testing.T) {
+	for name, metadata := range map[string]map[string]string{
+		"unknown mode":           {"fallbackMode": "panic"},
+		"fixed without replicas": {"fallbackMode": "fixed"},
+		"negative replicas":      {"fallbackMode": "hold", "fallbackReplicas": "-1"},
+		"invalid decay time":     {"fallbackMode": "decay", "fallbackDecayTime": "0s"},
+		"invalid minReplicas":    {"fallbackMode": "decay", "minReplicas": "few"},
+	} {
+		if _, err := parseFallback(metadata); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestScaler_SnapshotCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		snapshot := storage.Snapshot{
+			Workload:        "test-api",
+			GeneratedAt:     time.Now(),
+			StepSeconds:     60,
+			IntervalSeconds: 30,
+			Values:          []float64{100, 100},
+			DesiredReplicas: []int{3, 3},
+		}
+		if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+			t.Errorf("failed to encode snapshot: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s, err := New(server.URL, 5*time.Minute, tls.Config{Enabled: false}, logger, scalerTestMetrics)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	s.EnableSnapshotCache(30*time.Second, 5*time.Second)
+	defer s.Close()
+
+	ref := &pb.ScaledObjectRef{Name: "test-api", Namespace: "default", ScalerMetadata: map[string]string{}}
+	for range 5 {
+		if _, err := s.IsActive(context.Background(), ref); err != nil {
+			t.Fatalf("IsActive() error = %v", err)
+		}
+		if _, err := s.GetMetrics(context.Background(), &pb.GetMetricsRequest{ScaledObjectRef: ref, MetricName: "m"}); err != nil {
+			t.Fatalf("GetMetrics() error = %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("forecaster requests = %d, want 1 while the snapshot is fresh", got)
+	}
+}
+
 func TestScaler_StreamIsActive_NotImplemented(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	m := scalerTestMetrics
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/storage"
+)
+
+// Snapshot sources.
+const (
+	// sourceHTTP is the forecaster's /forecast/current API.
+	sourceHTTP = "http"
+
+	// sourceRedis is the forecaster's Redis storage backend, read directly so
+	// scaling keeps working while the forecaster itself is down.
+	sourceRedis = "redis"
+)
+
+// storeTimeout bounds one snapshot read from the store.
+const storeTimeout = 2 * time.Second
+
+// snapshotSource fetches the latest snapshot of a workload from one place.
+type snapshotSource struct {
+	name  string
+	fetch func(ctx context.Context, workload string) (*storage.Snapshot, error)
+}
+
+// UseSources sets where snapshots are read from, in order of preference: "http"
+// for the forecaster API and "redis" for store, the forecaster's Redis backend. A
+// source that fails or has no snapshot of a workload falls through to the next.
+// store may be nil when "redis" is not listed. Call it before serving requests.
+func (s *Scaler) UseSources(names []string, store storage.Store) error {
+	if len(names) == 0 {
+		return errors.New("at least one snapshot source is required")
+	}
+	sources := make([]snapshotSource, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			return fmt.Errorf("snapshot source %q listed twice", name)
+		}
+		seen[name] = true
+
+		switch name {
+		case sourceHTTP:
+			sources = append(sources, snapshotSource{name: name, fetch: s.fetchForecast})
+		case sourceRedis:
+			if store == nil {
+				return fmt.Errorf("snapshot source %q requires a store", name)
+			}
+			sources = append(sources, snapshotSource{name: name, fetch: func(ctx context.Context, workload string) (*storage.Snapshot, error) {
+				return s.fetchFromStore(ctx, store, workload)
+			}})
+		default:
+			return fmt.Errorf("unknown snapshot source %q: must be http or redis", name)
+		}
+	}
+	s.sources = sources
+	return nil
+}
+
+// fetchSnapshot fetches the latest snapshot of workload from the first source
+// that has it.
+func (s *Scaler) fetchSnapshot(ctx context.Context, workload string) (*storage.Snapshot, error) {
+	if len(s.sources) == 0 {
+		return s.fetchForecast(ctx, workload)
+	}
+	var errs []error
+	for i, src := range s.sources {
+		snapshot, err := src.fetch(ctx, workload)
+		if err == nil {
+			if s.metrics != nil {
+				s.metrics.RecordSourceFetch(src.name, "ok")
+			}
+			return snapshot, nil
+		}
+		if s.metrics != nil {
+			s.metrics.RecordSourceFetch(src.name, "error")
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", src.name, err))
+		if ctx.Err() != nil {
+			break
+		}
+		if i < len(s.sources)-1 {
+			s.logger.Debug("snapshot source failed, trying next",
+				"workload", workload,
+				"source", src.name,
+				"next", s.sources[i+1].name,
+				"error", err,
+			)
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// fetchFromStore reads the latest snapshot of workload from store.
+func (s *Scaler) fetchFromStore(ctx context.Context, store storage.Store, workload string) (*storage.Snapshot, error) {
+	start := time.Now()
+	defer func() {
+		if s.metrics != nil {
+			s.metrics.ObserveForecastFetch(time.Since(start).Seconds())
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+
+	snapshot, found, err := store.GetLatest(ctx, workload)
+	if err != nil {
+		if s.metrics != nil {
+			s.metrics.RecordForecastFetchError()
+		}
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if !found {
+		if s.metrics != nil {
+			s.metrics.RecordForecastFetchError()
+		}
+		return nil, fmt.Errorf("snapshot not found for workload %q", workload)
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/storage"
+	"github.com/HatiCode/kedastral/pkg/tls"
+)
+
+// newSourceTestScaler returns a scaler whose forecaster API serves the given
+// desired replicas, or fails when replicas is nil, counting the requests.
+func newSourceTestScaler(t *testing.T, replicas []int, requests *atomic.Int32) *Scaler {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if replicas == nil {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		snapshot := storage.Snapshot{Workload: "api", GeneratedAt: time.Now(), StepSeconds: 60, DesiredReplicas: replicas}
+		if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+			t.Errorf("failed to encode snapshot: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s, err := New(server.URL, time.Minute, tls.Config{}, logger, scalerTestMetrics)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return s
+}
+
+func TestScaler_UseSources(t *testing.T) {
+	store := storage.NewMemoryStore()
+	defer store.Stop()
+	if err := store.Put(context.Background(), storage.Snapshot{
+		Workload:        "api",
+		GeneratedAt:     time.Now(),
+		StepSeconds:     60,
+		DesiredReplicas: []int{7},
+	}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		sources      []string
+		httpReplicas []int
+		workload     string
+		want         int
+		wantRequests int32
+		wantErr      bool
+	}{
+		{"store first", []string{"redis", "http"}, []int{3}, "api", 7, 0, false},
+		{"http first", []string{"http", "redis"}, []int{3}, "api", 3, 1, false},
+		{"store after failed http", []string{"http", "redis"}, nil, "api", 7, 1, false},
+		{"http after missing snapshot", []string{"redis", "http"}, []int{3}, "other", 3, 1, false},
+		{"store only, missing snapshot", []string{"redis"}, []int{3}, "other", 0, 0, true},
+		{"all sources fail", []string{"redis", "http"}, nil, "other", 0, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			s := newSourceTestScaler(t, tt.httpReplicas, &requests)
+			if err := s.UseSources(tt.sources, store); err != nil {
+				t.Fatalf("UseSources() error = %v", err)
+			}
+
+			snapshot, err := s.getForecast(context.Background(), tt.workload)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("getForecast() = %+v, want error", snapshot)
+				}
+			} else if err != nil {
+				t.Fatalf("getForecast() error = %v", err)
+			} else if got := snapshot.DesiredReplicas[0]; got != tt.want {
+				t.Errorf("replicas = %d, want %d", got, tt.want)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("forecaster requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestScaler_UseSources_Errors(t *testing.T) {
+	var requests atomic.Int32
+	s := newSourceTestScaler(t, []int{1}, &requests)
+	store := storage.NewMemoryStore()
+	defer store.Stop()
+
+	for _, sources := range [][]string{
+		nil,
+		{"grpc"},
+		{"http", "http"},
+		{"redis"},
+	} {
+		var st storage.Store
+		if len(sources) != 1 || sources[0] != "redis" {
+			st = store
+		}
+		if err := s.UseSources(sources, st); err == nil {
+			t.Errorf("UseSources(%v) error = nil, want error", sources)
+		}
+	}
+}
@@ -18,14 +18,21 @@ logger = logging.getLogger(__name__)
 app = Flask(__name__)
 
 
+PROTOCOL_VERSION = 'v1'
+
+# Prophet's interval_width=0.90 makes yhat_upper the 0.95 quantile.
+INTERVAL_WIDTH = 0.90
+UPPER_QUANTILE = '0.95'
+
+
 class ProphetForecaster:
     def __init__(self):
-        self.model = None
+        self.models = {}
         self.last_trained = None
 
-    def train_and_predict(self, features, horizon_seconds, step_seconds):
-        if not features:
-            raise ValueError("features cannot be empty")
+    def fit(self, workload, features):
+        if not features or len(features) < 2:
+            raise ValueError("features must contain at least 2 points for Prophet")
 
         df = pd.DataFrame([
             {
@@ -37,10 +44,10 @@ class ProphetForecaster:
 
         df = df.sort_values('ds').reset_index(drop=True)
 
-        logger.info(f"Training Prophet on {len(df)} historical points")
+        logger.info(f"Training Prophet on {len(df)} historical points for workload={workload or '-'}")
 
         model = Prophet(
-            interval_width=0.95,
+            interval_width=INTERVAL_WIDTH,
             daily_seasonality=True,
             weekly_seasonality=True,
             yearly_seasonality=False,
@@ -48,8 +55,16 @@ class ProphetForecaster:
         )
 
         model.fit(df)
-        self.model = model
+        self.models[workload] = model
         self.last_trained = datetime.now()
+        return model
+
+    def predict(self, workload, features, horizon_seconds, step_seconds):
+        # Reuse the fit from /train when Kedastral drives training; otherwise fit
+        # on the request's features as predict-only services do.
+        model = self.models.get(workload)
+        if model is None:
+            model = self.fit(workload, features)
 
         num_periods = horizon_seconds // step_seconds
         future_df = model.make_future_dataframe(
@@ -59,16 +74,55 @@ class ProphetForecaster:
         )
 
         forecast = model.predict(future_df)
-        predictions = forecast['yhat'].apply(lambda x: max(0, x)).tolist()
+        predictions = forecast['yhat'].apply(lambda x: max(0, x)).tolist()[:num_periods]
+        upper = forecast['yhat_upper'].apply(lambda x: max(0, x)).tolist()[:num_periods]
 
         logger.info(f"Generated {len(predictions)} predictions")
 
-        return predictions[:num_periods]
+        return predictions, {'0.50': predictions, UPPER_QUANTILE: upper}
 
 
 forecaster = ProphetForecaster()
 
 
+def parse_request():
+    """Validate the request body shared by /predict and /train."""
+    data = request.get_json()
+
+    if not data:
+        raise ValueError('request body is required')
+
+    protocol = data.get('protocol', PROTOCOL_VERSION)
+    if protocol != PROTOCOL_VERSION:
+        raise ValueError(f'unsupported protocol: {protocol}')
+
+    required_fields = ['horizonSeconds', 'stepSeconds', 'features']
+    for field in required_fields:
+        if field not in data:
+            raise ValueError(f'missing required field: {field}')
+
+    horizon_seconds = int(data['horizonSeconds'])
+    step_seconds = int(data['stepSeconds'])
+    features = data['features']
+
+    if horizon_seconds <= 0:
+        raise ValueError('horizonSeconds must be > 0')
+
+    if step_seconds <= 0:
+        raise ValueError('stepSeconds must be > 0')
+
+    if step_seconds > horizon_seconds:
+        raise ValueError('stepSeconds cannot exceed horizonSeconds')
+
+    for i, f in enumerate(features):
+        if 'ts' not in f:
+            raise ValueError(f'feature[{i}] missing required field: ts')
+        if 'value' not in f:
+            raise ValueError(f'feature[{i}] missing required field: value')
+
+    return data.get('workload', ''), horizon_seconds, step_seconds, features
+
+
 @app.route('/healthz', methods=['GET'])
 def healthz():
     return jsonify({
@@ -78,6 +132,34 @@ def healthz():
     }), 200
 
 
+@app.route('/info', methods=['GET'])
+def info():
+    return jsonify({
+        'protocol': PROTOCOL_VERSION,
+        'name': 'prophet',
+        'features': ['quantiles', 'train']
+    }), 200
+
+
+@app.route('/train', methods=['POST'])
+def train():
+    """
+    BYOM training endpoint. Takes the same request body as /predict and fits the
+    workload's model, which later /predict calls reuse.
+    """
+    try:
+        workload, _, _, features = parse_request()
+        forecaster.fit(workload, features)
+        return jsonify({'status': 'trained'}), 200
+
+    except ValueError as e:
+        logger.error(f"Validation error: {e}")
+        return jsonify({'error': str(e)}), 400
+    except Exception as e:
+        logger.error(f"Training error: {e}", exc_info=True)
+        return jsonify({'error': 'internal server error'}), 500
+
+
 @app.route('/predict', methods=['POST'])
 def predict():
     """
@@ -85,6 +167,8 @@ def predict():
 
     Request:
     {
+        "protocol": "v1",
+        "workload": "my-api",
         "now": "<RFC3339>",
         "horizonSeconds": 1800,
         "stepSeconds": 60,
@@ -94,49 +178,21 @@ def predict():
     Response:
     {
         "metric": "prophet_forecast",
-        "values": [420.0, 415.2, ...]
+        "values": [420.0, 415.2, ...],
+        "quantiles": {"0.50": [...], "0.95": [...]}
     }
     """
     try:
-        data = request.get_json()
-
-        if not data:
-            return jsonify({'error': 'request body is required'}), 400
-
-        required_fields = ['horizonSeconds', 'stepSeconds', 'features']
-        for field in required_fields:
-            if field not in data:
-                return jsonify({'error': f'missing required field: {field}'}), 400
-
-        horizon_seconds = int(data['horizonSeconds'])
-        step_seconds = int(data['stepSeconds'])
-        features = data['features']
-
-        if horizon_seconds <= 0:
-            return jsonify({'error': 'horizonSeconds must be > 0'}), 400
-
-        if step_seconds <= 0:
-            return jsonify({'error': 'stepSeconds must be > 0'}), 400
-
-        if step_seconds > horizon_seconds:
-            return jsonify({'error': 'stepSeconds cannot exceed horizonSeconds'}), 400
-
-        if not features or len(features) < 2:
-            return jsonify({'error': 'features must contain at least 2 points for Prophet'}), 400
-
-        for i, f in enumerate(features):
-            if 'ts' not in f:
-                return jsonify({'error': f'feature[{i}] missing required field: ts'}), 400
-            if 'value' not in f:
-                return jsonify({'error': f'feature[{i}] missing required field: value'}), 400
+        workload, horizon_seconds, step_seconds, features = parse_request()
 
-        logger.info(f"Prediction request: horizon={horizon_seconds}s, step={step_seconds}s, features={len(features)}")
+        logger.info(f"Prediction request: workload={workload or '-'}, horizon={horizon_seconds}s, step={step_seconds}s, features={len(features)}")
 
-        predictions = forecaster.train_and_predict(features, horizon_seconds, step_seconds)
+        predictions, quantiles = forecaster.predict(workload, features, horizon_seconds, step_seconds)
 
         response = {
             'metric': 'prophet_forecast',
-            'values': predictions
+            'values': predictions,
+            'quantiles': quantiles
         }
 
         logger.info(f"Returning {len(predictions)} predictions")
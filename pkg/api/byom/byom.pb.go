@@ -0,0 +1,538 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11-devel
+// 	protoc        v6.33.1
+// source: byom.proto
+
+package byom
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type InfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InfoRequest) Reset() {
+	*x = InfoRequest{}
+	mi := &file_byom_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfoRequest) ProtoMessage() {}
+
+func (x *InfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_byom_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfoRequest.ProtoReflect.Descriptor instead.
+func (*InfoRequest) Descriptor() ([]byte, []int) {
+	return file_byom_proto_rawDescGZIP(), []int{0}
+}
+
+type InfoResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Protocol is the contract version the service implements, e.g. "v1".
+	Protocol string `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// Name identifies the model behind the service.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Longest supported horizon in seconds; 0 means unbounded.
+	MaxHorizonSeconds int32 `protobuf:"varint,3,opt,name=maxHorizonSeconds,proto3" json:"maxHorizonSeconds,omitempty"`
+	// Supported step sizes in seconds; empty means any step.
+	StepSeconds []int32 `protobuf:"varint,4,rep,packed,name=stepSeconds,proto3" json:"stepSeconds,omitempty"`
+	// Optional capabilities: "quantiles" and "train".
+	Features      []string `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InfoResponse) Reset() {
+	*x = InfoResponse{}
+	mi := &file_byom_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfoResponse) ProtoMessage() {}
+
+func (x *InfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_byom_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfoResponse.ProtoReflect.Descriptor instead.
+func (*InfoResponse) Descriptor() ([]byte, []int) {
+	return file_byom_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *InfoResponse) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *InfoResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InfoResponse) GetMaxHorizonSeconds() int32 {
+	if x != nil {
+		return x.MaxHorizonSeconds
+	}
+	return 0
+}
+
+func (x *InfoResponse) GetStepSeconds() []int32 {
+	if x != nil {
+		return x.StepSeconds
+	}
+	return nil
+}
+
+func (x *InfoResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// ForecastRequest carries the feature window in columnar form.
+type ForecastRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Protocol string                 `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Workload string                 `protobuf:"bytes,2,opt,name=workload,proto3" json:"workload,omitempty"`
+	// Namespace/name of the ForecastPolicy; empty outside operator mode.
+	Policy string `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"`
+	// Current time as Unix seconds.
+	NowUnix        int64 `protobuf:"varint,4,opt,name=nowUnix,proto3" json:"nowUnix,omitempty"`
+	HorizonSeconds int32 `protobuf:"varint,5,opt,name=horizonSeconds,proto3" json:"horizonSeconds,omitempty"`
+	StepSeconds    int32 `protobuf:"varint,6,opt,name=stepSeconds,proto3" json:"stepSeconds,omitempty"`
+	// One column per feature (e.g. "timestamp", "value", "hour"), each with one
+	// value per row in timestamp order. Missing values are NaN.
+	Features      []*Column `protobuf:"bytes,7,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForecastRequest) Reset() {
+	*x = ForecastRequest{}
+	mi := &file_byom_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastRequest) ProtoMessage() {}
+
+func (x *ForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_byom_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastRequest.ProtoReflect.Descriptor instead.
+func (*ForecastRequest) Descriptor() ([]byte, []int) {
+	return file_byom_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ForecastRequest) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *ForecastRequest) GetWorkload() string {
+	if x != nil {
+		return x.Workload
+	}
+	return ""
+}
+
+func (x *ForecastRequest) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *ForecastRequest) GetNowUnix() int64 {
+	if x != nil {
+		return x.NowUnix
+	}
+	return 0
+}
+
+func (x *ForecastRequest) GetHorizonSeconds() int32 {
+	if x != nil {
+		return x.HorizonSeconds
+	}
+	return 0
+}
+
+func (x *ForecastRequest) GetStepSeconds() int32 {
+	if x != nil {
+		return x.StepSeconds
+	}
+	return 0
+}
+
+func (x *ForecastRequest) GetFeatures() []*Column {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type Column struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values        []float64              `protobuf:"fixed64,2,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Column) Reset() {
+	*x = Column{}
+	mi := &file_byom_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Column) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Column) ProtoMessage() {}
+
+func (x *Column) ProtoReflect() protoreflect.Message {
+	mi := &file_byom_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Column.ProtoReflect.Descriptor instead.
+func (*Column) Descriptor() ([]byte, []int) {
+	return file_byom_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Column) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Column) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type TrainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrainResponse) Reset() {
+	*x = TrainResponse{}
+	mi := &file_byom_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrainResponse) ProtoMessage() {}
+
+func (x *TrainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_byom_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrainResponse.ProtoReflect.Descriptor instead.
+func (*TrainResponse) Descriptor() ([]byte, []int) {
+	return file_byom_proto_rawDescGZIP(), []int{4}
+}
+
+type PredictResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Metric string                 `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"`
+	// Point forecast, one value per step: horizonSeconds / stepSeconds values.
+	Values []float64 `protobuf:"fixed64,2,rep,packed,name=values,proto3" json:"values,omitempty"`
+	// Optional quantile forecasts, each the same length as values.
+	Quantiles     []*Quantile `protobuf:"bytes,3,rep,name=quantiles,proto3" json:"quantiles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictResponse) Reset() {
+	*x = PredictResponse{}
+	mi := &file_byom_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictResponse) ProtoMessage() {}
+
+func (x *PredictResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_byom_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictResponse.ProtoReflect.Descriptor instead.
+func (*PredictResponse) Descriptor() ([]byte, []int) {
+	return file_byom_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PredictResponse) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *PredictResponse) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *PredictResponse) GetQuantiles() []*Quantile {
+	if x != nil {
+		return x.Quantiles
+	}
+	return nil
+}
+
+type Quantile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Level in (0, 1), e.g. 0.9.
+	Level         float64   `protobuf:"fixed64,1,opt,name=level,proto3" json:"level,omitempty"`
+	Values        []float64 `protobuf:"fixed64,2,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quantile) Reset() {
+	*x = Quantile{}
+	mi := &file_byom_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quantile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quantile) ProtoMessage() {}
+
+func (x *Quantile) ProtoReflect() protoreflect.Message {
+	mi := &file_byom_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quantile.ProtoReflect.Descriptor instead.
+func (*Quantile) Descriptor() ([]byte, []int) {
+	return file_byom_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Quantile) GetLevel() float64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Quantile) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var File_byom_proto protoreflect.FileDescriptor
+
+const file_byom_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"byom.proto\x12\x04byom\"\r\n" +
+	"\vInfoRequest\"\xaa\x01\n" +
+	"\fInfoResponse\x12\x1a\n" +
+	"\bprotocol\x18\x01 \x01(\tR\bprotocol\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12,\n" +
+	"\x11maxHorizonSeconds\x18\x03 \x01(\x05R\x11maxHorizonSeconds\x12 \n" +
+	"\vstepSeconds\x18\x04 \x03(\x05R\vstepSeconds\x12\x1a\n" +
+	"\bfeatures\x18\x05 \x03(\tR\bfeatures\"\xef\x01\n" +
+	"\x0fForecastRequest\x12\x1a\n" +
+	"\bprotocol\x18\x01 \x01(\tR\bprotocol\x12\x1a\n" +
+	"\bworkload\x18\x02 \x01(\tR\bworkload\x12\x16\n" +
+	"\x06policy\x18\x03 \x01(\tR\x06policy\x12\x18\n" +
+	"\anowUnix\x18\x04 \x01(\x03R\anowUnix\x12&\n" +
+	"\x0ehorizonSeconds\x18\x05 \x01(\x05R\x0ehorizonSeconds\x12 \n" +
+	"\vstepSeconds\x18\x06 \x01(\x05R\vstepSeconds\x12(\n" +
+	"\bfeatures\x18\a \x03(\v2\f.byom.ColumnR\bfeatures\"4\n" +
+	"\x06Column\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\x01R\x06values\"\x0f\n" +
+	"\rTrainResponse\"o\n" +
+	"\x0fPredictResponse\x12\x16\n" +
+	"\x06metric\x18\x01 \x01(\tR\x06metric\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\x01R\x06values\x12,\n" +
+	"\tquantiles\x18\x03 \x03(\v2\x0e.byom.QuantileR\tquantiles\"8\n" +
+	"\bQuantile\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\x01R\x05level\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\x01R\x06values2\xa9\x01\n" +
+	"\x04BYOM\x12/\n" +
+	"\x04Info\x12\x11.byom.InfoRequest\x1a\x12.byom.InfoResponse\"\x00\x125\n" +
+	"\x05Train\x12\x15.byom.ForecastRequest\x1a\x13.byom.TrainResponse\"\x00\x129\n" +
+	"\aPredict\x12\x15.byom.ForecastRequest\x1a\x15.byom.PredictResponse\"\x00B,Z*github.com/HatiCode/kedastral/pkg/api/byomb\x06proto3"
+
+var (
+	file_byom_proto_rawDescOnce sync.Once
+	file_byom_proto_rawDescData []byte
+)
+
+func file_byom_proto_rawDescGZIP() []byte {
+	file_byom_proto_rawDescOnce.Do(func() {
+		file_byom_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_byom_proto_rawDesc), len(file_byom_proto_rawDesc)))
+	})
+	return file_byom_proto_rawDescData
+}
+
+var file_byom_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_byom_proto_goTypes = []any{
+	(*InfoRequest)(nil),     // 0: byom.InfoRequest
+	(*InfoResponse)(nil),    // 1: byom.InfoResponse
+	(*ForecastRequest)(nil), // 2: byom.ForecastRequest
+	(*Column)(nil),          // 3: byom.Column
+	(*TrainResponse)(nil),   // 4: byom.TrainResponse
+	(*PredictResponse)(nil), // 5: byom.PredictResponse
+	(*Quantile)(nil),        // 6: byom.Quantile
+}
+var file_byom_proto_depIdxs = []int32{
+	3, // 0: byom.ForecastRequest.features:type_name -> byom.Column
+	6, // 1: byom.PredictResponse.quantiles:type_name -> byom.Quantile
+	0, // 2: byom.BYOM.Info:input_type -> byom.InfoRequest
+	2, // 3: byom.BYOM.Train:input_type -> byom.ForecastRequest
+	2, // 4: byom.BYOM.Predict:input_type -> byom.ForecastRequest
+	1, // 5: byom.BYOM.Info:output_type -> byom.InfoResponse
+	4, // 6: byom.BYOM.Train:output_type -> byom.TrainResponse
+	5, // 7: byom.BYOM.Predict:output_type -> byom.PredictResponse
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_byom_proto_init() }
+func file_byom_proto_init() {
+	if File_byom_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_byom_proto_rawDesc), len(file_byom_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_byom_proto_goTypes,
+		DependencyIndexes: file_byom_proto_depIdxs,
+		MessageInfos:      file_byom_proto_msgTypes,
+	}.Build()
+	File_byom_proto = out.File
+	file_byom_proto_goTypes = nil
+	file_byom_proto_depIdxs = nil
+}
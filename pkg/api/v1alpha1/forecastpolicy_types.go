@@ -69,8 +69,76 @@ type ModelSpec struct {
 	SARIMA *SARIMAParams `json:"sarima,omitempty"`
 
 	// BYOMURL is the bring-your-own-model service URL. Required when type is byom.
+	// An HTTP(S) URL points at the service's /predict endpoint; byom+grpc://host:port
+	// selects the gRPC transport. Client certificates for mTLS are configured on the
+	// forecaster with --byom-tls-*.
 	// +optional
 	BYOMURL string `json:"byomURL,omitempty"`
+
+	// BYOMAuth sends credentials from a Secret with every request to the BYOM service.
+	// +optional
+	BYOMAuth *BYOMAuthSpec `json:"byomAuth,omitempty"`
+
+	// PersistState saves trained model state to the forecaster's store after each
+	// training pass and restores it when the forecast loop starts, so a restarted or
+	// updated policy predicts immediately. Ignored for byom.
+	// +optional
+	PersistState bool `json:"persistState,omitempty"`
+
+	// Conformal replaces the model's quantiles with intervals calibrated on its recent
+	// out-of-sample errors.
+	// +optional
+	Conformal *ConformalSpec `json:"conformal,omitempty"`
+
+	// Fallback lists models tried in order when the primary model fails to train or
+	// predict, or forecasts implausibly.
+	// +optional
+	Fallback *FallbackSpec `json:"fallback,omitempty"`
+}
+
+// FallbackSpec configures the chain of models used when the primary model fails.
+// Fallback models share the primary's parameters, e.g. model.arima for arima.
+type FallbackSpec struct {
+	// Models are tried in order after the primary model, e.g. [baseline, last-value].
+	// last-value repeats the newest observation and never fails.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=baseline;arima;sarima;byom;last-value
+	Models []string `json:"models"`
+
+	// MaxJump rejects a forecast whose peak exceeds this multiple of the peak observed
+	// in the prediction window. Defaults to 10.
+	// +optional
+	MaxJump float64 `json:"maxJump,omitempty"`
+}
+
+// BYOMAuthSpec references the credentials a BYOM service expects. The Secret is
+// read on every reconcile, so rotated credentials take effect without editing the
+// policy.
+type BYOMAuthSpec struct {
+	// SecretName is the Secret in the policy's namespace holding the credentials.
+	SecretName string `json:"secretName"`
+
+	// TokenKey is the Secret key whose value is sent as "Authorization: Bearer <value>".
+	// +optional
+	TokenKey string `json:"tokenKey,omitempty"`
+
+	// Headers maps request header names to the Secret keys whose values are sent as
+	// those headers, e.g. {"X-Api-Key": "api-key"}.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// ConformalSpec configures conformal prediction intervals around any model.
+type ConformalSpec struct {
+	// Enabled turns conformal calibration on.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Window is the number of recent forecast errors per horizon step used for calibration.
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	Window int `json:"window,omitempty"`
 }
 
 // ForecastSpec controls the forecast horizon and cadence. Durations use Go format
@@ -88,12 +156,122 @@ type ForecastSpec struct {
 	// +kubebuilder:default="30m"
 	// +optional
 	Window string `json:"window,omitempty"`
+	// TrainInterval retrains the model in the background at this cadence, independent
+	// of Interval; predictions use the last successfully trained version. Unset or "0"
+	// trains on every forecast interval.
+	// +optional
+	TrainInterval string `json:"trainInterval,omitempty"`
+	// TrainTimeout bounds a single training pass.
+	// +kubebuilder:default="5s"
+	// +optional
+	TrainTimeout string `json:"trainTimeout,omitempty"`
+}
+
+// ResamplingSpec configures snapping of collected data onto the forecast step grid,
+// for data sources that return irregular or duplicated timestamps.
+type ResamplingSpec struct {
+	// Enabled turns resampling on.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Aggregate is how points on the same grid step are combined: mean, sum, or last.
+	// +kubebuilder:validation:Enum=mean;sum;last
+	// +kubebuilder:default=mean
+	// +optional
+	Aggregate string `json:"aggregate,omitempty"`
+
+	// Fill is how missing grid steps are filled: linear, seasonal, or forward.
+	// +kubebuilder:validation:Enum=linear;seasonal;forward
+	// +kubebuilder:default=linear
+	// +optional
+	Fill string `json:"fill,omitempty"`
+
+	// Season is the season length used by the seasonal fill.
+	// +kubebuilder:default="24h"
+	// +optional
+	Season string `json:"season,omitempty"`
+
+	// MaxGap is the longest gap the resampler fills. A longer gap fails the forecast
+	// tick instead of training on fabricated data.
+	// +kubebuilder:default="15m"
+	// +optional
+	MaxGap string `json:"maxGap,omitempty"`
+}
+
+// CleaningSpec configures outlier and gap cleaning of the training window before the
+// model is trained, so incident spikes and metrics-pipeline dropouts do not pollute it.
+type CleaningSpec struct {
+	// Enabled turns cleaning on.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// OutlierWindow is the Hampel filter half-width in points. 0 disables outlier detection.
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	OutlierWindow int `json:"outlierWindow,omitempty"`
+
+	// OutlierThreshold is how many scaled MADs from the window median a point may deviate
+	// before it is treated as an outlier.
+	// +kubebuilder:default=3
+	// +optional
+	OutlierThreshold float64 `json:"outlierThreshold,omitempty"`
+
+	// Replacement is how outliers are replaced: median, interpolate, or clip.
+	// +kubebuilder:validation:Enum=median;interpolate;clip
+	// +kubebuilder:default=median
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
+
+	// MaxGap is the longest data gap filled by linear interpolation. Longer gaps are
+	// left unfilled. "0" disables gap filling.
+	// +kubebuilder:default="10m"
+	// +optional
+	MaxGap string `json:"maxGap,omitempty"`
 }
 
 // CapacitySpec configures the capacity planner that converts forecasts to replicas.
 type CapacitySpec struct {
-	// TargetPerPod is the target metric value handled by a single pod.
-	TargetPerPod float64 `json:"targetPerPod"`
+	// TargetPerPod is the target metric value handled by a single pod. Required in
+	// throughput mode unless Curve is set, ignored in queue mode.
+	// +optional
+	TargetPerPod float64 `json:"targetPerPod,omitempty"`
+
+	// Curve replaces TargetPerPod with the load sustained at each replica count, for
+	// workloads whose throughput does not grow linearly with replicas, such as those
+	// contending for a shared database. Throughput mode only, without spec.metrics.
+	// +optional
+	Curve *CapacityCurveSpec `json:"curve,omitempty"`
+
+	// TargetRecommendation learns targetPerPod from the per-pod load at which the
+	// workload's latency breaches its SLO, reported in status.targetRecommendation.
+	// +optional
+	TargetRecommendation *TargetRecommendationSpec `json:"targetRecommendation,omitempty"`
+
+	// Mode selects the capacity model: throughput divides the forecast by
+	// TargetPerPod, queue treats it as an arrival rate and sizes replicas to drain
+	// the backlog within queue.drainTime.
+	// +kubebuilder:validation:Enum=throughput;queue
+	// +kubebuilder:default=throughput
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// Queue configures queue mode. Required when Mode is queue.
+	// +optional
+	Queue *QueueSpec `json:"queue,omitempty"`
+
+	// Planner selects how replicas are chosen: deterministic sizes each step for its
+	// forecast, cost picks the path with the lowest expected cost from the quantile
+	// forecast, given cost.
+	// +kubebuilder:validation:Enum=deterministic;cost
+	// +kubebuilder:default=deterministic
+	// +optional
+	Planner string `json:"planner,omitempty"`
+
+	// Cost prices replicas and under-provisioning for the cost planner. Required when
+	// Planner is cost.
+	// +optional
+	Cost *CostSpec `json:"cost,omitempty"`
 
 	// Headroom is a safety multiplier applied when quantiles are unavailable.
 	// +kubebuilder:default=1.2
@@ -122,6 +300,230 @@ type CapacitySpec struct {
 	// +kubebuilder:default=50
 	// +optional
 	DownMaxPercentPerStep int `json:"downMaxPercentPerStep,omitempty"`
+
+	// Stabilization damps replica changes across successive forecasts, like the
+	// behavior policies of a HorizontalPodAutoscaler.
+	// +optional
+	Stabilization *StabilizationSpec `json:"stabilization,omitempty"`
+
+	// Overrides are scheduled rules bounding replicas regardless of the forecast,
+	// applied to each forecast step that falls inside their schedule.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Overrides []ScheduledOverride `json:"overrides,omitempty"`
+
+	// Priority orders this policy against the other members of a ReplicaBudget with
+	// the priority strategy; higher is served first.
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
+	// PodStartupTime is how long a new pod takes to become ready (e.g. 90s). Replicas
+	// are planned this much ahead of the forecast demand.
+	// +optional
+	PodStartupTime string `json:"podStartupTime,omitempty"`
+
+	// Combine is how the pods needed for spec.metric and each of spec.metrics are
+	// combined at every step: max plans for the most demanding metric, weighted for
+	// the weighted sum. Ignored without spec.metrics.
+	// +kubebuilder:validation:Enum=max;weighted
+	// +kubebuilder:default=max
+	// +optional
+	Combine string `json:"combine,omitempty"`
+
+	// Weight of spec.metric when Combine is weighted. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Weight float64 `json:"weight,omitempty"`
+}
+
+// TargetRecommendationSpec configures the targetPerPod recommender, which
+// periodically collects the workload's load, ready replicas, and latency.
+type TargetRecommendationSpec struct {
+	// LatencyDataSourceRef references the DataSource that reports the workload's
+	// latency, such as a p99 in seconds.
+	LatencyDataSourceRef DataSourceRef `json:"latencyDataSourceRef"`
+
+	// ReplicasDataSourceRef references the DataSource that reports the workload's
+	// ready replicas.
+	ReplicasDataSourceRef DataSourceRef `json:"replicasDataSourceRef"`
+
+	// LatencySLO is the latency the workload must stay within, in the unit of the
+	// latency DataSource.
+	// +kubebuilder:validation:Minimum=0
+	LatencySLO float64 `json:"latencySLO"`
+
+	// Lookback is the history the recommender learns from (e.g. 7d).
+	// +kubebuilder:default="7d"
+	// +optional
+	Lookback string `json:"lookback,omitempty"`
+
+	// Interval is how often the recommender runs (e.g. 1h).
+	// +kubebuilder:default="1h"
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// Apply plans with the recommended targetPerPod instead of targetPerPod while its
+	// confidence is at least MinConfidence.
+	// +optional
+	Apply bool `json:"apply,omitempty"`
+
+	// MinConfidence is the confidence (0-1) a recommendation needs to be applied.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1
+	// +kubebuilder:default=0.8
+	// +optional
+	MinConfidence float64 `json:"minConfidence,omitempty"`
+}
+
+// CapacityCurveSpec is the load a workload sustains at each replica count.
+type CapacityCurveSpec struct {
+	// Model is how the curve is built: piecewise interpolates Points linearly from
+	// zero, usl uses the Universal Scalability Law with USL, or fitted to Points
+	// when USL is not set.
+	// +kubebuilder:validation:Enum=piecewise;usl
+	// +kubebuilder:default=piecewise
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// Points are measured loads at increasing replica counts.
+	// +listType=atomic
+	// +optional
+	Points []CurvePointSpec `json:"points,omitempty"`
+
+	// USL sets the Universal Scalability Law parameters directly.
+	// +optional
+	USL *USLSpec `json:"usl,omitempty"`
+}
+
+// CurvePointSpec is the sustainable load measured at a replica count.
+type CurvePointSpec struct {
+	// +kubebuilder:validation:Minimum=1
+	Replicas int `json:"replicas"`
+
+	// +kubebuilder:validation:Minimum=0
+	Load float64 `json:"load"`
+}
+
+// USLSpec holds the Universal Scalability Law parameters: n replicas sustain
+// lambda*n / (1 + sigma*(n-1) + kappa*n*(n-1)).
+type USLSpec struct {
+	// Lambda is the load a single replica sustains.
+	Lambda float64 `json:"lambda"`
+
+	// Sigma is the contention for shared resources (0-1).
+	// +optional
+	Sigma float64 `json:"sigma,omitempty"`
+
+	// Kappa is the coherency cost between replicas.
+	// +optional
+	Kappa float64 `json:"kappa,omitempty"`
+}
+
+// StabilizationSpec keeps forecast jitter from changing replicas on every tick.
+type StabilizationSpec struct {
+	// ScaleDownWindow keeps replicas at the highest recommendation made within this
+	// window (e.g. 5m), so they only decrease once it has passed.
+	// +optional
+	ScaleDownWindow string `json:"scaleDownWindow,omitempty"`
+
+	// ScaleUpHold is the minimum time replicas are held after a scale-up (e.g. 2m).
+	// +optional
+	ScaleUpHold string `json:"scaleUpHold,omitempty"`
+
+	// DeadBandReplicas ignores changes of at most this many replicas.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DeadBandReplicas int `json:"deadBandReplicas,omitempty"`
+
+	// DeadBandPercent ignores changes of at most this percentage of the current
+	// replicas (0-100).
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	DeadBandPercent int `json:"deadBandPercent,omitempty"`
+}
+
+// ScheduledOverride bounds replicas while its schedule is active. When several are
+// active at once, the highest minimum and the lowest maximum apply.
+type ScheduledOverride struct {
+	// Name identifies the override in snapshots and decision explanations.
+	Name string `json:"name"`
+
+	// Schedule is when the override applies: a time window ("Mon-Fri 08:00-10:00"),
+	// a cron expression with a duration ("0 2 * * sun for 3h"), or a fixed interval
+	// ("2026-11-27/2026-12-01").
+	Schedule string `json:"schedule"`
+
+	// Timezone is the IANA time zone Schedule is read in (e.g. Europe/Paris). Defaults to UTC.
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// MinReplicas replaces capacity.minReplicas while active.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReplicas int `json:"minReplicas,omitempty"`
+
+	// MaxReplicas replaces capacity.maxReplicas while active.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxReplicas int `json:"maxReplicas,omitempty"`
+
+	// FreezeScaleDown keeps replicas from decreasing while active.
+	// +optional
+	FreezeScaleDown bool `json:"freezeScaleDown,omitempty"`
+}
+
+// CostSpec prices capacity for the cost planner. Only the ratio of the two costs
+// affects the chosen replicas; absolute values make the backtest cost meaningful.
+type CostSpec struct {
+	// ReplicaHourCost is the cost of running one replica for an hour.
+	// +kubebuilder:validation:Minimum=0
+	ReplicaHourCost float64 `json:"replicaHourCost"`
+
+	// UnderProvisionPenalty is the cost of one pod-hour of demand left unserved.
+	// +kubebuilder:validation:Minimum=0
+	UnderProvisionPenalty float64 `json:"underProvisionPenalty"`
+}
+
+// QueueSpec configures queue-backlog capacity planning for queue workers.
+type QueueSpec struct {
+	// DepthDataSourceRef references the DataSource that reports the current queue depth.
+	DepthDataSourceRef DataSourceRef `json:"depthDataSourceRef"`
+
+	// ServiceRatePerPod is the number of items a single pod processes per second.
+	// +kubebuilder:validation:Minimum=0
+	ServiceRatePerPod float64 `json:"serviceRatePerPod"`
+
+	// DrainTime is how quickly a backlog should be worked off (e.g. 5m).
+	// +kubebuilder:default="5m"
+	// +optional
+	DrainTime string `json:"drainTime,omitempty"`
+}
+
+// MetricSpec is an additional metric that bounds the workload's capacity, forecast
+// from its own DataSource and planned against its own per-pod target.
+type MetricSpec struct {
+	// Name is the metric name used in logs, snapshots, and the binding metric.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+	Name string `json:"name"`
+
+	// DataSourceRef references the DataSource to collect this metric from.
+	DataSourceRef DataSourceRef `json:"dataSourceRef"`
+
+	// Model is the forecasting model for this metric. Defaults to spec.model.type;
+	// parameters such as spec.model.arima are shared.
+	// +kubebuilder:validation:Enum=baseline;arima;sarima;byom
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// TargetPerPod is the value of this metric a single pod can sustain.
+	TargetPerPod float64 `json:"targetPerPod"`
+
+	// Weight of this metric when capacity.combine is weighted. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Weight float64 `json:"weight,omitempty"`
 }
 
 // ForecastPolicySpec defines the desired forecasting and scaling behavior for a workload.
@@ -141,13 +543,117 @@ type ForecastPolicySpec struct {
 	// +optional
 	Forecast ForecastSpec `json:"forecast,omitempty"`
 
+	// Cleaning replaces outliers and fills short gaps in the training window before
+	// the model is trained.
+	// +optional
+	Cleaning *CleaningSpec `json:"cleaning,omitempty"`
+
+	// Resampling snaps collected data onto the step grid before cleaning and training.
+	// +optional
+	Resampling *ResamplingSpec `json:"resampling,omitempty"`
+
 	Capacity CapacitySpec `json:"capacity"`
 
+	// Metrics are additional metrics planned alongside Metric. Replicas are computed
+	// per metric and combined per capacity.combine; snapshots record which metric
+	// was binding at each step.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Metrics []MetricSpec `json:"metrics,omitempty"`
+
 	// LeadTime is how far ahead the scaler looks for proactive scale-up.
 	// Passed to the generated ScaledObject trigger metadata.
 	// +kubebuilder:default="10m"
 	// +optional
 	LeadTime string `json:"leadTime,omitempty"`
+
+	// Activation lets the scaler deactivate the workload while no load is predicted,
+	// so KEDA scales it to zero when capacity.minReplicas is 0. It is activated again
+	// capacity.podStartupTime ahead of the predicted load.
+	// +optional
+	Activation *ActivationSpec `json:"activation,omitempty"`
+
+	// Reactive makes the scaler also query the live metric and return the replicas
+	// it needs, at capacity.targetPerPod, when they exceed the forecast replicas.
+	// +optional
+	Reactive *ReactiveSpec `json:"reactive,omitempty"`
+
+	// ScalerFallback sets the replicas the scaler serves while the forecaster is
+	// unreachable or its forecast is stale, and the generated ScaledObject's
+	// fallback for when the scaler itself fails. Unlike model.fallback, it applies
+	// when no forecast is available at all.
+	// +optional
+	ScalerFallback *ScalerFallbackSpec `json:"scalerFallback,omitempty"`
+}
+
+// ScalerFallbackSpec configures the replicas served without a fresh forecast.
+type ScalerFallbackSpec struct {
+	// Mode is hold to keep the last replicas selected from a fresh forecast, decay
+	// to lower them linearly to capacity.minReplicas over decayTime, or fixed to
+	// serve replicas.
+	// +kubebuilder:validation:Enum=hold;decay;fixed
+	Mode string `json:"mode"`
+
+	// Replicas are served in fixed mode, and in the other modes until the scaler has
+	// seen a fresh forecast. They are also the ScaledObject's fallback replicas,
+	// which default to capacity.minReplicas. Required in fixed mode.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int `json:"replicas,omitempty"`
+
+	// DecayTime is how long decay mode takes to reach capacity.minReplicas.
+	// +kubebuilder:default="30m"
+	// +optional
+	DecayTime string `json:"decayTime,omitempty"`
+
+	// FailureThreshold is the number of consecutive scaler failures after which
+	// KEDA applies the ScaledObject's fallback replicas.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +optional
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+}
+
+// ReactiveSpec configures hybrid reactive and predictive scaling, passed to the
+// generated ScaledObject trigger metadata.
+type ReactiveSpec struct {
+	// DataSourceRef references the DataSource the live metric is queried from.
+	// Defaults to spec.dataSourceRef.
+	// +optional
+	DataSourceRef *DataSourceRef `json:"dataSourceRef,omitempty"`
+
+	// CorrectionSigma, when positive, also shifts the forecast over the lead window
+	// up by the gap between the live metric and the current forecast once that gap
+	// exceeds this many standard deviations of the forecast error. The shift only
+	// sizes the replicas the scaler returns; the stored forecast is unchanged until
+	// the next tick collects the spike. 0 disables it.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CorrectionSigma float64 `json:"correctionSigma,omitempty"`
+}
+
+// ActivationSpec configures forecast-driven activation, passed to the generated
+// ScaledObject trigger metadata.
+type ActivationSpec struct {
+	// Threshold is the forecast value at or above which the workload is activated.
+	// The workload is inactive while the forecast stays below it for the whole lead
+	// window.
+	// +kubebuilder:validation:Minimum=0
+	Threshold float64 `json:"threshold"`
+
+	// DeactivationThreshold is the forecast value below which an active workload is
+	// deactivated. Defaults to Threshold; a lower value keeps a workload whose
+	// forecast hovers around Threshold from flapping.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DeactivationThreshold float64 `json:"deactivationThreshold,omitempty"`
+
+	// Quantile compares a quantile forecast (e.g. p90) with the thresholds instead of
+	// the point forecast, activating for less likely traffic too. The point forecast
+	// is used when the model produces no such quantile.
+	// +optional
+	Quantile string `json:"quantile,omitempty"`
 }
 
 // ForecastPolicyStatus reports the observed state of a ForecastPolicy.
@@ -172,6 +678,10 @@ type ForecastPolicyStatus struct {
 	// +optional
 	ScaledObjectName string `json:"scaledObjectName,omitempty"`
 
+	// TargetRecommendation is the latest targetPerPod learned by the recommender.
+	// +optional
+	TargetRecommendation *TargetRecommendationStatus `json:"targetRecommendation,omitempty"`
+
 	// Conditions represent the latest observations of the policy state.
 	// +optional
 	// +listType=map
@@ -179,6 +689,29 @@ type ForecastPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// TargetRecommendationStatus is a targetPerPod learned from observed latency.
+type TargetRecommendationStatus struct {
+	// TargetPerPod is the highest per-pod load observed to meet the latency SLO.
+	TargetPerPod float64 `json:"targetPerPod"`
+
+	// Confidence, from 0 to 1, grows with the samples observed and with how clearly
+	// latency breaches the SLO above TargetPerPod but not below it.
+	Confidence float64 `json:"confidence"`
+
+	// Samples is the number of observations the recommendation was learned from.
+	Samples int `json:"samples"`
+
+	// BreachObserved is false when the workload never reached a load breaching the
+	// SLO, so TargetPerPod is only a lower bound.
+	BreachObserved bool `json:"breachObserved"`
+
+	// Applied reports whether the forecaster plans with TargetPerPod.
+	Applied bool `json:"applied"`
+
+	// ComputedAt is when the recommendation was learned.
+	ComputedAt metav1.Time `json:"computedAt"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=fp
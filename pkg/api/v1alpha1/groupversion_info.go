@@ -1,9 +1,10 @@
 // Package v1alpha1 contains the Kedastral API types for the kedastral.io group.
 //
-// It defines the ForecastPolicy and DataSource custom resources reconciled by the
-// forecaster's embedded controller. ForecastPolicy describes a workload to forecast
-// and scale; DataSource describes a metrics backend (Prometheus, VictoriaMetrics, HTTP)
-// that policies reference.
+// It defines the ForecastPolicy, DataSource, and ReplicaBudget custom resources
+// reconciled by the forecaster's embedded controller. ForecastPolicy describes a
+// workload to forecast and scale; DataSource describes a metrics backend (Prometheus,
+// VictoriaMetrics, HTTP) that policies reference; ReplicaBudget caps the replicas a
+// set of policies plan together.
 //
 // +kubebuilder:object:generate=true
 // +groupName=kedastral.io
@@ -27,5 +28,6 @@ func init() {
 	SchemeBuilder.Register(
 		&ForecastPolicy{}, &ForecastPolicyList{},
 		&DataSource{}, &DataSourceList{},
+		&ReplicaBudget{}, &ReplicaBudgetList{},
 	)
 }
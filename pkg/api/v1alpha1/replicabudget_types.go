@@ -0,0 +1,102 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReplicaBudgetSpec caps the replicas that a set of ForecastPolicies may plan
+// together at every forecast step.
+type ReplicaBudgetSpec struct {
+	// MaxReplicas is the most replicas the member policies may plan in total at any
+	// step.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int `json:"maxReplicas"`
+
+	// Namespaces limits the budget to ForecastPolicies in these namespaces. Empty
+	// covers every namespace.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Selector limits the budget to ForecastPolicies whose labels match. Empty
+	// selects every policy in Namespaces.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Strategy is how plans are scaled back when they exceed MaxReplicas:
+	// proportional scales every member back by the same fraction, priority serves
+	// members in descending capacity.priority. Members always keep their
+	// minReplicas.
+	// +kubebuilder:validation:Enum=proportional;priority
+	// +kubebuilder:default=proportional
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+}
+
+// ReplicaBudgetStatus reports the observed state of a ReplicaBudget.
+type ReplicaBudgetStatus struct {
+	// ObservedGeneration is the generation last processed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Members lists the member ForecastPolicies as namespace/name.
+	// +optional
+	Members []string `json:"members,omitempty"`
+
+	// StartTime and StepSeconds place the per-step fields below on a time grid: the
+	// finest step of the members, from the latest reconcile.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +optional
+	StepSeconds int `json:"stepSeconds,omitempty"`
+
+	// RequestedReplicas is the replicas the members planned in total at each step,
+	// and AllocatedReplicas what the budget allowed them.
+	// +optional
+	RequestedReplicas []int `json:"requestedReplicas,omitempty"`
+	// +optional
+	AllocatedReplicas []int `json:"allocatedReplicas,omitempty"`
+
+	// UtilizationPercent is AllocatedReplicas as a percentage of MaxReplicas at each
+	// step, and PeakUtilizationPercent the highest of them.
+	// +optional
+	UtilizationPercent []int `json:"utilizationPercent,omitempty"`
+	// +optional
+	PeakUtilizationPercent int `json:"peakUtilizationPercent,omitempty"`
+
+	// ConstrainedSteps is the number of steps at which the members were scaled back.
+	// +optional
+	ConstrainedSteps int `json:"constrainedSteps,omitempty"`
+
+	// Conditions represent the latest observations of the budget state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=rb
+// +kubebuilder:printcolumn:name="Max",type=integer,JSONPath=`.spec.maxReplicas`
+// +kubebuilder:printcolumn:name="Strategy",type=string,JSONPath=`.spec.strategy`
+// +kubebuilder:printcolumn:name="Peak %",type=integer,JSONPath=`.status.peakUtilizationPercent`
+// +kubebuilder:printcolumn:name="Constrained",type=integer,JSONPath=`.status.constrainedSteps`
+
+// ReplicaBudget caps the replicas planned together by the ForecastPolicies it
+// selects, so that synchronised peaks fit the cluster or cloud quota.
+type ReplicaBudget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReplicaBudgetSpec   `json:"spec,omitempty"`
+	Status ReplicaBudgetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReplicaBudgetList contains a list of ReplicaBudget.
+type ReplicaBudgetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReplicaBudget `json:"items"`
+}
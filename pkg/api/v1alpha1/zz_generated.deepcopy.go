@@ -27,9 +27,101 @@ func (in *ARIMAParams) DeepCopy() *ARIMAParams {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActivationSpec) DeepCopyInto(out *ActivationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActivationSpec.
+func (in *ActivationSpec) DeepCopy() *ActivationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActivationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BYOMAuthSpec) DeepCopyInto(out *BYOMAuthSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BYOMAuthSpec.
+func (in *BYOMAuthSpec) DeepCopy() *BYOMAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BYOMAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityCurveSpec) DeepCopyInto(out *CapacityCurveSpec) {
+	*out = *in
+	if in.Points != nil {
+		in, out := &in.Points, &out.Points
+		*out = make([]CurvePointSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.USL != nil {
+		in, out := &in.USL, &out.USL
+		*out = new(USLSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityCurveSpec.
+func (in *CapacityCurveSpec) DeepCopy() *CapacityCurveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityCurveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CapacitySpec) DeepCopyInto(out *CapacitySpec) {
 	*out = *in
+	if in.Curve != nil {
+		in, out := &in.Curve, &out.Curve
+		*out = new(CapacityCurveSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetRecommendation != nil {
+		in, out := &in.TargetRecommendation, &out.TargetRecommendation
+		*out = new(TargetRecommendationSpec)
+		**out = **in
+	}
+	if in.Queue != nil {
+		in, out := &in.Queue, &out.Queue
+		*out = new(QueueSpec)
+		**out = **in
+	}
+	if in.Cost != nil {
+		in, out := &in.Cost, &out.Cost
+		*out = new(CostSpec)
+		**out = **in
+	}
+	if in.Stabilization != nil {
+		in, out := &in.Stabilization, &out.Stabilization
+		*out = new(StabilizationSpec)
+		**out = **in
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]ScheduledOverride, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacitySpec.
@@ -42,6 +134,66 @@ func (in *CapacitySpec) DeepCopy() *CapacitySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleaningSpec) DeepCopyInto(out *CleaningSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleaningSpec.
+func (in *CleaningSpec) DeepCopy() *CleaningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CleaningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConformalSpec) DeepCopyInto(out *ConformalSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConformalSpec.
+func (in *ConformalSpec) DeepCopy() *ConformalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConformalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostSpec) DeepCopyInto(out *CostSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostSpec.
+func (in *CostSpec) DeepCopy() *CostSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CostSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CurvePointSpec) DeepCopyInto(out *CurvePointSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CurvePointSpec.
+func (in *CurvePointSpec) DeepCopy() *CurvePointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CurvePointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataSource) DeepCopyInto(out *DataSource) {
 	*out = *in
@@ -160,6 +312,26 @@ func (in *DataSourceStatus) DeepCopy() *DataSourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FallbackSpec) DeepCopyInto(out *FallbackSpec) {
+	*out = *in
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FallbackSpec.
+func (in *FallbackSpec) DeepCopy() *FallbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FallbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForecastPolicy) DeepCopyInto(out *ForecastPolicy) {
 	*out = *in
@@ -226,7 +398,37 @@ func (in *ForecastPolicySpec) DeepCopyInto(out *ForecastPolicySpec) {
 	out.DataSourceRef = in.DataSourceRef
 	in.Model.DeepCopyInto(&out.Model)
 	out.Forecast = in.Forecast
-	out.Capacity = in.Capacity
+	if in.Cleaning != nil {
+		in, out := &in.Cleaning, &out.Cleaning
+		*out = new(CleaningSpec)
+		**out = **in
+	}
+	if in.Resampling != nil {
+		in, out := &in.Resampling, &out.Resampling
+		*out = new(ResamplingSpec)
+		**out = **in
+	}
+	in.Capacity.DeepCopyInto(&out.Capacity)
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]MetricSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Activation != nil {
+		in, out := &in.Activation, &out.Activation
+		*out = new(ActivationSpec)
+		**out = **in
+	}
+	if in.Reactive != nil {
+		in, out := &in.Reactive, &out.Reactive
+		*out = new(ReactiveSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScalerFallback != nil {
+		in, out := &in.ScalerFallback, &out.ScalerFallback
+		*out = new(ScalerFallbackSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForecastPolicySpec.
@@ -246,6 +448,11 @@ func (in *ForecastPolicyStatus) DeepCopyInto(out *ForecastPolicyStatus) {
 		in, out := &in.LastForecastTime, &out.LastForecastTime
 		*out = (*in).DeepCopy()
 	}
+	if in.TargetRecommendation != nil {
+		in, out := &in.TargetRecommendation, &out.TargetRecommendation
+		*out = new(TargetRecommendationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -280,6 +487,22 @@ func (in *ForecastSpec) DeepCopy() *ForecastSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
+	*out = *in
+	out.DataSourceRef = in.DataSourceRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSpec.
+func (in *MetricSpec) DeepCopy() *MetricSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
 	*out = *in
@@ -293,6 +516,21 @@ func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
 		*out = new(SARIMAParams)
 		**out = **in
 	}
+	if in.BYOMAuth != nil {
+		in, out := &in.BYOMAuth, &out.BYOMAuth
+		*out = new(BYOMAuthSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conformal != nil {
+		in, out := &in.Conformal, &out.Conformal
+		*out = new(ConformalSpec)
+		**out = **in
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(FallbackSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
@@ -305,6 +543,187 @@ func (in *ModelSpec) DeepCopy() *ModelSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueueSpec) DeepCopyInto(out *QueueSpec) {
+	*out = *in
+	out.DepthDataSourceRef = in.DepthDataSourceRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueueSpec.
+func (in *QueueSpec) DeepCopy() *QueueSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QueueSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReactiveSpec) DeepCopyInto(out *ReactiveSpec) {
+	*out = *in
+	if in.DataSourceRef != nil {
+		in, out := &in.DataSourceRef, &out.DataSourceRef
+		*out = new(DataSourceRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReactiveSpec.
+func (in *ReactiveSpec) DeepCopy() *ReactiveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReactiveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaBudget) DeepCopyInto(out *ReplicaBudget) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaBudget.
+func (in *ReplicaBudget) DeepCopy() *ReplicaBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplicaBudget) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaBudgetList) DeepCopyInto(out *ReplicaBudgetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReplicaBudget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaBudgetList.
+func (in *ReplicaBudgetList) DeepCopy() *ReplicaBudgetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaBudgetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplicaBudgetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaBudgetSpec) DeepCopyInto(out *ReplicaBudgetSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaBudgetSpec.
+func (in *ReplicaBudgetSpec) DeepCopy() *ReplicaBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaBudgetStatus) DeepCopyInto(out *ReplicaBudgetStatus) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RequestedReplicas != nil {
+		in, out := &in.RequestedReplicas, &out.RequestedReplicas
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllocatedReplicas != nil {
+		in, out := &in.AllocatedReplicas, &out.AllocatedReplicas
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.UtilizationPercent != nil {
+		in, out := &in.UtilizationPercent, &out.UtilizationPercent
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaBudgetStatus.
+func (in *ReplicaBudgetStatus) DeepCopy() *ReplicaBudgetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaBudgetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResamplingSpec) DeepCopyInto(out *ResamplingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResamplingSpec.
+func (in *ResamplingSpec) DeepCopy() *ResamplingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResamplingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SARIMAParams) DeepCopyInto(out *SARIMAParams) {
 	*out = *in
@@ -334,3 +753,101 @@ func (in *ScaleTargetRef) DeepCopy() *ScaleTargetRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalerFallbackSpec) DeepCopyInto(out *ScalerFallbackSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerFallbackSpec.
+func (in *ScalerFallbackSpec) DeepCopy() *ScalerFallbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalerFallbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledOverride) DeepCopyInto(out *ScheduledOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledOverride.
+func (in *ScheduledOverride) DeepCopy() *ScheduledOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StabilizationSpec) DeepCopyInto(out *StabilizationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StabilizationSpec.
+func (in *StabilizationSpec) DeepCopy() *StabilizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StabilizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetRecommendationSpec) DeepCopyInto(out *TargetRecommendationSpec) {
+	*out = *in
+	out.LatencyDataSourceRef = in.LatencyDataSourceRef
+	out.ReplicasDataSourceRef = in.ReplicasDataSourceRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetRecommendationSpec.
+func (in *TargetRecommendationSpec) DeepCopy() *TargetRecommendationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetRecommendationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetRecommendationStatus) DeepCopyInto(out *TargetRecommendationStatus) {
+	*out = *in
+	in.ComputedAt.DeepCopyInto(&out.ComputedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetRecommendationStatus.
+func (in *TargetRecommendationStatus) DeepCopy() *TargetRecommendationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetRecommendationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *USLSpec) DeepCopyInto(out *USLSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new USLSpec.
+func (in *USLSpec) DeepCopy() *USLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(USLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -41,8 +41,26 @@ type Report struct {
 	MAPE                 float64 `json:"mape"`
 	UnderProvisionedRate float64 `json:"underProvisionedRate"`
 	MeanOverProvision    float64 `json:"meanOverProvision"`
+
+	// Planners compares the realised cost of the deterministic and cost planners on
+	// the same forecasts. Set when the policy has a ReplicaHourCost or
+	// UnderProvisionPenalty.
+	Planners []PlannerCost `json:"planners,omitempty"`
+}
+
+// PlannerCost is the realised cost of one capacity planner's replicas against the
+// actual load, priced with the policy's ReplicaHourCost and UnderProvisionPenalty.
+type PlannerCost struct {
+	Planner              string  `json:"planner"`
+	ReplicaHours         float64 `json:"replicaHours"`
+	ShortfallPodHours    float64 `json:"shortfallPodHours"`
+	UnderProvisionedRate float64 `json:"underProvisionedRate"`
+	Cost                 float64 `json:"cost"`
 }
 
+// comparedPlanners are the planners priced in Report.Planners.
+var comparedPlanners = []string{"deterministic", "cost"}
+
 // Run performs a walk-forward backtest of cfg over the series.
 func Run(ctx context.Context, series Series, cfg Config) (Report, error) {
 	if cfg.NewModel == nil {
@@ -61,6 +79,7 @@ func Run(ctx context.Context, series Series, cfg Config) (Report, error) {
 	stepSec := int(cfg.Step.Seconds())
 	windowSteps := int(cfg.Window / cfg.Step)
 	horizonSteps := int(cfg.Horizon / cfg.Step)
+	startupSteps := int(math.Ceil(float64(cfg.Policy.PodStartupSeconds) / float64(stepSec)))
 	strideSteps := int(cfg.Stride / cfg.Step)
 	if strideSteps < 1 {
 		strideSteps = 1
@@ -77,6 +96,16 @@ func Run(ctx context.Context, series Series, cfg Config) (Report, error) {
 	var planned, needed []int
 	prevReplicas := cfg.Policy.MinReplicas
 
+	compare := cfg.Policy.ReplicaHourCost > 0 || cfg.Policy.UnderProvisionPenalty > 0
+	var costs []plannerRun
+	if compare {
+		for _, planner := range comparedPlanners {
+			policy := cfg.Policy
+			policy.Planner = planner
+			costs = append(costs, plannerRun{policy: policy, prev: cfg.Policy.MinReplicas})
+		}
+	}
+
 	for i := windowSteps; i+horizonSteps <= series.Len(); i += strideSteps {
 		frame, err := builder.BuildFeatures(historyDataFrame(series, i-windowSteps, i))
 		if err != nil {
@@ -98,12 +127,13 @@ func Run(ctx context.Context, series Series, cfg Config) (Report, error) {
 		count := min(len(forecast.Values), horizonSteps)
 
 		replicas := capacity.ToReplicas(prevReplicas, forecast.Values, stepSec, cfg.Policy, forecast.Quantiles)
+		ready := readyReplicas(prevReplicas, replicas, startupSteps)
 
 		for k := 0; k < count; k++ {
 			predicted = append(predicted, forecast.Values[k])
 			actual = append(actual, future[k])
-			if k < len(replicas) {
-				planned = append(planned, replicas[k])
+			if k < len(ready) {
+				planned = append(planned, ready[k])
 				needed = append(needed, neededReplicas(future[k], cfg.Policy))
 			}
 		}
@@ -111,6 +141,9 @@ func Run(ctx context.Context, series Series, cfg Config) (Report, error) {
 		if len(replicas) > 0 {
 			prevReplicas = replicas[0]
 		}
+		for k := range costs {
+			costs[k].plan(forecast, future[:count], stepSec, startupSteps)
+		}
 		report.Windows++
 	}
 
@@ -124,24 +157,82 @@ func Run(ctx context.Context, series Series, cfg Config) (Report, error) {
 	report.MAPE = MAPE(predicted, actual)
 	report.UnderProvisionedRate = UnderProvisionedRate(planned, needed)
 	report.MeanOverProvision = MeanOverProvision(planned, needed)
+	for _, run := range costs {
+		report.Planners = append(report.Planners, run.report(stepSec))
+	}
 
 	return report, nil
 }
 
-// neededReplicas is the bare replica requirement for an actual load value, floored at
-// MinReplicas. Headroom is intentionally excluded: it is the planner's safety margin,
-// so over-provisioning it causes is what MeanOverProvision is meant to surface.
+// neededReplicas is the bare replica requirement for an actual load value, from the
+// policy's target per pod or capacity curve, floored at MinReplicas. Headroom is
+// intentionally excluded: it is the planner's safety margin, so over-provisioning it
+// causes is what MeanOverProvision is meant to surface.
 func neededReplicas(value float64, policy capacity.Policy) int {
-	required := 0
-	if policy.TargetPerPod > 0 {
-		required = int(math.Ceil(value / policy.TargetPerPod))
-	}
+	required := int(math.Ceil(policy.Pods(value)))
 	if required < policy.MinReplicas {
 		required = policy.MinReplicas
 	}
 	return required
 }
 
+// readyReplicas models pod startup latency: replicas requested at a step only serve
+// load startupSteps later, while removed replicas stop serving at once. A replica
+// therefore counts at step k only if it was planned at every step since k-startupSteps,
+// with prev running and ready before the plan starts.
+func readyReplicas(prev int, replicas []int, startupSteps int) []int {
+	if startupSteps <= 0 {
+		return replicas
+	}
+	ready := make([]int, len(replicas))
+	for k := range replicas {
+		ready[k] = replicas[k]
+		if k < startupSteps {
+			ready[k] = min(ready[k], prev)
+		}
+		for j := max(0, k-startupSteps); j < k; j++ {
+			ready[k] = min(ready[k], replicas[j])
+		}
+	}
+	return ready
+}
+
+// plannerRun accumulates one planner's replicas and the actual load they served.
+type plannerRun struct {
+	policy  capacity.Policy
+	prev    int
+	planned []int
+	actual  []float64
+}
+
+func (r *plannerRun) plan(forecast models.Forecast, future []float64, stepSec, startupSteps int) {
+	replicas := capacity.ToReplicas(r.prev, forecast.Values, stepSec, r.policy, forecast.Quantiles)
+	ready := readyReplicas(r.prev, replicas, startupSteps)
+	for k := 0; k < len(future) && k < len(ready); k++ {
+		r.planned = append(r.planned, ready[k])
+		r.actual = append(r.actual, future[k])
+	}
+	if len(replicas) > 0 {
+		r.prev = replicas[0]
+	}
+}
+
+// report prices the accumulated plan. The shortfall is the fractional pods the
+// actual load needed beyond those planned, matching what the cost planner minimises.
+func (r *plannerRun) report(stepSec int) PlannerCost {
+	stepHours := float64(stepSec) / 3600
+	result := PlannerCost{Planner: r.policy.Planner}
+	needed := make([]int, len(r.planned))
+	for i, replicas := range r.planned {
+		result.ReplicaHours += float64(replicas) * stepHours
+		result.ShortfallPodHours += max(r.policy.Pods(r.actual[i])-float64(replicas), 0) * stepHours
+		needed[i] = neededReplicas(r.actual[i], r.policy)
+	}
+	result.UnderProvisionedRate = UnderProvisionedRate(r.planned, needed)
+	result.Cost = result.ReplicaHours*r.policy.ReplicaHourCost + result.ShortfallPodHours*r.policy.UnderProvisionPenalty
+	return result
+}
+
 func historyDataFrame(series Series, from, to int) adapters.DataFrame {
 	rows := make([]adapters.Row, 0, to-from)
 	for i := from; i < to; i++ {
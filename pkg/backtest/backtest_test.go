@@ -3,6 +3,7 @@ package backtest
 import (
 	"context"
 	"math"
+	"reflect"
 	"testing"
 	"time"
 
@@ -93,3 +94,84 @@ func TestRun_SeriesTooShort(t *testing.T) {
 		t.Error("expected error for too-short series")
 	}
 }
+
+func TestRun_PlannerCosts(t *testing.T) {
+	series := syntheticSeries(180, time.Minute)
+
+	report, err := Run(context.Background(), series, baselineConfig())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(report.Planners) != 0 {
+		t.Errorf("Planners = %v, want none without costs", report.Planners)
+	}
+
+	cfg := baselineConfig()
+	cfg.Policy.ReplicaHourCost = 1
+	cfg.Policy.UnderProvisionPenalty = 5
+	report, err = Run(context.Background(), series, cfg)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(report.Planners) != 2 || report.Planners[0].Planner != "deterministic" || report.Planners[1].Planner != "cost" {
+		t.Fatalf("Planners = %+v, want deterministic and cost", report.Planners)
+	}
+	for _, planner := range report.Planners {
+		if planner.ReplicaHours <= 0 || planner.Cost <= 0 || math.IsNaN(planner.Cost) {
+			t.Errorf("%s: replica-hours %v, cost %v", planner.Planner, planner.ReplicaHours, planner.Cost)
+		}
+		want := planner.ReplicaHours*1 + planner.ShortfallPodHours*5
+		if math.Abs(planner.Cost-want) > 1e-9 {
+			t.Errorf("%s: cost = %v, want %v", planner.Planner, planner.Cost, want)
+		}
+	}
+}
+
+func TestReadyReplicas(t *testing.T) {
+	tests := []struct {
+		name     string
+		prev     int
+		replicas []int
+		startup  int
+		want     []int
+	}{
+		{"no startup", 2, []int{4, 4, 1}, 0, []int{4, 4, 1}},
+		{"scale up is delayed", 2, []int{4, 4, 4, 4}, 2, []int{2, 2, 4, 4}},
+		{"scale down is immediate", 4, []int{4, 1, 4, 4}, 1, []int{4, 1, 1, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := readyReplicas(tt.prev, tt.replicas, tt.startup)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readyReplicas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_PodStartupLatency(t *testing.T) {
+	series := syntheticSeries(180, time.Minute)
+
+	cfg := baselineConfig()
+	cfg.Policy.PodStartupSeconds = 300
+	report, err := Run(context.Background(), series, cfg)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Points == 0 || report.UnderProvisionedRate < 0 || report.UnderProvisionedRate > 1 {
+		t.Errorf("points = %d, under-provisioned rate = %v", report.Points, report.UnderProvisionedRate)
+	}
+}
+
+func TestNeededReplicas_Curve(t *testing.T) {
+	policy := capacity.Policy{TargetPerPod: 50, MinReplicas: 1}
+	if got := neededReplicas(160, policy); got != 4 {
+		t.Errorf("neededReplicas(linear) = %d, want 4", got)
+	}
+
+	// Two replicas sustain 100 and four 150; beyond, each adds 25: 160 needs 4.4.
+	policy.Curve = &capacity.Curve{Points: []capacity.CurvePoint{{Replicas: 2, Load: 100}, {Replicas: 4, Load: 150}}}
+	if got := neededReplicas(160, policy); got != 5 {
+		t.Errorf("neededReplicas(curve) = %d, want 5", got)
+	}
+}
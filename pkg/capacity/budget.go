@@ -0,0 +1,115 @@
+package capacity
+
+import (
+	"slices"
+)
+
+// Strategies for AllocateBudget.
+const (
+	// BudgetPriority serves claims in descending priority, sharing what is left
+	// proportionally among claims of equal priority.
+	BudgetPriority = "priority"
+	// BudgetProportional scales every claim back by the same fraction.
+	BudgetProportional = "proportional"
+)
+
+// BudgetClaim is one workload's replicas at one step of a shared replica budget.
+type BudgetClaim struct {
+	// Request is the replicas the workload planned.
+	Request int
+
+	// Floor is the replicas granted regardless of the budget, normally the step's
+	// minimum (see StepMinimums). Capped at Request.
+	Floor int
+
+	// Priority orders claims under BudgetPriority; higher is served first.
+	Priority int
+}
+
+// AllocateBudget shares budget replicas between claims. Every claim gets its floor,
+// even when the floors alone exceed the budget; when the requests fit, every claim
+// gets its request. Otherwise the replicas left after the floors are shared by
+// strategy, BudgetProportional by default. Shares are rounded down and the leftover
+// replicas go one by one to the largest remainders, so the allocations sum to the
+// budget.
+func AllocateBudget(budget int, claims []BudgetClaim, strategy string) []int {
+	alloc := make([]int, len(claims))
+	extra := make([]int, len(claims))
+	remaining, wanted := budget, 0
+	for i, c := range claims {
+		request := max(c.Request, 0)
+		alloc[i] = min(max(c.Floor, 0), request)
+		extra[i] = request - alloc[i]
+		remaining -= alloc[i]
+		wanted += extra[i]
+	}
+	if remaining <= 0 {
+		return alloc
+	}
+	if wanted <= remaining {
+		for i := range alloc {
+			alloc[i] += extra[i]
+		}
+		return alloc
+	}
+
+	if strategy != BudgetPriority {
+		share(alloc, extra, allIndexes(len(claims)), remaining)
+		return alloc
+	}
+
+	// Serve tiers of equal priority in descending order.
+	order := allIndexes(len(claims))
+	slices.SortStableFunc(order, func(a, b int) int { return claims[b].Priority - claims[a].Priority })
+	for start := 0; start < len(order) && remaining > 0; {
+		end := start
+		tierWanted := 0
+		for end < len(order) && claims[order[end]].Priority == claims[order[start]].Priority {
+			tierWanted += extra[order[end]]
+			end++
+		}
+		tier := order[start:end]
+		if tierWanted <= remaining {
+			for _, i := range tier {
+				alloc[i] += extra[i]
+			}
+			remaining -= tierWanted
+		} else {
+			share(alloc, extra, tier, remaining)
+			remaining = 0
+		}
+		start = end
+	}
+	return alloc
+}
+
+// share adds amount replicas to the claims at indexes, in proportion to their extra
+// replicas wanted, which must exceed amount in total.
+func share(alloc, extra, indexes []int, amount int) {
+	total := 0
+	for _, i := range indexes {
+		total += extra[i]
+	}
+	remainders := make([]int, len(indexes))
+	given := 0
+	for k, i := range indexes {
+		granted := extra[i] * amount / total
+		alloc[i] += granted
+		given += granted
+		remainders[k] = extra[i] * amount % total
+	}
+
+	byRemainder := allIndexes(len(indexes))
+	slices.SortStableFunc(byRemainder, func(a, b int) int { return remainders[b] - remainders[a] })
+	for _, k := range byRemainder[:amount-given] {
+		alloc[indexes[k]]++
+	}
+}
+
+func allIndexes(n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return indexes
+}
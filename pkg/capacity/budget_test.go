@@ -0,0 +1,70 @@
+package capacity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAllocateBudget(t *testing.T) {
+	claims := []BudgetClaim{
+		{Request: 30, Floor: 2, Priority: 10},
+		{Request: 20, Floor: 2, Priority: 0},
+		{Request: 10, Floor: 2, Priority: 0},
+	}
+
+	tests := []struct {
+		name     string
+		budget   int
+		strategy string
+		want     []int
+	}{
+		{"requests fit", 60, BudgetProportional, []int{30, 20, 10}},
+		// 34 left after floors for 28 + 18 + 8 = 54 wanted.
+		{"proportional", 40, BudgetProportional, []int{20, 13, 7}},
+		{"priority serves the highest first", 40, BudgetPriority, []int{30, 6, 4}},
+		{"priority shares a tier", 20, BudgetPriority, []int{16, 2, 2}},
+		{"floors beat the budget", 4, BudgetPriority, []int{2, 2, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AllocateBudget(tt.budget, claims, tt.strategy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AllocateBudget(%d, %s) = %v, want %v", tt.budget, tt.strategy, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllocateBudget_SumsToBudget(t *testing.T) {
+	claims := []BudgetClaim{{Request: 7}, {Request: 7}, {Request: 7}}
+	got := AllocateBudget(10, claims, BudgetProportional)
+	if sum := got[0] + got[1] + got[2]; sum != 10 {
+		t.Errorf("AllocateBudget() = %v, sums to %d, want 10", got, sum)
+	}
+}
+
+func TestToReplicasTrace_Budget(t *testing.T) {
+	p := Policy{
+		TargetPerPod:          100,
+		Headroom:              1.0,
+		MinReplicas:           2,
+		UpMaxFactorPerStep:    10,
+		DownMaxPercentPerStep: 100,
+		StepLimits: []StepLimit{
+			{BudgetReplicas: 5},
+			{BudgetReplicas: 1, MinReplicas: 3},
+		},
+	}
+
+	replicas, trace := ToReplicasTrace(4, []float64{800, 800}, 60, p, nil)
+	// The budget caps step 0 and yields to the override minimum at step 1.
+	if want := []int{5, 3}; !reflect.DeepEqual(replicas, want) {
+		t.Fatalf("replicas = %v, want %v", replicas, want)
+	}
+	if want := []Clamp{{ClampReplicaBudget, 8, 5}}; !reflect.DeepEqual(trace[0].Clamps, want) {
+		t.Errorf("trace[0].Clamps = %v, want %v", trace[0].Clamps, want)
+	}
+	if got := StepMinimums(p, 3); !reflect.DeepEqual(got, []int{2, 3, 2}) {
+		t.Errorf("StepMinimums() = %v, want [2 3 2]", got)
+	}
+}
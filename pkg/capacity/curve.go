@@ -0,0 +1,271 @@
+package capacity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CurvePoint is the sustainable load measured at a replica count.
+type CurvePoint struct {
+	Replicas int
+	Load     float64
+}
+
+// USL is the Universal Scalability Law: n replicas sustain
+//
+//	λn / (1 + σ(n−1) + κn(n−1))
+//
+// where Lambda (λ) is the load of a single replica, Sigma (σ) the contention for
+// shared resources, and Kappa (κ) the cost of keeping replicas coherent. With κ > 0
+// throughput peaks at sqrt((1−σ)/κ) replicas and falls beyond.
+type USL struct {
+	Lambda float64
+	Sigma  float64
+	Kappa  float64
+}
+
+// Curve is the load a workload sustains at each replica count, for workloads whose
+// throughput does not grow linearly with replicas. Exactly one of Points and USL is
+// set. Points are interpolated linearly from the origin, and beyond the last point
+// the last segment is extended.
+type Curve struct {
+	Points []CurvePoint
+	USL    *USL
+}
+
+// Validate reports whether the curve is usable.
+func (c *Curve) Validate() error {
+	if (len(c.Points) > 0) == (c.USL != nil) {
+		return errors.New("capacity curve needs either points or USL parameters")
+	}
+	if u := c.USL; u != nil {
+		if u.Lambda <= 0 || u.Sigma < 0 || u.Sigma > 1 || u.Kappa < 0 {
+			return fmt.Errorf("invalid USL parameters: lambda must be > 0, sigma 0-1, kappa >= 0")
+		}
+		return nil
+	}
+	for i, pt := range c.Points {
+		if pt.Replicas <= 0 || pt.Load < 0 {
+			return fmt.Errorf("curve point %d: replicas must be > 0 and load >= 0", i)
+		}
+		if i > 0 && pt.Replicas <= c.Points[i-1].Replicas {
+			return fmt.Errorf("curve point %d: replicas must increase", i)
+		}
+	}
+	return nil
+}
+
+// Load returns the load sustained by replicas, which may be fractional.
+func (c *Curve) Load(replicas float64) float64 {
+	if replicas <= 0 {
+		return 0
+	}
+	if u := c.USL; u != nil {
+		return u.Lambda * replicas / (1 + u.Sigma*(replicas-1) + u.Kappa*replicas*(replicas-1))
+	}
+	x0, y0 := 0.0, 0.0
+	for _, pt := range c.Points {
+		x1, y1 := float64(pt.Replicas), pt.Load
+		if replicas <= x1 {
+			return y0 + (replicas-x0)*(y1-y0)/(x1-x0)
+		}
+		x0, y0 = x1, y1
+	}
+	return max(y0+(replicas-x0)*c.lastSlope(), 0)
+}
+
+// Replicas returns the fewest, possibly fractional, replicas that sustain load. A
+// load beyond what the curve can reach returns the replicas sustaining the most
+// load: the USL peak, the point after which the curve stops growing, or +Inf for a
+// USL without coherency cost, which grows forever towards λ/σ.
+func (c *Curve) Replicas(load float64) float64 {
+	if load <= 0 {
+		return 0
+	}
+	if c.USL != nil {
+		return c.USL.replicas(load)
+	}
+	x0, y0 := 0.0, 0.0
+	for _, pt := range c.Points {
+		x1, y1 := float64(pt.Replicas), pt.Load
+		if y0 < load && load <= y1 {
+			return x0 + (load-y0)*(x1-x0)/(y1-y0)
+		}
+		x0, y0 = x1, y1
+	}
+	if slope := c.lastSlope(); slope > 0 && load > y0 {
+		return x0 + (load-y0)/slope
+	}
+	return c.peakPoint()
+}
+
+// lastSlope is the load gained per replica along the last segment of the points.
+func (c *Curve) lastSlope() float64 {
+	n := len(c.Points)
+	x1, y1 := float64(c.Points[n-1].Replicas), c.Points[n-1].Load
+	x0, y0 := 0.0, 0.0
+	if n > 1 {
+		x0, y0 = float64(c.Points[n-2].Replicas), c.Points[n-2].Load
+	}
+	return (y1 - y0) / (x1 - x0)
+}
+
+// peakPoint is the replicas of the first point with the highest load.
+func (c *Curve) peakPoint() float64 {
+	best := c.Points[0]
+	for _, pt := range c.Points[1:] {
+		if pt.Load > best.Load {
+			best = pt
+		}
+	}
+	return float64(best.Replicas)
+}
+
+// replicas inverts the USL: the smallest root of λn = load(1 + σ(n−1) + κn(n−1)).
+func (u *USL) replicas(load float64) float64 {
+	if u.Kappa == 0 {
+		if u.Lambda <= load*u.Sigma {
+			return math.Inf(1)
+		}
+		return load * (1 - u.Sigma) / (u.Lambda - load*u.Sigma)
+	}
+	peak := math.Sqrt((1 - u.Sigma) / u.Kappa)
+	if load >= (&Curve{USL: u}).Load(peak) {
+		return peak
+	}
+	a := load * u.Kappa
+	b := load*u.Sigma - load*u.Kappa - u.Lambda
+	c := load * (1 - u.Sigma)
+	return (-b - math.Sqrt(b*b-4*a*c)) / (2 * a)
+}
+
+// FitUSL fits the Universal Scalability Law to measured points by least squares on
+// its linear form n/X(n) = (1 + σ(n−1) + κn(n−1)) / λ. A coefficient that would
+// come out negative is fixed at 0 and the rest refitted. It needs at least three
+// points.
+func FitUSL(points []CurvePoint) (USL, error) {
+	if len(points) < 3 {
+		return USL{}, errors.New("fitting a USL needs at least 3 points")
+	}
+	rows := make([][]float64, len(points))
+	y := make([]float64, len(points))
+	for i, pt := range points {
+		if pt.Replicas <= 0 || pt.Load <= 0 {
+			return USL{}, fmt.Errorf("curve point %d: replicas and load must be > 0", i)
+		}
+		n := float64(pt.Replicas)
+		rows[i] = []float64{1, n - 1, n * (n - 1)}
+		y[i] = n / pt.Load
+	}
+
+	// Columns: 0 is 1/λ, 1 is σ/λ, 2 is κ/λ.
+	for _, columns := range [][]int{{0, 1, 2}, {0, 1}, {0, 2}, {0}} {
+		coef, ok := leastSquares(rows, y, columns)
+		if !ok || coef[0] <= 0 || coef[1] < 0 || coef[2] < 0 {
+			continue
+		}
+		u := USL{Lambda: 1 / coef[0], Sigma: coef[1] / coef[0], Kappa: coef[2] / coef[0]}
+		if u.Sigma > 1 {
+			continue
+		}
+		return u, nil
+	}
+	return USL{}, errors.New("points do not fit a USL")
+}
+
+// leastSquares solves the normal equations for y ≈ rows·coef using only the given
+// columns; the others are 0 in the result. It reports false when the columns are
+// linearly dependent.
+func leastSquares(rows [][]float64, y []float64, columns []int) ([3]float64, bool) {
+	k := len(columns)
+	// Augmented matrix of AᵀA | Aᵀy.
+	m := make([][]float64, k)
+	for r := range m {
+		m[r] = make([]float64, k+1)
+		for i, row := range rows {
+			for c := range k {
+				m[r][c] += row[columns[r]] * row[columns[c]]
+			}
+			m[r][k] += row[columns[r]] * y[i]
+		}
+	}
+	for col := range k {
+		pivot := col
+		for r := col + 1; r < k; r++ {
+			if math.Abs(m[r][col]) > math.Abs(m[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(m[pivot][col]) < 1e-12 {
+			return [3]float64{}, false
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		for r := range k {
+			if r == col {
+				continue
+			}
+			f := m[r][col] / m[col][col]
+			for c := col; c <= k; c++ {
+				m[r][c] -= f * m[col][c]
+			}
+		}
+	}
+	var coef [3]float64
+	for r, column := range columns {
+		coef[column] = m[r][k] / m[r][r]
+	}
+	return coef, true
+}
+
+// ParseCurve parses a capacity curve: piecewise points as "replicas:load" pairs
+// ("1:100,10:800,40:2000"), USL parameters as "usl:lambda,sigma,kappa"
+// ("usl:100,0.05,0.0002"), or points to fit a USL to as "usl:" followed by pairs.
+func ParseCurve(s string) (*Curve, error) {
+	s = strings.TrimSpace(s)
+	body, usl := strings.CutPrefix(s, "usl:")
+	if usl && !strings.Contains(body, ":") {
+		fields := strings.Split(body, ",")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid USL %q: want lambda,sigma,kappa", s)
+		}
+		var params [3]float64
+		for i, f := range fields {
+			v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid USL %q: %w", s, err)
+			}
+			params[i] = v
+		}
+		c := &Curve{USL: &USL{Lambda: params[0], Sigma: params[1], Kappa: params[2]}}
+		return c, c.Validate()
+	}
+
+	var points []CurvePoint
+	for _, pair := range strings.Split(body, ",") {
+		replicas, load, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid curve point %q: want replicas:load", pair)
+		}
+		r, err := strconv.Atoi(replicas)
+		if err != nil {
+			return nil, fmt.Errorf("invalid curve point %q: %w", pair, err)
+		}
+		l, err := strconv.ParseFloat(load, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid curve point %q: %w", pair, err)
+		}
+		points = append(points, CurvePoint{Replicas: r, Load: l})
+	}
+	if usl {
+		fit, err := FitUSL(points)
+		if err != nil {
+			return nil, err
+		}
+		return &Curve{USL: &fit}, nil
+	}
+	c := &Curve{Points: points}
+	return c, c.Validate()
+}
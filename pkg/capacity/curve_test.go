@@ -0,0 +1,147 @@
+package capacity
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCurve_Points(t *testing.T) {
+	c := &Curve{Points: []CurvePoint{{1, 100}, {10, 600}, {20, 800}}}
+
+	for _, tt := range []struct {
+		load, replicas float64
+	}{
+		{0, 0},
+		{50, 0.5},
+		{100, 1},
+		{350, 5.5},
+		// Beyond the last point the last segment continues at 20 per replica.
+		{900, 25},
+	} {
+		if got := c.Replicas(tt.load); math.Abs(got-tt.replicas) > 1e-9 {
+			t.Errorf("Replicas(%v) = %v, want %v", tt.load, got, tt.replicas)
+		}
+		if got := c.Load(tt.replicas); math.Abs(got-tt.load) > 1e-9 {
+			t.Errorf("Load(%v) = %v, want %v", tt.replicas, got, tt.load)
+		}
+	}
+
+	flat := &Curve{Points: []CurvePoint{{10, 500}, {20, 500}}}
+	if got := flat.Replicas(600); got != 10 {
+		t.Errorf("Replicas() beyond a flat curve = %v, want the peak at 10", got)
+	}
+}
+
+func TestCurve_USL(t *testing.T) {
+	c := &Curve{USL: &USL{Lambda: 100, Sigma: 0.05, Kappa: 0.001}}
+
+	if got := c.Replicas(c.Load(10)); math.Abs(got-10) > 1e-9 {
+		t.Errorf("Replicas(Load(10)) = %v, want 10", got)
+	}
+	peak := math.Sqrt(0.95 / 0.001)
+	if got := c.Replicas(1e6); math.Abs(got-peak) > 1e-9 {
+		t.Errorf("Replicas() beyond the peak = %v, want %v", got, peak)
+	}
+
+	// Without coherency cost throughput only approaches lambda/sigma.
+	unbounded := &Curve{USL: &USL{Lambda: 100, Sigma: 0.1}}
+	if got := unbounded.Replicas(2000); !math.IsInf(got, 1) {
+		t.Errorf("Replicas() beyond lambda/sigma = %v, want +Inf", got)
+	}
+	p := Policy{Curve: unbounded, MaxReplicas: 40}
+	if got := ToReplicas(40, []float64{2000}, 60, p, nil); got[0] != 40 {
+		t.Errorf("ToReplicas() beyond the curve = %v, want MaxReplicas 40", got)
+	}
+}
+
+func TestPolicy_Pods_MonotoneBeyondUSL(t *testing.T) {
+	// lambda/sigma = 2000 is the most load any replica count approaches.
+	p := Policy{Curve: &Curve{USL: &USL{Lambda: 100, Sigma: 0.05}}}
+	below, at := p.Pods(1900), p.Pods(2000)
+	if math.Abs(below-361) > 1e-9 {
+		t.Errorf("Pods(1900) = %v, want 361", below)
+	}
+	if at < below {
+		t.Errorf("Pods(2000) = %v, want at least Pods(1900) = %v", at, below)
+	}
+	if got := ToReplicas(1, []float64{1900, 2000}, 60, p, nil); got[1] < got[0] {
+		t.Errorf("ToReplicas() = %v, want no fewer replicas for the higher load", got)
+	}
+}
+
+func TestFitUSL(t *testing.T) {
+	want := USL{Lambda: 120, Sigma: 0.03, Kappa: 0.0004}
+	c := &Curve{USL: &want}
+	var points []CurvePoint
+	for _, n := range []int{1, 2, 4, 8, 16, 32} {
+		points = append(points, CurvePoint{Replicas: n, Load: c.Load(float64(n))})
+	}
+
+	got, err := FitUSL(points)
+	if err != nil {
+		t.Fatalf("FitUSL() error = %v", err)
+	}
+	if math.Abs(got.Lambda-want.Lambda) > 1e-6 || math.Abs(got.Sigma-want.Sigma) > 1e-9 || math.Abs(got.Kappa-want.Kappa) > 1e-9 {
+		t.Errorf("FitUSL() = %+v, want %+v", got, want)
+	}
+
+	// Perfectly linear points fit with no contention or coherency cost.
+	got, err = FitUSL([]CurvePoint{{1, 50}, {2, 100}, {4, 200}})
+	if err != nil || math.Abs(got.Lambda-50) > 1e-9 || got.Sigma != 0 || got.Kappa != 0 {
+		t.Errorf("FitUSL(linear) = %+v, %v, want lambda 50", got, err)
+	}
+
+	if _, err := FitUSL(points[:2]); err == nil {
+		t.Error("expected error for fewer than 3 points")
+	}
+}
+
+func TestParseCurve(t *testing.T) {
+	c, err := ParseCurve("1:100, 10:600")
+	if err != nil {
+		t.Fatalf("ParseCurve(points) error = %v", err)
+	}
+	if want := []CurvePoint{{1, 100}, {10, 600}}; !reflect.DeepEqual(c.Points, want) {
+		t.Errorf("Points = %v, want %v", c.Points, want)
+	}
+
+	c, err = ParseCurve("usl:100,0.05,0.001")
+	if err != nil {
+		t.Fatalf("ParseCurve(usl) error = %v", err)
+	}
+	if want := (USL{Lambda: 100, Sigma: 0.05, Kappa: 0.001}); *c.USL != want {
+		t.Errorf("USL = %+v, want %+v", *c.USL, want)
+	}
+
+	c, err = ParseCurve("usl:1:50,2:100,4:200")
+	if err != nil || c.USL == nil || math.Abs(c.USL.Lambda-50) > 1e-9 {
+		t.Errorf("ParseCurve(usl fit) = %+v, %v, want a fitted USL", c, err)
+	}
+
+	for _, s := range []string{"", "10:600,1:100", "1=100", "usl:100,0.05", "usl:100,2,0"} {
+		if _, err := ParseCurve(s); err == nil {
+			t.Errorf("ParseCurve(%q) expected error", s)
+		}
+	}
+}
+
+func TestToReplicasTrace_Curve(t *testing.T) {
+	p := Policy{
+		Headroom:              1.0,
+		MinReplicas:           1,
+		MaxReplicas:           50,
+		UpMaxFactorPerStep:    10,
+		DownMaxPercentPerStep: 100,
+		Curve:                 &Curve{Points: []CurvePoint{{1, 100}, {10, 500}}},
+	}
+
+	replicas, trace := ToReplicasTrace(1, []float64{300, 100}, 60, p, nil)
+	// 300 needs 1 + 200/400*9 = 5.5 replicas on the curve, 3 if it were linear.
+	if want := []int{6, 1}; !reflect.DeepEqual(replicas, want) {
+		t.Errorf("replicas = %v, want %v", replicas, want)
+	}
+	if trace[0].Load != 300 || trace[0].Need != 5.5 {
+		t.Errorf("trace[0] load = %v, need = %v, want 300 and 5.5", trace[0].Load, trace[0].Need)
+	}
+}
@@ -0,0 +1,111 @@
+package capacity
+
+// Combine modes for ToReplicasMulti.
+const (
+	// CombineMax plans for the signal needing the most pods at each step.
+	CombineMax = "max"
+	// CombineWeighted plans for the weighted sum of the pods each signal needs.
+	CombineWeighted = "weighted"
+)
+
+// Signal is the forecast of one metric that bounds a workload's capacity, such as
+// requests per second or open connections, with the load a single pod can sustain.
+type Signal struct {
+	// Metric names the signal in the binding metric reported per step.
+	Metric string
+
+	// Values is the point forecast. All signals must cover the same steps.
+	Values []float64
+
+	// Quantiles optionally provides quantile forecasts, used as in ToReplicas.
+	Quantiles map[float64][]float64
+
+	// TargetPerPod is the sustainable load of this metric per pod. Must be > 0.
+	TargetPerPod float64
+
+	// Weight scales the signal's pods under CombineWeighted. If <= 0, defaults to 1.
+	Weight float64
+}
+
+// ToReplicasMulti plans replicas for a workload bound by several metrics. Each
+// signal's forecast is converted to fractional pods with its own TargetPerPod and the
+// policy's headroom or quantile level; the per-step needs are combined with
+// CombineMax (the default) or CombineWeighted, and the result is rounded and clamped
+// exactly as in ToReplicas. Policy.TargetPerPod is ignored.
+//
+// It also returns the trace of each step, as in ToReplicasTrace, whose Metric is the
+// binding metric: the signal that needed the most pods, or contributed the most under
+// CombineWeighted, at the step that determined the replica count. The trace's source,
+// forecast, and load are those of the binding metric. Steps are planned up to the
+// shortest signal.
+func ToReplicasMulti(prev int, signals []Signal, stepSec int, p Policy, combine string) ([]int, []StepTrace) {
+	steps := -1
+	for _, s := range signals {
+		if steps < 0 || len(s.Values) < steps {
+			steps = len(s.Values)
+		}
+	}
+	if steps <= 0 {
+		return nil, nil
+	}
+	p = normalize(p)
+
+	pods := make([]float64, steps)
+	binding := make([]int, steps)
+	largest := make([]float64, steps)
+	sources := make([]string, len(signals))
+	loads := make([][]float64, len(signals))
+	for k, s := range signals {
+		target := s.TargetPerPod
+		if target <= 0 {
+			target = 1
+		}
+		weight := 1.0
+		if combine == CombineWeighted && s.Weight > 0 {
+			weight = s.Weight
+		}
+
+		perPod := func(load float64) float64 { return load / target }
+		need, load, source := loadToPods(s.Values[:steps], perPod, p, trimQuantiles(s.Quantiles, steps))
+		sources[k] = source
+		loads[k] = load
+		for i, n := range need {
+			n *= weight
+			if combine == CombineWeighted {
+				pods[i] += n
+			} else if n > pods[i] {
+				pods[i] = n
+			}
+			if k == 0 || n > largest[i] {
+				largest[i], binding[i] = n, k
+			}
+		}
+	}
+
+	p.PrewarmWindowSteps += startupSteps(p, stepSec)
+	res, trace := plan(prev, pods, p)
+	for i := range trace {
+		t := &trace[i]
+		k := binding[t.NeedStep]
+		t.Metric = signals[k].Metric
+		t.Source = sources[k]
+		t.Forecast = signals[k].Values[t.NeedStep]
+		t.Load = loads[k][t.NeedStep]
+	}
+	return res, trace
+}
+
+// trimQuantiles truncates each quantile series to steps so a longer signal still
+// uses its quantiles.
+func trimQuantiles(quantiles map[float64][]float64, steps int) map[float64][]float64 {
+	if quantiles == nil {
+		return nil
+	}
+	trimmed := make(map[float64][]float64, len(quantiles))
+	for level, values := range quantiles {
+		if len(values) >= steps {
+			trimmed[level] = values[:steps]
+		}
+	}
+	return trimmed
+}
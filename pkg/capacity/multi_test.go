@@ -0,0 +1,114 @@
+package capacity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToReplicasMulti_Max(t *testing.T) {
+	p := Policy{
+		Headroom:              1.0,
+		MinReplicas:           1,
+		MaxReplicas:           100,
+		UpMaxFactorPerStep:    10,
+		DownMaxPercentPerStep: 100,
+	}
+	signals := []Signal{
+		{Metric: "rps", Values: []float64{400, 400, 100, 100}, TargetPerPod: 100},
+		{Metric: "connections", Values: []float64{100, 900, 900, 100}, TargetPerPod: 300},
+	}
+
+	replicas, trace := ToReplicasMulti(1, signals, 60, p, CombineMax)
+	// rps needs 4, 4, 1, 1 pods; connections needs 0.33, 3, 3, 0.33.
+	if want := []int{4, 4, 3, 1}; !reflect.DeepEqual(replicas, want) {
+		t.Errorf("replicas = %v, want %v", replicas, want)
+	}
+	if want := []string{"rps", "rps", "connections", "rps"}; !reflect.DeepEqual(bindingMetrics(trace), want) {
+		t.Errorf("binding = %v, want %v", bindingMetrics(trace), want)
+	}
+}
+
+func TestToReplicasMulti_Weighted(t *testing.T) {
+	p := Policy{
+		Headroom:              1.0,
+		MinReplicas:           0,
+		UpMaxFactorPerStep:    10,
+		DownMaxPercentPerStep: 100,
+	}
+	signals := []Signal{
+		{Metric: "rps", Values: []float64{200, 200}, TargetPerPod: 100, Weight: 1},
+		{Metric: "cpu", Values: []float64{1, 4}, TargetPerPod: 1, Weight: 0.5},
+	}
+
+	replicas, trace := ToReplicasMulti(3, signals, 60, p, CombineWeighted)
+	// Step 0: 2 + 0.5*1 = 2.5 -> 3; step 1: 2 + 0.5*4 = 4.
+	if want := []int{3, 4}; !reflect.DeepEqual(replicas, want) {
+		t.Errorf("replicas = %v, want %v", replicas, want)
+	}
+	// Step 0: rps contributes 2 of 2.5; step 1: both contribute 2, the first wins.
+	if want := []string{"rps", "rps"}; !reflect.DeepEqual(bindingMetrics(trace), want) {
+		t.Errorf("binding = %v, want %v", bindingMetrics(trace), want)
+	}
+}
+
+func TestToReplicasMulti_MatchesToReplicasForOneSignal(t *testing.T) {
+	p := Policy{
+		TargetPerPod:          50,
+		Headroom:              1.2,
+		MinReplicas:           1,
+		MaxReplicas:           100,
+		UpMaxFactorPerStep:    2.0,
+		DownMaxPercentPerStep: 50,
+		PrewarmWindowSteps:    2,
+	}
+	forecast := []float64{120, 130, 125, 400, 100}
+
+	want := ToReplicas(2, forecast, 60, p, nil)
+	_, wantTrace := ToReplicasTrace(2, forecast, 60, p, nil)
+	got, trace := ToReplicasMulti(2, []Signal{{Metric: "rps", Values: forecast, TargetPerPod: 50}}, 60, p, CombineMax)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToReplicasMulti() = %v, ToReplicas() = %v", got, want)
+	}
+	for i := range trace {
+		if trace[i].Metric != "rps" {
+			t.Errorf("trace[%d].Metric = %q, want rps", i, trace[i].Metric)
+		}
+		trace[i].Metric = ""
+	}
+	if !reflect.DeepEqual(trace, wantTrace) {
+		t.Errorf("ToReplicasMulti() trace = %+v, ToReplicasTrace() = %+v", trace, wantTrace)
+	}
+}
+
+func TestToReplicasMulti_PrewarmReportsSourceStep(t *testing.T) {
+	p := Policy{
+		Headroom:              1.0,
+		UpMaxFactorPerStep:    10,
+		DownMaxPercentPerStep: 100,
+		PrewarmWindowSteps:    1,
+	}
+	signals := []Signal{
+		{Metric: "rps", Values: []float64{300, 100, 100}, TargetPerPod: 100},
+		{Metric: "connections", Values: []float64{0, 0, 500}, TargetPerPod: 100},
+	}
+
+	_, trace := ToReplicasMulti(1, signals, 60, p, CombineMax)
+	// Step 1 prewarms for step 2, where connections bind.
+	if want := []string{"rps", "connections", "connections"}; !reflect.DeepEqual(bindingMetrics(trace), want) {
+		t.Errorf("binding = %v, want %v", bindingMetrics(trace), want)
+	}
+}
+
+func TestToReplicasMulti_Empty(t *testing.T) {
+	if replicas, trace := ToReplicasMulti(1, nil, 60, Policy{}, CombineMax); replicas != nil || trace != nil {
+		t.Errorf("ToReplicasMulti(nil) = %v, %v, want nil", replicas, trace)
+	}
+}
+
+func bindingMetrics(trace []StepTrace) []string {
+	metrics := make([]string, len(trace))
+	for i, t := range trace {
+		metrics[i] = t.Metric
+	}
+	return metrics
+}
@@ -0,0 +1,237 @@
+package capacity
+
+import (
+	"context"
+	"math"
+	"slices"
+)
+
+// demandSamples is the number of points at which the forecast distribution of each
+// step is sampled to estimate the expected shortfall.
+const demandSamples = 100
+
+// optimize chooses the replica path that minimises expected cost over the horizon:
+// ReplicaHourCost for every replica running plus UnderProvisionPenalty for every
+// pod-hour of demand left unserved, in expectation over the forecast distribution.
+// The path obeys the same bounds and change clamps as the deterministic planner, so
+// it is found by dynamic programming over (step, replicas).
+//
+// The distribution of each step is read from all quantiles provided; without them
+// the point forecast with headroom is treated as certain. With PodStartupSeconds the
+// replicas of a step must also cover the demand of the steps until they are ready.
+// QuantileLevel, PrewarmWindowSteps, and RoundingMode do not apply: the planner
+// already weighs the whole distribution and sees the whole horizon. The trace of each
+// step reports the expected pods demanded as its need.
+//
+// Each step takes time linear in the replicas between the bounds, which MaxReplicas
+// caps. ctx is checked between steps, and its error returned once it is done.
+func optimize(ctx context.Context, prev int, forecast []float64, stepSec int, p Policy, quantiles map[float64][]float64) ([]int, []StepTrace, error) {
+	if stepSec <= 0 {
+		stepSec = 60
+	}
+	stepHours := float64(stepSec) / 3600
+
+	demand := make([][]float64, len(forecast))
+	for i := range forecast {
+		demand[i] = podDemand(i, forecast, quantiles, p)
+	}
+	demand = shiftDemand(demand, startupSteps(p, stepSec))
+	peak := 0.0
+	for _, d := range demand {
+		peak = max(peak, d[len(d)-1])
+	}
+
+	prevOut := clampBounds(prev, p.MinReplicas, p.MaxReplicas)
+
+	// Running more replicas than the highest possible demand never lowers the cost,
+	// so the state space stops there (or at prev) when that is below MaxReplicas.
+	// Scheduled overrides may widen it.
+	lo := p.MinReplicas
+	hi := min(p.MaxReplicas, max(prevOut, int(math.Ceil(peak)), lo))
+	for i := range p.StepLimits {
+		b := stepBounds(p, i, 0)
+		lo = min(lo, b.lo)
+		if b.hi > 0 {
+			hi = max(hi, b.hi)
+		}
+		hi = max(hi, b.lo)
+	}
+	states := hi - lo + 1
+
+	inf := math.Inf(1)
+	cost := make([]float64, states)
+	for s := range cost {
+		cost[s] = inf
+	}
+	cost[prevOut-lo] = 0
+
+	// parent[i][s] is the state at step i-1 leading to state s at step i; for step 0
+	// it is unused because every path starts at prevOut.
+	parent := make([][]int, len(forecast))
+	next := make([]float64, states)
+	reachLo := make([]int, states)
+	reachHi := make([]int, states)
+	window := make([]int, 0, states)
+
+	for i := range forecast {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		parent[i] = make([]int, states)
+
+		// The states reachable from a state form a range whose ends never decrease
+		// as the state grows, so the states reaching a target form a window sliding
+		// up with it. The cheapest of the window is kept at its front.
+		for from := range cost {
+			r := from + lo
+			b := stepBounds(p, i, r)
+			if b.hi <= 0 {
+				b.hi = hi
+			}
+			reachLo[from] = clampBounds(clampChange(r, 0, p.UpMaxFactorPerStep, p.DownMaxPercentPerStep), b.lo, b.hi) - lo
+			reachHi[from] = clampBounds(clampChange(r, hi, p.UpMaxFactorPerStep, p.DownMaxPercentPerStep), b.lo, b.hi) - lo
+		}
+		window = window[:0]
+		from := 0
+		for to := range next {
+			next[to] = inf
+			for ; from < states && reachLo[from] <= to; from++ {
+				if math.IsInf(cost[from], 1) {
+					continue
+				}
+				for len(window) > 0 && cost[window[len(window)-1]] > cost[from] {
+					window = window[:len(window)-1]
+				}
+				window = append(window, from)
+			}
+			for len(window) > 0 && reachHi[window[0]] < to {
+				window = window[1:]
+			}
+			if len(window) > 0 {
+				next[to] = cost[window[0]] + stepCost(to+lo, demand[i], stepHours, p)
+				parent[i][to] = window[0]
+			}
+		}
+		cost, next = next, cost
+	}
+
+	best := 0
+	for s := range cost {
+		if cost[s] < cost[best] {
+			best = s
+		}
+	}
+
+	res := make([]int, len(forecast))
+	trace := make([]StepTrace, len(forecast))
+	for i := len(forecast) - 1; i >= 0; i-- {
+		res[i] = best + lo
+		best = parent[i][best]
+
+		var expected float64
+		for _, d := range demand[i] {
+			expected += d
+		}
+		expected /= float64(len(demand[i]))
+		trace[i] = StepTrace{
+			Source:   SourceCost,
+			NeedStep: i,
+			Forecast: forecast[i],
+			Load:     p.load(expected),
+			Need:     expected,
+			Rounded:  res[i],
+			Replicas: res[i],
+		}
+	}
+	return res, trace, nil
+}
+
+// shiftDemand makes the demand of step i the highest demand over steps i..i+lead,
+// level by level, so capacity requested at i covers the load when it becomes ready.
+func shiftDemand(demand [][]float64, lead int) [][]float64 {
+	if lead <= 0 {
+		return demand
+	}
+	shifted := make([][]float64, len(demand))
+	for i := range demand {
+		end := min(i+lead, len(demand)-1)
+		width := 0
+		for j := i; j <= end; j++ {
+			width = max(width, len(demand[j]))
+		}
+		shifted[i] = make([]float64, width)
+		for j := i; j <= end; j++ {
+			for k := range shifted[i] {
+				shifted[i][k] = max(shifted[i][k], sample(demand[j], k, width))
+			}
+		}
+	}
+	return shifted
+}
+
+// sample returns the k-th of width evenly spaced levels of ascending samples, which
+// may hold a single certain value.
+func sample(samples []float64, k, width int) float64 {
+	if len(samples) == width {
+		return samples[k]
+	}
+	return samples[k*len(samples)/width]
+}
+
+// stepCost is the expected cost of running replicas for one step given samples of
+// the pods demanded.
+func stepCost(replicas int, demand []float64, stepHours float64, p Policy) float64 {
+	var shortfall float64
+	for _, d := range demand {
+		if d > float64(replicas) {
+			shortfall += d - float64(replicas)
+		}
+	}
+	shortfall /= float64(len(demand))
+	return stepHours * (float64(replicas)*p.ReplicaHourCost + shortfall*p.UnderProvisionPenalty)
+}
+
+// podDemand returns ascending samples of the pods demanded at step i, drawn evenly
+// from the quantile function interpolated between the provided quantiles and
+// extrapolated linearly into the tails.
+func podDemand(i int, forecast []float64, quantiles map[float64][]float64, p Policy) []float64 {
+	var levels []float64
+	for level, values := range quantiles {
+		if level > 0 && level < 1 && i < len(values) && !math.IsNaN(values[i]) {
+			levels = append(levels, level)
+		}
+	}
+	if len(levels) == 0 {
+		return []float64{p.Pods(max(forecast[i], 0) * p.Headroom)}
+	}
+	slices.Sort(levels)
+	values := make([]float64, len(levels))
+	for k, level := range levels {
+		values[k] = quantiles[level][i]
+		// Quantile crossings would make the function non-monotonic; flatten them.
+		if k > 0 {
+			values[k] = max(values[k], values[k-1])
+		}
+	}
+
+	samples := make([]float64, demandSamples)
+	for j := range samples {
+		u := (float64(j) + 0.5) / demandSamples
+		samples[j] = p.Pods(max(interpolateQuantile(levels, values, u), 0))
+	}
+	return samples
+}
+
+// interpolateQuantile evaluates the piecewise-linear quantile function through
+// (levels, values) at u, extending the first and last segments beyond the outermost
+// quantiles. With a single quantile the function is constant.
+func interpolateQuantile(levels, values []float64, u float64) float64 {
+	if len(levels) == 1 {
+		return values[0]
+	}
+	k := 1
+	for k < len(levels)-1 && u > levels[k] {
+		k++
+	}
+	return values[k-1] + (values[k]-values[k-1])*(u-levels[k-1])/(levels[k]-levels[k-1])
+}
@@ -0,0 +1,151 @@
+package capacity
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func costPolicy(replicaHourCost, penalty float64) Policy {
+	return Policy{
+		TargetPerPod:          100,
+		Headroom:              1.0,
+		MinReplicas:           1,
+		MaxReplicas:           20,
+		UpMaxFactorPerStep:    2.0,
+		DownMaxPercentPerStep: 50,
+		Planner:               "cost",
+		ReplicaHourCost:       replicaHourCost,
+		UnderProvisionPenalty: penalty,
+	}
+}
+
+func TestToReplicas_CostCertainDemand(t *testing.T) {
+	forecast := []float64{250, 250, 420, 300}
+
+	// Unserved demand costs more than a replica: cover it fully.
+	got := ToReplicas(3, forecast, 60, costPolicy(1, 10), nil)
+	if want := []int{3, 3, 5, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expensive shortfall: got %v, want %v", got, want)
+	}
+
+	// Replicas cost more than the demand they would serve: shed down to the floor
+	// as fast as the down clamp allows.
+	got = ToReplicas(8, forecast, 60, costPolicy(10, 1), nil)
+	if want := []int{4, 2, 1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cheap shortfall: got %v, want %v", got, want)
+	}
+}
+
+func TestToReplicas_CostRespectsUpClamp(t *testing.T) {
+	forecast := []float64{100, 100, 100, 1000}
+
+	got := ToReplicas(1, forecast, 60, costPolicy(1, 100), nil)
+	// The deterministic planner would be stuck at 2, 4 after the jump; the optimiser
+	// pays for replicas early to be at 10 when the demand arrives.
+	if want := []int{2, 3, 5, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestToReplicas_CostUsesQuantiles(t *testing.T) {
+	forecast := []float64{500}
+	quantiles := map[float64][]float64{
+		0.1: {300},
+		0.5: {500},
+		0.9: {900},
+	}
+
+	low := ToReplicas(5, forecast, 60, costPolicy(1, 1.5), quantiles)
+	high := ToReplicas(5, forecast, 60, costPolicy(1, 20), quantiles)
+	if low[0] >= high[0] {
+		t.Errorf("replicas with a low penalty (%d) should be below those with a high penalty (%d)", low[0], high[0])
+	}
+	// At a penalty of 20 the optimal level is the 95th percentile, beyond p90.
+	if high[0] < 9 {
+		t.Errorf("high penalty: got %d replicas, want at least the p90 need of 9", high[0])
+	}
+}
+
+func TestToReplicas_CostNotAboveDeterministic(t *testing.T) {
+	forecast := []float64{200, 350, 500, 650, 400, 250}
+	quantiles := map[float64][]float64{
+		0.1: {150, 280, 390, 500, 300, 190},
+		0.5: {200, 350, 500, 650, 400, 250},
+		0.9: {270, 450, 660, 880, 540, 330},
+	}
+	p := costPolicy(1, 4)
+
+	deterministic := p
+	deterministic.Planner = ""
+	deterministic.QuantileLevel = 0.9
+
+	expected := func(replicas []int) float64 {
+		var total float64
+		for i, r := range replicas {
+			total += stepCost(r, podDemand(i, forecast, quantiles, p), 1.0/60, p)
+		}
+		return total
+	}
+
+	optimized := expected(ToReplicas(2, forecast, 60, p, quantiles))
+	baseline := expected(ToReplicas(2, forecast, 60, deterministic, quantiles))
+	if optimized > baseline {
+		t.Errorf("expected cost of the cost planner = %.4f, above the deterministic planner's %.4f", optimized, baseline)
+	}
+}
+
+func TestInterpolateQuantile(t *testing.T) {
+	levels := []float64{0.25, 0.75}
+	values := []float64{10, 20}
+
+	tests := []struct {
+		u    float64
+		want float64
+	}{
+		{0.5, 15},
+		{0.25, 10},
+		{0.05, 6},  // extrapolated below the lowest quantile
+		{0.95, 24}, // extrapolated above the highest quantile
+	}
+	for _, tt := range tests {
+		if got := interpolateQuantile(levels, values, tt.u); got < tt.want-1e-9 || got > tt.want+1e-9 {
+			t.Errorf("interpolateQuantile(%v) = %v, want %v", tt.u, got, tt.want)
+		}
+	}
+}
+
+func TestToReplicas_CostPodStartup(t *testing.T) {
+	forecast := []float64{100, 100, 500}
+	p := costPolicy(1, 10)
+	p.UpMaxFactorPerStep = 10
+	p.PodStartupSeconds = 60
+
+	got := ToReplicas(1, forecast, 60, p, nil)
+	if want := []int{1, 5, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestToReplicas_CostNeedsMaxReplicas(t *testing.T) {
+	forecast := []float64{250, 420}
+	p := costPolicy(10, 1)
+	p.MaxReplicas = 0
+
+	deterministic := p
+	deterministic.Planner = ""
+	if got, want := ToReplicas(3, forecast, 60, p, nil), ToReplicas(3, forecast, 60, deterministic, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("without MaxReplicas got %v, want the deterministic plan %v", got, want)
+	}
+}
+
+func TestToReplicasTraceContext_CostCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := ToReplicasTraceContext(ctx, 1, []float64{100, 200}, 60, costPolicy(1, 10), nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ToReplicasTraceContext() error = %v, want context.Canceled", err)
+	}
+}
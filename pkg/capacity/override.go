@@ -0,0 +1,118 @@
+package capacity
+
+import (
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/durationx"
+)
+
+// Override is a scheduled rule that bounds replicas while its schedule is active,
+// regardless of the forecast.
+type Override struct {
+	Name     string
+	Schedule durationx.Schedule
+
+	// MinReplicas and MaxReplicas replace the policy bounds while the override is
+	// active. 0 keeps the policy bound.
+	MinReplicas int
+	MaxReplicas int
+
+	// FreezeScaleDown keeps replicas from decreasing while the override is active.
+	FreezeScaleDown bool
+}
+
+// StepLimit bounds the replicas of one forecast step, combining every override
+// active at the step.
+type StepLimit struct {
+	// MinReplicas and MaxReplicas replace the policy bounds when > 0.
+	MinReplicas int
+	MaxReplicas int
+
+	// FreezeScaleDown keeps the step from planning fewer replicas than the previous one.
+	FreezeScaleDown bool
+
+	// BudgetReplicas caps the step at the workload's share of a replica budget when
+	// > 0 (see AllocateBudget). It beats a scale-down freeze but not a minimum.
+	BudgetReplicas int
+
+	// Overrides names the overrides active at the step.
+	Overrides []string
+}
+
+// StepLimits evaluates overrides at the start of each of steps forecast steps, the
+// first starting at start. Active overrides combine to the highest minimum, the lowest
+// maximum, and a freeze if any freezes; when the minimum exceeds the maximum the
+// minimum wins. It returns nil when no override is active during the horizon.
+func StepLimits(overrides []Override, start time.Time, stepSec, steps int) []StepLimit {
+	var limits []StepLimit
+	for i := 0; i < steps; i++ {
+		at := start.Add(time.Duration(i*stepSec) * time.Second)
+		var limit StepLimit
+		for _, o := range overrides {
+			if !o.Schedule.Active(at) {
+				continue
+			}
+			limit.Overrides = append(limit.Overrides, o.Name)
+			limit.MinReplicas = max(limit.MinReplicas, o.MinReplicas)
+			if o.MaxReplicas > 0 && (limit.MaxReplicas == 0 || o.MaxReplicas < limit.MaxReplicas) {
+				limit.MaxReplicas = o.MaxReplicas
+			}
+			limit.FreezeScaleDown = limit.FreezeScaleDown || o.FreezeScaleDown
+		}
+		if limit.MaxReplicas > 0 && limit.MinReplicas > limit.MaxReplicas {
+			limit.MaxReplicas = limit.MinReplicas
+		}
+		if len(limit.Overrides) == 0 {
+			continue
+		}
+		if limits == nil {
+			limits = make([]StepLimit, steps)
+		}
+		limits[i] = limit
+	}
+	return limits
+}
+
+// StepMinimums returns the replicas each of steps forecast steps plans at least,
+// whatever the forecast: MinReplicas, or the minimum of the overrides active at the
+// step. A replica budget never allocates a workload less than this.
+func StepMinimums(p Policy, steps int) []int {
+	mins := make([]int, steps)
+	for i := range mins {
+		mins[i] = max(p.MinReplicas, 0)
+		if i < len(p.StepLimits) && p.StepLimits[i].MinReplicas > 0 {
+			mins[i] = p.StepLimits[i].MinReplicas
+		}
+	}
+	return mins
+}
+
+// stepBounds returns the replica bounds of step i given the replicas planned for the
+// previous step: the policy bounds, replaced by the step's limit when it has one. A
+// maximum beats a scale-down freeze, and a minimum beats a maximum.
+func stepBounds(p Policy, i, prev int) bounds {
+	b := bounds{lo: p.MinReplicas, hi: p.MaxReplicas, loRule: ClampMinReplicas, hiRule: ClampMaxReplicas}
+	if i >= len(p.StepLimits) {
+		return b
+	}
+	limit := p.StepLimits[i]
+	if limit.MinReplicas > 0 {
+		b.lo, b.loRule = limit.MinReplicas, ClampOverrideMin
+	}
+	if limit.MaxReplicas > 0 {
+		b.hi, b.hiRule = limit.MaxReplicas, ClampOverrideMax
+	}
+	if limit.BudgetReplicas > 0 && (b.hi <= 0 || limit.BudgetReplicas < b.hi) {
+		b.hi, b.hiRule = limit.BudgetReplicas, ClampReplicaBudget
+	}
+	if limit.FreezeScaleDown && prev > b.lo {
+		b.lo, b.loRule = prev, ClampFreezeScaleDown
+		if b.hi > 0 && b.lo > b.hi {
+			b.lo = b.hi
+		}
+	}
+	if b.hi > 0 && b.lo > b.hi {
+		b.hi, b.hiRule = b.lo, b.loRule
+	}
+	return b
+}
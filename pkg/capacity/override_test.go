@@ -0,0 +1,87 @@
+package capacity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/durationx"
+)
+
+func mustSchedule(t *testing.T, spec string) durationx.Schedule {
+	t.Helper()
+	schedule, err := durationx.ParseSchedule(spec, nil)
+	if err != nil {
+		t.Fatalf("ParseSchedule(%q) error = %v", spec, err)
+	}
+	return schedule
+}
+
+func TestStepLimits(t *testing.T) {
+	start := time.Date(2026, 10, 19, 7, 58, 0, 0, time.UTC) // a Monday
+	overrides := []Override{
+		{Name: "business-hours", Schedule: mustSchedule(t, "Mon-Fri 08:00-10:00"), MinReplicas: 20},
+		{Name: "deploy", Schedule: mustSchedule(t, "0 8 * * * for 2m"), MaxReplicas: 15, FreezeScaleDown: true},
+	}
+
+	limits := StepLimits(overrides, start, 60, 5)
+	want := []StepLimit{
+		{},
+		{},
+		{MinReplicas: 20, MaxReplicas: 20, FreezeScaleDown: true, Overrides: []string{"business-hours", "deploy"}},
+		{MinReplicas: 20, MaxReplicas: 20, FreezeScaleDown: true, Overrides: []string{"business-hours", "deploy"}},
+		{MinReplicas: 20, Overrides: []string{"business-hours"}},
+	}
+	if !reflect.DeepEqual(limits, want) {
+		t.Errorf("StepLimits() = %+v, want %+v", limits, want)
+	}
+
+	if limits := StepLimits(overrides, start.Add(-2*time.Hour), 60, 5); limits != nil {
+		t.Errorf("StepLimits() outside all windows = %+v, want nil", limits)
+	}
+}
+
+func TestToReplicas_StepLimits(t *testing.T) {
+	p := Policy{
+		TargetPerPod:          100,
+		Headroom:              1.0,
+		MinReplicas:           1,
+		MaxReplicas:           10,
+		UpMaxFactorPerStep:    10,
+		DownMaxPercentPerStep: 100,
+	}
+	forecast := []float64{800, 200, 200, 200, 900}
+
+	tests := []struct {
+		name   string
+		limits []StepLimit
+		want   []int
+	}{
+		{"none", nil, []int{8, 2, 2, 2, 9}},
+		{"minimum", []StepLimit{{}, {MinReplicas: 5}}, []int{8, 5, 2, 2, 9}},
+		{"minimum beats policy max", []StepLimit{{MinReplicas: 12}}, []int{12, 2, 2, 2, 9}},
+		{"maximum", []StepLimit{{}, {}, {}, {}, {MaxReplicas: 4}}, []int{8, 2, 2, 2, 4}},
+		{"freeze", []StepLimit{{}, {FreezeScaleDown: true}, {FreezeScaleDown: true}}, []int{8, 8, 8, 2, 9}},
+		{"maximum beats freeze", []StepLimit{{}, {FreezeScaleDown: true, MaxReplicas: 6}}, []int{8, 6, 2, 2, 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := p
+			p.StepLimits = tt.limits
+			if got := ToReplicas(8, forecast, 60, p, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToReplicas_CostStepLimits(t *testing.T) {
+	p := costPolicy(1, 10)
+	p.UpMaxFactorPerStep = 10
+	p.StepLimits = []StepLimit{{}, {MinReplicas: 6}, {FreezeScaleDown: true}}
+
+	got := ToReplicas(2, []float64{200, 200, 100}, 60, p, nil)
+	if want := []int{2, 6, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
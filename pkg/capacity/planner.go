@@ -12,6 +12,12 @@ type Policy struct {
 	// Must be > 0.
 	TargetPerPod float64
 
+	// Curve replaces TargetPerPod when set, for workloads whose throughput does not
+	// grow linearly with replicas: the planner needs the fewest replicas the curve
+	// says sustain the load. Used by ToReplicas; ToReplicasMulti and ToReplicasQueue
+	// ignore it.
+	Curve *Curve
+
 	// Headroom is a multiplicative safety factor (e.g., 1.2 for +20%).
 	// Used as fallback when quantiles are not available.
 	// Must be >= 1.0
@@ -39,9 +45,45 @@ type Policy struct {
 	// 0 = single point at i (conservative). N>0 = max over [i .. i+N] (aggressive).
 	PrewarmWindowSteps int
 
+	// PodStartupSeconds is how long a new pod takes to become ready. Demand is shifted
+	// earlier by this much, rounded up to whole steps, so replicas are requested in
+	// time to be ready when the load arrives; capacity is still held until the load
+	// has passed. Adds to PrewarmWindowSteps.
+	PodStartupSeconds int
+
+	// StepLimits replaces the bounds of individual forecast steps, indexed by step,
+	// with those of the scheduled overrides active then (see StepLimits). Steps beyond
+	// its length use MinReplicas and MaxReplicas.
+	StepLimits []StepLimit
+
 	// RoundingMode controls how fractional pods are turned into integers.
 	// "ceil" (default), "round", or "floor".
 	RoundingMode string
+
+	// ServiceRatePerPod is the number of queue items a pod processes per second.
+	// Used by ToReplicasQueue instead of TargetPerPod. Must be > 0.
+	ServiceRatePerPod float64
+
+	// DrainSeconds is the deadline, in seconds, by which ToReplicasQueue plans to
+	// have processed the current backlog plus the arrivals expected meanwhile.
+	// If <= 0, defaults to one step.
+	DrainSeconds int
+
+	// Planner selects how ToReplicas chooses replicas: "deterministic" (default) sizes
+	// each step for its forecast with headroom or quantile, "cost" minimises the
+	// expected cost of the whole path (see ReplicaHourCost and UnderProvisionPenalty).
+	// The cost planner needs MaxReplicas, which bounds its search; without it the
+	// deterministic planner is used.
+	Planner string
+
+	// ReplicaHourCost is the cost of running one replica for an hour, used by the
+	// cost planner.
+	ReplicaHourCost float64
+
+	// UnderProvisionPenalty is the cost of one pod-hour of demand left unserved, used
+	// by the cost planner. Relative to ReplicaHourCost it sets how much idle capacity
+	// is worth paying for to avoid under-provisioning.
+	UnderProvisionPenalty float64
 }
 
 // ToReplicas converts a forecasted load series into desired replicas, applying the policy.
@@ -50,10 +92,45 @@ type Policy struct {
 // stepSec is the step resolution in seconds.
 // quantiles optionally provides quantile forecasts. When provided and Policy.QuantileLevel > 0,
 // uses the specified quantile instead of applying headroom to the point forecast.
+// With the cost planner the whole quantile forecast is used instead (see Policy.Planner).
 func ToReplicas(prev int, forecast []float64, stepSec int, p Policy, quantiles map[float64][]float64) []int {
-	if len(forecast) == 0 {
-		return nil
+	res, _ := ToReplicasTrace(prev, forecast, stepSec, p, quantiles)
+	return res
+}
+
+// unreachablePods is the need reported for a load no replica count sustains while
+// adding replicas always helps, as for a USL without coherency cost. It is larger
+// than any need the curve reports for a lower load, and the replica bounds clamp it.
+const unreachablePods = math.MaxInt32
+
+// Pods returns the fractional replicas needed to sustain load: load / TargetPerPod,
+// or the inverse of Curve when set. A load beyond the curve's reach needs the
+// replicas sustaining the most load, or unreachablePods when adding replicas always
+// helps, so a higher load never needs fewer replicas.
+func (p Policy) Pods(load float64) float64 {
+	if p.Curve == nil {
+		if p.TargetPerPod <= 0 {
+			return 0
+		}
+		return load / p.TargetPerPod
+	}
+	pods := p.Curve.Replicas(load)
+	if math.IsInf(pods, 1) {
+		return unreachablePods
 	}
+	return pods
+}
+
+// load is the inverse of Pods: the load sustained by pods.
+func (p Policy) load(pods float64) float64 {
+	if p.Curve == nil {
+		return pods * p.TargetPerPod
+	}
+	return p.Curve.Load(pods)
+}
+
+// normalize fills in defaults for unset policy fields and clamps out-of-range ones.
+func normalize(p Policy) Policy {
 	if p.TargetPerPod <= 0 {
 		p.TargetPerPod = 1
 	}
@@ -66,9 +143,6 @@ func ToReplicas(prev int, forecast []float64, stepSec int, p Policy, quantiles m
 	if p.MaxReplicas > 0 && p.MaxReplicas < p.MinReplicas {
 		p.MaxReplicas = p.MinReplicas
 	}
-	if stepSec <= 0 {
-		stepSec = 60
-	}
 	if p.UpMaxFactorPerStep <= 0 {
 		p.UpMaxFactorPerStep = 2.0
 	}
@@ -81,10 +155,30 @@ func ToReplicas(prev int, forecast []float64, stepSec int, p Policy, quantiles m
 	if p.PrewarmWindowSteps < 0 {
 		p.PrewarmWindowSteps = 0
 	}
+	if p.PodStartupSeconds < 0 {
+		p.PodStartupSeconds = 0
+	}
+
+	return p
+}
+
+// startupSteps is the pod startup time in whole steps, rounded up.
+func startupSteps(p Policy, stepSec int) int {
+	if p.PodStartupSeconds <= 0 || stepSec <= 0 {
+		return 0
+	}
+	return (p.PodStartupSeconds + stepSec - 1) / stepSec
+}
 
+// loadToPods converts a load series into fractional pods needed at each step: the
+// configured quantile when available, otherwise the point forecast with headroom,
+// converted by toPods. It also returns the load planned for at each step and which
+// of the two was used.
+func loadToPods(forecast []float64, toPods func(float64) float64, p Policy, quantiles map[float64][]float64) ([]float64, []float64, string) {
 	useQuantile := p.QuantileLevel > 0 && quantiles != nil && len(quantiles[p.QuantileLevel]) == len(forecast)
 
-	adj := make([]float64, len(forecast))
+	pods := make([]float64, len(forecast))
+	loads := make([]float64, len(forecast))
 	for i, v := range forecast {
 		if v < 0 {
 			v = 0
@@ -100,37 +194,47 @@ func ToReplicas(prev int, forecast []float64, stepSec int, p Policy, quantiles m
 			capacityValue = v * p.Headroom
 		}
 
-		raw := capacityValue / p.TargetPerPod
-		adj[i] = raw
+		loads[i] = capacityValue
+		pods[i] = toPods(capacityValue)
 	}
+	if useQuantile {
+		return pods, loads, SourceQuantile
+	}
+	return pods, loads, SourceHeadroom
+}
 
-	res := make([]int, len(forecast))
+// plan turns fractional pod needs into replica counts, applying the prewarm window,
+// rounding, bounds, and change clamps. It also returns the trace of each step, with
+// the step whose need was used, which differs from the step itself when prewarming;
+// callers fill in the source of the need.
+func plan(prev int, pods []float64, p Policy) ([]int, []StepTrace) {
+	res := make([]int, len(pods))
+	trace := make([]StepTrace, len(pods))
 	prevOut := clampBounds(prev, p.MinReplicas, p.MaxReplicas)
 
-	for i := range forecast {
-		jStart := i
+	for i := range pods {
 		jEnd := i + p.PrewarmWindowSteps
-		if jEnd >= len(adj) {
-			jEnd = len(adj) - 1
+		if jEnd >= len(pods) {
+			jEnd = len(pods) - 1
 		}
-		need := 0.0
-		for j := jStart; j <= jEnd; j++ {
-			if adj[j] > need {
-				need = adj[j]
+		need, source := 0.0, i
+		for j := i; j <= jEnd; j++ {
+			if pods[j] > need {
+				need, source = pods[j], j
 			}
 		}
 
-		desired := roundPods(need, p.RoundingMode)
+		t := StepTrace{NeedStep: source, Need: need, Rounded: roundPods(need, p.RoundingMode)}
 
 		// Apply bounds, then change clamps, then bounds again.
-		desired = clampBounds(desired, p.MinReplicas, p.MaxReplicas)
-		desired = clampChange(prevOut, desired, p.UpMaxFactorPerStep, p.DownMaxPercentPerStep)
-		desired = clampBounds(desired, p.MinReplicas, p.MaxReplicas)
+		desired := clampStep(p, i, prevOut, t.Rounded, &t)
 
+		t.Replicas = desired
 		res[i] = desired
+		trace[i] = t
 		prevOut = desired
 	}
-	return res
+	return res, trace
 }
 
 func roundPods(x float64, mode string) int {
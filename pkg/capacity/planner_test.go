@@ -156,3 +156,26 @@ func TestToReplicas_QuantilesFallbackToHeadroom(t *testing.T) {
 		t.Fatalf("got %v, want %v (should use headroom, not quantiles)", got, want)
 	}
 }
+
+func TestToReplicas_PodStartup(t *testing.T) {
+	p := Policy{
+		TargetPerPod:          100,
+		Headroom:              1.0,
+		MinReplicas:           1,
+		MaxReplicas:           20,
+		UpMaxFactorPerStep:    10,
+		DownMaxPercentPerStep: 100,
+	}
+	forecast := []float64{100, 100, 500, 500, 100}
+
+	if got, want := ToReplicas(1, forecast, 60, p, nil), []int{1, 1, 5, 5, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("without startup: got %v, want %v", got, want)
+	}
+
+	// 90s rounds up to two 60s steps: the ramp at step 2 is requested at step 0, and
+	// capacity is held through step 3 when the load is still there.
+	p.PodStartupSeconds = 90
+	if got, want := ToReplicas(1, forecast, 60, p, nil), []int{5, 5, 5, 5, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("with startup: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+package capacity
+
+import "math"
+
+// ToReplicasQueue plans replicas for queue workers that must drain a backlog
+// before a deadline, rather than sustain a throughput. arrivals is the forecast
+// arrival rate (items per second) for each step and backlog the current queue depth.
+//
+// At each step i the planner needs
+//
+//	pods = (arrivals over the next DrainSeconds + backlog_i) / (ServiceRatePerPod × DrainSeconds)
+//
+// where arrivals use the policy's quantile or headroom as in ToReplicas, and the
+// last forecast rate is assumed beyond the horizon. The result is rounded, bounded,
+// and change-clamped like ToReplicas; PrewarmWindowSteps is not applied because the
+// drain window already looks ahead.
+//
+// The backlog is then simulated with the planned replicas and the point forecast:
+// backlog_{i+1} = max(0, backlog_i + arrivals_i×stepSec − replicas_i×ServiceRatePerPod×stepSec).
+// The second return value is that backlog at the end of each step, and the third the
+// trace of each step as in ToReplicasTrace, whose load is the items to drain.
+func ToReplicasQueue(prev int, arrivals []float64, backlog float64, stepSec int, p Policy, quantiles map[float64][]float64) ([]int, []float64, []StepTrace) {
+	if len(arrivals) == 0 {
+		return nil, nil, nil
+	}
+	p = normalize(p)
+	if stepSec <= 0 {
+		stepSec = 60
+	}
+	if p.ServiceRatePerPod <= 0 {
+		p.ServiceRatePerPod = 1
+	}
+	if p.DrainSeconds <= 0 {
+		p.DrainSeconds = stepSec
+	}
+	if backlog < 0 || math.IsNaN(backlog) {
+		backlog = 0
+	}
+
+	// The loads of loadToPods are the planning arrival rate per step.
+	_, planned, _ := loadToPods(arrivals, func(load float64) float64 { return load }, p, quantiles)
+	drainSteps := int(math.Ceil(float64(p.DrainSeconds) / float64(stepSec)))
+	step := float64(stepSec)
+
+	res := make([]int, len(arrivals))
+	trajectory := make([]float64, len(arrivals))
+	trace := make([]StepTrace, len(arrivals))
+	prevOut := clampBounds(prev, p.MinReplicas, p.MaxReplicas)
+
+	for i := range arrivals {
+		// Arrivals expected within the drain window, the last step possibly partial.
+		window := 0.0
+		remaining := float64(p.DrainSeconds)
+		for j := i; j < i+drainSteps; j++ {
+			rate := planned[min(j, len(planned)-1)]
+			window += rate * math.Min(step, remaining)
+			remaining -= step
+		}
+
+		need := (window + backlog) / (p.ServiceRatePerPod * float64(p.DrainSeconds))
+		t := StepTrace{
+			Source:   SourceQueue,
+			NeedStep: i,
+			Forecast: arrivals[i],
+			Load:     window + backlog,
+			Need:     need,
+			Rounded:  roundPods(need, p.RoundingMode),
+		}
+		desired := clampStep(p, i, prevOut, t.Rounded, &t)
+		t.Replicas = desired
+		trace[i] = t
+		res[i] = desired
+		prevOut = desired
+
+		arrived := math.Max(arrivals[i], 0) * step
+		processed := float64(desired) * p.ServiceRatePerPod * step
+		backlog = math.Max(0, backlog+arrived-processed)
+		trajectory[i] = backlog
+	}
+	return res, trajectory, trace
+}
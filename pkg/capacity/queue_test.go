@@ -0,0 +1,78 @@
+package capacity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToReplicasQueue_DrainsBacklog(t *testing.T) {
+	p := Policy{
+		Headroom:              1.0,
+		MinReplicas:           1,
+		MaxReplicas:           50,
+		UpMaxFactorPerStep:    10,
+		DownMaxPercentPerStep: 100,
+		ServiceRatePerPod:     1,
+		DrainSeconds:          300,
+	}
+	arrivals := []float64{2, 2, 2, 2, 2}
+
+	replicas, backlog, _ := ToReplicasQueue(1, arrivals, 600, 60, p, nil)
+	// Step 0: (2*300 + 600) / (1*300) = 4 pods; backlog 600 + 120 - 240 = 480.
+	// Step 3: (600 + 240) / 300 = 2.8 -> 3 pods; backlog 240 + 120 - 180 = 180.
+	if want := []int{4, 4, 4, 3, 3}; !reflect.DeepEqual(replicas, want) {
+		t.Errorf("replicas = %v, want %v", replicas, want)
+	}
+	if want := []float64{480, 360, 240, 180, 120}; !reflect.DeepEqual(backlog, want) {
+		t.Errorf("backlog = %v, want %v", backlog, want)
+	}
+}
+
+func TestToReplicasQueue_Quantile(t *testing.T) {
+	p := Policy{
+		QuantileLevel:         0.9,
+		MinReplicas:           0,
+		UpMaxFactorPerStep:    10,
+		DownMaxPercentPerStep: 100,
+		ServiceRatePerPod:     1,
+		DrainSeconds:          60,
+	}
+	arrivals := []float64{2, 2}
+	quantiles := map[float64][]float64{0.9: {3, 3}}
+
+	replicas, backlog, _ := ToReplicasQueue(3, arrivals, 0, 60, p, quantiles)
+	// Plans for the p90 arrival rate; the backlog is simulated with the point forecast.
+	if want := []int{3, 3}; !reflect.DeepEqual(replicas, want) {
+		t.Errorf("replicas = %v, want %v", replicas, want)
+	}
+	if want := []float64{0, 0}; !reflect.DeepEqual(backlog, want) {
+		t.Errorf("backlog = %v, want %v", backlog, want)
+	}
+}
+
+func TestToReplicasQueue_ClampedReplicasGrowBacklog(t *testing.T) {
+	p := Policy{
+		Headroom:              1.0,
+		MinReplicas:           1,
+		MaxReplicas:           2,
+		UpMaxFactorPerStep:    10,
+		DownMaxPercentPerStep: 100,
+		ServiceRatePerPod:     1,
+		DrainSeconds:          60,
+	}
+
+	replicas, backlog, _ := ToReplicasQueue(1, []float64{5, 5}, 0, 60, p, nil)
+	if want := []int{2, 2}; !reflect.DeepEqual(replicas, want) {
+		t.Errorf("replicas = %v, want %v", replicas, want)
+	}
+	// 5/s arrive, 2/s are processed: the backlog grows by 180 per step.
+	if want := []float64{180, 360}; !reflect.DeepEqual(backlog, want) {
+		t.Errorf("backlog = %v, want %v", backlog, want)
+	}
+}
+
+func TestToReplicasQueue_Empty(t *testing.T) {
+	if replicas, backlog, _ := ToReplicasQueue(1, nil, 10, 60, Policy{}, nil); replicas != nil || backlog != nil {
+		t.Errorf("ToReplicasQueue(nil) = %v, %v, want nil", replicas, backlog)
+	}
+}
@@ -0,0 +1,131 @@
+package capacity
+
+import (
+	"errors"
+	"math"
+	"slices"
+)
+
+// LoadSample is one observation of a running workload: the load it served, the
+// replicas serving it, and the latency it served it at.
+type LoadSample struct {
+	Load     float64
+	Replicas float64
+	Latency  float64
+}
+
+// RecommendOptions configures RecommendTargetPerPod.
+type RecommendOptions struct {
+	// LatencySLO is the latency the workload must stay within, in the unit of the
+	// samples' Latency.
+	LatencySLO float64
+
+	// MaxBreachRatio is the fraction of samples at a per-pod load that may exceed
+	// the SLO before that load is considered breaching. Defaults to 0.05.
+	MaxBreachRatio float64
+
+	// Bins is the number of equal-count per-pod load bins the samples are grouped
+	// into. Defaults to 20.
+	Bins int
+
+	// MinSamples is the number of samples needed for full confidence. Defaults to
+	// 1000, about a day at one-minute steps.
+	MinSamples int
+}
+
+// TargetRecommendation is a TargetPerPod learned from observed load and latency.
+type TargetRecommendation struct {
+	// TargetPerPod is the highest per-pod load observed to meet the SLO.
+	TargetPerPod float64
+
+	// Confidence, from 0 to 1, grows with the number of samples and with how
+	// clearly latency breaches the SLO above TargetPerPod but not below it.
+	Confidence float64
+
+	// Samples is the number of usable samples.
+	Samples int
+
+	// BreachObserved reports whether the samples reached a load that breaches the
+	// SLO. Without it TargetPerPod is only a lower bound, and Confidence is halved.
+	BreachObserved bool
+}
+
+// RecommendTargetPerPod learns the per-pod load a workload sustains within its
+// latency SLO. Samples are sorted by per-pod load (Load/Replicas) into equal-count
+// bins, and the target is the highest load of the last bin before the first one
+// whose breach ratio, and that of every sample above it, exceed MaxBreachRatio.
+// Requiring both keeps an isolated burst of slow requests at low load from pulling
+// the target down. Samples without replicas or with non-finite values are ignored.
+func RecommendTargetPerPod(samples []LoadSample, opts RecommendOptions) (TargetRecommendation, error) {
+	if opts.LatencySLO <= 0 {
+		return TargetRecommendation{}, errors.New("latency SLO must be > 0")
+	}
+	if opts.MaxBreachRatio <= 0 {
+		opts.MaxBreachRatio = 0.05
+	}
+	if opts.Bins <= 0 {
+		opts.Bins = 20
+	}
+	if opts.MinSamples <= 0 {
+		opts.MinSamples = 1000
+	}
+
+	type point struct {
+		perPod float64
+		breach bool
+	}
+	points := make([]point, 0, len(samples))
+	for _, s := range samples {
+		if s.Replicas <= 0 || s.Load < 0 || math.IsNaN(s.Latency) || math.IsInf(s.Load+s.Replicas+s.Latency, 0) {
+			continue
+		}
+		points = append(points, point{perPod: s.Load / s.Replicas, breach: s.Latency > opts.LatencySLO})
+	}
+	if len(points) < 2*opts.Bins {
+		return TargetRecommendation{}, errors.New("not enough samples to recommend a target")
+	}
+	slices.SortFunc(points, func(a, b point) int {
+		switch {
+		case a.perPod < b.perPod:
+			return -1
+		case a.perPod > b.perPod:
+			return 1
+		}
+		return 0
+	})
+
+	// breaches[i] counts the breaching samples among points[i:].
+	breaches := make([]int, len(points)+1)
+	for i := len(points) - 1; i >= 0; i-- {
+		breaches[i] = breaches[i+1]
+		if points[i].breach {
+			breaches[i]++
+		}
+	}
+	ratio := func(from, to int) float64 {
+		return float64(breaches[from]-breaches[to]) / float64(to-from)
+	}
+
+	rec := TargetRecommendation{Samples: len(points)}
+	cut := len(points)
+	for b := range opts.Bins {
+		from, to := b*len(points)/opts.Bins, (b+1)*len(points)/opts.Bins
+		if ratio(from, to) > opts.MaxBreachRatio && ratio(from, len(points)) > opts.MaxBreachRatio {
+			cut = from
+			rec.BreachObserved = true
+			break
+		}
+	}
+	if cut == 0 {
+		return TargetRecommendation{}, errors.New("latency SLO is breached even at the lowest observed load")
+	}
+	rec.TargetPerPod = points[cut-1].perPod
+
+	contrast := 0.5
+	if rec.BreachObserved {
+		below, above := ratio(0, cut), ratio(cut, len(points))
+		contrast = max(0, 1-below/above)
+	}
+	rec.Confidence = min(1, float64(len(points))/float64(opts.MinSamples)) * contrast
+	return rec, nil
+}
@@ -0,0 +1,71 @@
+package capacity
+
+import (
+	"math"
+	"testing"
+)
+
+// saturatingSamples serves 1..n per-pod load on 4 replicas, with latency crossing
+// 200ms above saturation.
+func saturatingSamples(n int, saturation float64) []LoadSample {
+	samples := make([]LoadSample, n)
+	for i := range samples {
+		perPod := float64(i + 1)
+		latency := 0.05
+		if perPod > saturation {
+			latency = 0.5
+		}
+		samples[i] = LoadSample{Load: 4 * perPod, Replicas: 4, Latency: latency}
+	}
+	return samples
+}
+
+func TestRecommendTargetPerPod(t *testing.T) {
+	samples := saturatingSamples(1000, 700)
+	// A burst of slow requests at low load is not a saturation point.
+	samples[100].Latency = 0.5
+
+	rec, err := RecommendTargetPerPod(samples, RecommendOptions{LatencySLO: 0.2})
+	if err != nil {
+		t.Fatalf("RecommendTargetPerPod() error = %v", err)
+	}
+	if rec.TargetPerPod != 700 || !rec.BreachObserved || rec.Samples != 1000 {
+		t.Errorf("recommendation = %+v, want target 700 with a breach observed over 1000 samples", rec)
+	}
+	if want := 1 - (1.0/700)/1.0; math.Abs(rec.Confidence-want) > 1e-9 {
+		t.Errorf("Confidence = %v, want %v", rec.Confidence, want)
+	}
+}
+
+func TestRecommendTargetPerPod_NoBreach(t *testing.T) {
+	samples := saturatingSamples(500, math.Inf(1))
+	samples = append(samples, LoadSample{Load: 100, Replicas: 0, Latency: 9})
+
+	rec, err := RecommendTargetPerPod(samples, RecommendOptions{LatencySLO: 0.2})
+	if err != nil {
+		t.Fatalf("RecommendTargetPerPod() error = %v", err)
+	}
+	// Only a lower bound: the highest load seen, with halved confidence.
+	if rec.TargetPerPod != 500 || rec.BreachObserved || rec.Confidence != 0.25 {
+		t.Errorf("recommendation = %+v, want target 500, no breach, confidence 0.25", rec)
+	}
+}
+
+func TestRecommendTargetPerPod_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []LoadSample
+		opts    RecommendOptions
+	}{
+		{"no SLO", saturatingSamples(100, 50), RecommendOptions{}},
+		{"too few samples", saturatingSamples(10, 5), RecommendOptions{LatencySLO: 0.2}},
+		{"always breaching", saturatingSamples(100, 0), RecommendOptions{LatencySLO: 0.2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RecommendTargetPerPod(tt.samples, tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
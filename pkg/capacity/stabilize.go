@@ -0,0 +1,168 @@
+package capacity
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Stabilization damps replica changes across successive plans of a workload, in the
+// manner of the Kubernetes HPA behavior policies. The zero value disables it.
+type Stabilization struct {
+	// ScaleDownWindowSeconds keeps each step at the highest replicas recommended for
+	// any time within this many seconds before it, by the plan itself or an earlier
+	// one, so replicas only decrease once the window has seen lower recommendations.
+	ScaleDownWindowSeconds int
+
+	// ScaleUpHoldSeconds keeps replicas from decreasing for this many seconds after
+	// a scale-up.
+	ScaleUpHoldSeconds int
+
+	// DeadBandReplicas and DeadBandPercent ignore a change from the previous step of
+	// at most this many replicas, or at most this percentage of the previous step.
+	DeadBandReplicas int
+	DeadBandPercent  int
+}
+
+// Enabled reports whether any stabilization rule is set.
+func (s Stabilization) Enabled() bool {
+	return s.ScaleDownWindowSeconds > 0 || s.ScaleUpHoldSeconds > 0 || s.DeadBandReplicas > 0 || s.DeadBandPercent > 0
+}
+
+// withinDeadBand reports whether moving from prev to next is too small to act on.
+func (s Stabilization) withinDeadBand(prev, next int) bool {
+	diff := next - prev
+	if diff < 0 {
+		diff = -diff
+	}
+	if s.DeadBandReplicas > 0 && diff <= s.DeadBandReplicas {
+		return true
+	}
+	return s.DeadBandPercent > 0 && float64(diff*100) <= float64(s.DeadBandPercent*prev)
+}
+
+// Stabilizer applies a Stabilization to the successive plans of one workload,
+// remembering the recommendations and scale-ups of earlier plans. Safe for
+// concurrent use, so a rebuilt forecaster can take over the stabilizer of the one
+// it replaces while that one finishes its last tick.
+type Stabilizer struct {
+	Stabilization
+
+	mu sync.Mutex
+
+	// recommendations are the unstabilized first steps of earlier plans still
+	// within the scale-down window.
+	recommendations []recommendation
+
+	// published is the first step of the last stabilized plan, and hasPublished
+	// whether there was one.
+	published    int
+	hasPublished bool
+
+	// holdUntil and holdReplicas are the end and level of the current scale-up hold.
+	holdUntil    time.Time
+	holdReplicas int
+}
+
+// recommendation is the replicas a plan recommended for a point in time.
+type recommendation struct {
+	at       time.Time
+	replicas int
+}
+
+// NewStabilizer returns a Stabilizer for s with no history.
+func NewStabilizer(s Stabilization) *Stabilizer {
+	return &Stabilizer{Stabilization: s}
+}
+
+// Stabilize applies the stabilization rules to replicas, a plan whose first step
+// starts at start, and records the plan for the next call. At each step the
+// scale-down window raises the replicas to the highest recommendation within the
+// window, a scale-up hold keeps them at the level of a recent scale-up, and the dead
+// band holds them at the previous step; the bounds of p still apply last. Each rule
+// that changes a step is recorded in its entry of trace, when there is one, whose
+// Replicas are updated.
+func (s *Stabilizer) Stabilize(start time.Time, stepSec int, replicas []int, p Policy, trace []StepTrace) []int {
+	if len(replicas) == 0 {
+		return replicas
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p = normalize(p)
+	step := time.Duration(stepSec) * time.Second
+	window := time.Duration(s.ScaleDownWindowSeconds) * time.Second
+	hold := time.Duration(s.ScaleUpHoldSeconds) * time.Second
+
+	out := make([]int, len(replicas))
+	prev, hasPrev := s.published, s.hasPublished
+	holdUntil, holdReplicas := s.holdUntil, s.holdReplicas
+	for i, x := range replicas {
+		at := start.Add(time.Duration(i) * step)
+		var scratch StepTrace
+		t := &scratch
+		if i < len(trace) {
+			t = &trace[i]
+		}
+
+		if window > 0 {
+			x = t.record(ClampScaleDownWindow, x, max(x, s.windowMax(at.Add(-window), start, step, replicas[:i])))
+		}
+		if at.Before(holdUntil) && x < holdReplicas {
+			x = t.record(ClampScaleUpHold, x, holdReplicas)
+		}
+		if hasPrev && s.withinDeadBand(prev, x) {
+			x = t.record(ClampDeadBand, x, prev)
+		}
+		from := prev
+		if !hasPrev {
+			from = x
+		}
+		x = stepBounds(p, i, from).apply(x, t)
+
+		if hold > 0 && hasPrev && x > prev {
+			holdUntil, holdReplicas = at.Add(hold), x
+		}
+		if i == 0 {
+			s.commit(start, replicas[0], x, holdUntil, holdReplicas, window)
+		}
+		t.Replicas = x
+		out[i] = x
+		prev, hasPrev = x, true
+	}
+	return out
+}
+
+// windowMax returns the highest recommendation after from: those of earlier plans,
+// and the planned steps from start.
+func (s *Stabilizer) windowMax(from, start time.Time, step time.Duration, planned []int) int {
+	highest := math.MinInt
+	for _, r := range s.recommendations {
+		if r.at.After(from) {
+			highest = max(highest, r.replicas)
+		}
+	}
+	for j, replicas := range planned {
+		if start.Add(time.Duration(j) * step).After(from) {
+			highest = max(highest, replicas)
+		}
+	}
+	return highest
+}
+
+// commit records the first step of a plan: its recommendation, the stabilized
+// replicas published for it, and the scale-up hold in force.
+func (s *Stabilizer) commit(at time.Time, recommended, published int, holdUntil time.Time, holdReplicas int, window time.Duration) {
+	kept := s.recommendations[:0]
+	for _, r := range s.recommendations {
+		if r.at.After(at.Add(-window)) {
+			kept = append(kept, r)
+		}
+	}
+	if window > 0 {
+		kept = append(kept, recommendation{at: at, replicas: recommended})
+	}
+	s.recommendations = kept
+	s.published, s.hasPublished = published, true
+	s.holdUntil, s.holdReplicas = holdUntil, holdReplicas
+}
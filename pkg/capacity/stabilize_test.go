@@ -0,0 +1,72 @@
+package capacity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStabilizer_ScaleDownWindow(t *testing.T) {
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	s := NewStabilizer(Stabilization{ScaleDownWindowSeconds: 180})
+	p := Policy{MinReplicas: 1}
+
+	trace := make([]StepTrace, 4)
+	got := s.Stabilize(start, 60, []int{10, 6, 6, 6}, p, trace)
+	if want := []int{10, 10, 10, 6}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("first plan = %v, want %v", got, want)
+	}
+	if want := []Clamp{{ClampScaleDownWindow, 6, 10}}; !reflect.DeepEqual(trace[1].Clamps, want) || trace[1].Replicas != 10 {
+		t.Errorf("trace[1] = %+v, want clamps %v and 10 replicas", trace[1], want)
+	}
+
+	// The next plan still sees the earlier recommendation of 10 within the window.
+	got = s.Stabilize(start.Add(time.Minute), 60, []int{6, 6, 6, 6}, p, nil)
+	if want := []int{10, 10, 6, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("second plan = %v, want %v", got, want)
+	}
+}
+
+func TestStabilizer_ScaleUpHold(t *testing.T) {
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	s := NewStabilizer(Stabilization{ScaleUpHoldSeconds: 120})
+	p := Policy{MinReplicas: 1}
+
+	if got := s.Stabilize(start, 60, []int{4, 4}, p, nil); !reflect.DeepEqual(got, []int{4, 4}) {
+		t.Fatalf("first plan = %v, want [4 4]", got)
+	}
+	got := s.Stabilize(start.Add(time.Minute), 60, []int{8, 3, 3, 3}, p, nil)
+	if want := []int{8, 8, 3, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("scale-up plan = %v, want %v", got, want)
+	}
+	got = s.Stabilize(start.Add(2*time.Minute), 60, []int{3, 3}, p, nil)
+	if want := []int{8, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("held plan = %v, want %v", got, want)
+	}
+}
+
+func TestStabilizer_DeadBand(t *testing.T) {
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		s      Stabilization
+		limits []StepLimit
+		next   []int
+		want   []int
+	}{
+		{"replicas", Stabilization{DeadBandReplicas: 1}, nil, []int{11, 9, 12, 13}, []int{10, 10, 12, 12}},
+		{"percent", Stabilization{DeadBandPercent: 10}, nil, []int{11, 12, 13, 14}, []int{10, 12, 12, 14}},
+		{"minimum beats the band", Stabilization{DeadBandReplicas: 2}, []StepLimit{{MinReplicas: 11}}, []int{11, 11}, []int{11, 11}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStabilizer(tt.s)
+			s.Stabilize(start, 60, []int{10}, Policy{MinReplicas: 1}, nil)
+			got := s.Stabilize(start.Add(time.Minute), 60, tt.next, Policy{MinReplicas: 1, StepLimits: tt.limits}, nil)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Stabilize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
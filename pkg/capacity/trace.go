@@ -0,0 +1,140 @@
+package capacity
+
+import "context"
+
+// Sources of a step's need, reported in StepTrace.Source.
+const (
+	// SourceQuantile: the need is the forecast quantile at Policy.QuantileLevel.
+	SourceQuantile = "quantile"
+	// SourceHeadroom: the need is the point forecast times Policy.Headroom.
+	SourceHeadroom = "headroom"
+	// SourceQueue: the need drains the backlog and the arrivals within DrainSeconds.
+	SourceQueue = "queue"
+	// SourceCost: the replicas were chosen by the cost planner.
+	SourceCost = "cost"
+)
+
+// Rules a clamp can apply, reported in Clamp.Rule.
+const (
+	ClampMinReplicas     = "minReplicas"
+	ClampMaxReplicas     = "maxReplicas"
+	ClampOverrideMin     = "overrideMinReplicas"
+	ClampOverrideMax     = "overrideMaxReplicas"
+	ClampFreezeScaleDown = "freezeScaleDown"
+	ClampReplicaBudget   = "replicaBudget"
+	ClampUpMaxFactor     = "upMaxFactorPerStep"
+	ClampDownMaxPercent  = "downMaxPercentPerStep"
+	ClampScaleDownWindow = "scaleDownStabilization"
+	ClampScaleUpHold     = "scaleUpHold"
+	ClampDeadBand        = "deadBand"
+)
+
+// StepTrace records how the planner arrived at the replicas of one step.
+type StepTrace struct {
+	// Source is where the need came from: SourceQuantile, SourceHeadroom,
+	// SourceQueue, or SourceCost.
+	Source string `json:"source"`
+
+	// Metric is the binding metric of a multi-metric plan.
+	Metric string `json:"metric,omitempty"`
+
+	// NeedStep is the step whose need was planned for. It is later than the step
+	// itself when the prewarm window or pod startup time looks ahead.
+	NeedStep int `json:"needStep"`
+
+	// Forecast is the point forecast at NeedStep, and Load the value planned for:
+	// the quantile, the forecast with headroom, or the items to drain in queue mode.
+	Forecast float64 `json:"forecast"`
+	Load     float64 `json:"load"`
+
+	// Need is the fractional pods required; under the cost planner, the expected
+	// pods demanded.
+	Need float64 `json:"need"`
+
+	// Rounded is Need after the rounding mode.
+	Rounded int `json:"rounded"`
+
+	// Clamps lists, in order, every bound or change limit that altered the value.
+	Clamps []Clamp `json:"clamps,omitempty"`
+
+	// Replicas is the planned replica count.
+	Replicas int `json:"replicas"`
+}
+
+// Clamp is one bound or change limit applied to a step, moving it From one replica
+// count To another.
+type Clamp struct {
+	Rule string `json:"rule"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// ToReplicasTrace is ToReplicas that also returns the trace of each step: the need
+// and its source, the rounding, and every clamp applied.
+func ToReplicasTrace(prev int, forecast []float64, stepSec int, p Policy, quantiles map[float64][]float64) ([]int, []StepTrace) {
+	res, trace, _ := ToReplicasTraceContext(context.Background(), prev, forecast, stepSec, p, quantiles)
+	return res, trace
+}
+
+// ToReplicasTraceContext is ToReplicasTrace that gives up once ctx is done,
+// returning its error. Only the cost planner, whose search grows with MaxReplicas,
+// checks ctx.
+func ToReplicasTraceContext(ctx context.Context, prev int, forecast []float64, stepSec int, p Policy, quantiles map[float64][]float64) ([]int, []StepTrace, error) {
+	if len(forecast) == 0 {
+		return nil, nil, nil
+	}
+	p = normalize(p)
+	if p.Planner == "cost" && p.MaxReplicas > 0 {
+		return optimize(ctx, prev, forecast, stepSec, p, quantiles)
+	}
+	p.PrewarmWindowSteps += startupSteps(p, stepSec)
+	pods, loads, source := loadToPods(forecast, p.Pods, p, quantiles)
+	res, trace := plan(prev, pods, p)
+	for i := range trace {
+		t := &trace[i]
+		t.Source = source
+		t.Forecast = forecast[t.NeedStep]
+		t.Load = loads[t.NeedStep]
+	}
+	return res, trace, nil
+}
+
+// bounds are the replica bounds of a step and the rules that set them.
+type bounds struct {
+	lo, hi         int
+	loRule, hiRule string
+}
+
+// apply bounds x, recording the clamp in t.
+func (b bounds) apply(x int, t *StepTrace) int {
+	if b.hi > 0 && x > b.hi {
+		return t.record(b.hiRule, x, b.hi)
+	}
+	if x < b.lo {
+		return t.record(b.loRule, x, b.lo)
+	}
+	return x
+}
+
+// clampStep applies the bounds of step i, the change clamps from prev, and the bounds
+// again to desired, recording each clamp in t.
+func clampStep(p Policy, i, prev, desired int, t *StepTrace) int {
+	b := stepBounds(p, i, prev)
+	desired = b.apply(desired, t)
+	changed := clampChange(prev, desired, p.UpMaxFactorPerStep, p.DownMaxPercentPerStep)
+	if changed < desired {
+		desired = t.record(ClampUpMaxFactor, desired, changed)
+	} else {
+		desired = t.record(ClampDownMaxPercent, desired, changed)
+	}
+	return b.apply(desired, t)
+}
+
+// record appends a clamp from one value to another unless it left the value
+// unchanged, and returns the new value.
+func (t *StepTrace) record(rule string, from, to int) int {
+	if from != to {
+		t.Clamps = append(t.Clamps, Clamp{Rule: rule, From: from, To: to})
+	}
+	return to
+}
@@ -0,0 +1,92 @@
+package capacity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToReplicasTrace_Clamps(t *testing.T) {
+	p := Policy{
+		TargetPerPod:          100,
+		Headroom:              1.0,
+		MinReplicas:           2,
+		MaxReplicas:           8,
+		UpMaxFactorPerStep:    2.0,
+		DownMaxPercentPerStep: 50,
+	}
+
+	replicas, trace := ToReplicasTrace(2, []float64{600, 1200, 120}, 60, p, nil)
+	if want := ToReplicas(2, []float64{600, 1200, 120}, 60, p, nil); !reflect.DeepEqual(replicas, want) {
+		t.Fatalf("ToReplicasTrace() = %v, ToReplicas() = %v", replicas, want)
+	}
+
+	want := []StepTrace{
+		{Source: SourceHeadroom, NeedStep: 0, Forecast: 600, Load: 600, Need: 6, Rounded: 6,
+			Clamps: []Clamp{{ClampUpMaxFactor, 6, 4}}, Replicas: 4},
+		{Source: SourceHeadroom, NeedStep: 1, Forecast: 1200, Load: 1200, Need: 12, Rounded: 12,
+			Clamps: []Clamp{{ClampMaxReplicas, 12, 8}}, Replicas: 8},
+		{Source: SourceHeadroom, NeedStep: 2, Forecast: 120, Load: 120, Need: 1.2, Rounded: 2,
+			Clamps: []Clamp{{ClampDownMaxPercent, 2, 4}}, Replicas: 4},
+	}
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace = %+v\nwant    %+v", trace, want)
+	}
+}
+
+func TestToReplicasTrace_OverridesAndQuantile(t *testing.T) {
+	p := Policy{
+		TargetPerPod:          100,
+		Headroom:              1.0,
+		QuantileLevel:         0.9,
+		MinReplicas:           1,
+		MaxReplicas:           10,
+		UpMaxFactorPerStep:    2.0,
+		DownMaxPercentPerStep: 50,
+		StepLimits: []StepLimit{
+			{},
+			{MinReplicas: 6, Overrides: []string{"peak"}},
+			{FreezeScaleDown: true, Overrides: []string{"deploy"}},
+		},
+	}
+	forecast := []float64{80, 80, 80}
+	quantiles := map[float64][]float64{0.9: {100, 100, 100}}
+
+	replicas, trace := ToReplicasTrace(2, forecast, 60, p, quantiles)
+	if want := []int{1, 6, 6}; !reflect.DeepEqual(replicas, want) {
+		t.Fatalf("replicas = %v, want %v", replicas, want)
+	}
+	// The override minimum beats the up clamp at its own step.
+	wantClamps := [][]Clamp{
+		nil,
+		{{ClampOverrideMin, 1, 6}, {ClampUpMaxFactor, 6, 2}, {ClampOverrideMin, 2, 6}},
+		{{ClampFreezeScaleDown, 1, 6}},
+	}
+	for i, step := range trace {
+		if step.Source != SourceQuantile || step.Forecast != 80 || step.Load != 100 {
+			t.Errorf("trace[%d] source = %s, forecast = %v, load = %v, want quantile, 80, 100", i, step.Source, step.Forecast, step.Load)
+		}
+		if !reflect.DeepEqual(step.Clamps, wantClamps[i]) {
+			t.Errorf("trace[%d].Clamps = %v, want %v", i, step.Clamps, wantClamps[i])
+		}
+	}
+}
+
+func TestToReplicasTrace_PrewarmNeedStep(t *testing.T) {
+	p := Policy{TargetPerPod: 100, Headroom: 1.0, UpMaxFactorPerStep: 10, DownMaxPercentPerStep: 100, PodStartupSeconds: 60}
+
+	_, trace := ToReplicasTrace(1, []float64{100, 500, 100}, 60, p, nil)
+	for i, want := range []int{1, 1, 2} {
+		if trace[i].NeedStep != want {
+			t.Errorf("trace[%d].NeedStep = %d, want %d", i, trace[i].NeedStep, want)
+		}
+	}
+}
+
+func TestToReplicasTrace_Cost(t *testing.T) {
+	replicas, trace := ToReplicasTrace(3, []float64{250, 250}, 60, costPolicy(1, 10), nil)
+	for i, step := range trace {
+		if step.Source != SourceCost || step.Replicas != replicas[i] || step.Need != 2.5 {
+			t.Errorf("trace[%d] = %+v, want cost source, need 2.5, replicas %d", i, step, replicas[i])
+		}
+	}
+}
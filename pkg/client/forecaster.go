@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/HatiCode/kedastral/pkg/capacity"
 	"github.com/HatiCode/kedastral/pkg/storage"
 )
 
@@ -51,6 +52,17 @@ type SnapshotResponse struct {
 	HorizonSeconds  int       `json:"horizonSeconds"`
 	Values          []float64 `json:"values"`
 	DesiredReplicas []int     `json:"desiredReplicas"`
+	Model           string    `json:"model,omitempty"`
+
+	Signals        []storage.SignalForecast `json:"signals,omitempty"`
+	BindingMetrics []string                 `json:"bindingMetrics,omitempty"`
+
+	QueueDepth float64   `json:"queueDepth,omitempty"`
+	Backlog    []float64 `json:"backlog,omitempty"`
+
+	Overrides []storage.ActiveOverride `json:"overrides,omitempty"`
+
+	Trace []capacity.StepTrace `json:"trace,omitempty"`
 }
 
 // SnapshotResult contains the snapshot and metadata about staleness.
@@ -111,6 +123,13 @@ func (c *ForecasterClient) GetSnapshot(ctx context.Context, workload string) (*S
 		HorizonSeconds:  snapshotResp.HorizonSeconds,
 		Values:          snapshotResp.Values,
 		DesiredReplicas: snapshotResp.DesiredReplicas,
+		Model:           snapshotResp.Model,
+		Signals:         snapshotResp.Signals,
+		BindingMetrics:  snapshotResp.BindingMetrics,
+		QueueDepth:      snapshotResp.QueueDepth,
+		Backlog:         snapshotResp.Backlog,
+		Overrides:       snapshotResp.Overrides,
+		Trace:           snapshotResp.Trace,
 	}
 
 	return &SnapshotResult{
@@ -6,7 +6,8 @@
 // be combined with standard units (e.g. "1w2d3h", "168h", "7d", "90m").
 //
 // It also provides flag helpers (Var, Duration) that drop in for flag.DurationVar and
-// flag.Duration so command-line flags accept the extended syntax.
+// flag.Duration so command-line flags accept the extended syntax, and ParseSchedule,
+// which parses cron expressions and time windows into a Schedule.
 package durationx
 
 import (
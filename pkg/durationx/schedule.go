@@ -0,0 +1,279 @@
+package durationx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a set of time windows: recurring windows opened by a cron expression,
+// recurring daily or weekly windows, or a single fixed interval.
+type Schedule struct {
+	cron     *cronExpr
+	duration time.Duration
+
+	start, end time.Time
+
+	loc *time.Location
+}
+
+// ParseSchedule parses a schedule in one of three forms:
+//
+//	"<cron> for <duration>"     "0 8 * * 1-5 for 2h"
+//	"[<days>] HH:MM-HH:MM"      "Mon-Fri 08:00-10:00", "Sat,Sun 22:00-06:00"
+//	"<start>/<end>"             "2026-11-27/2026-12-01T06:00"
+//
+// Cron expressions have the five standard fields (minute, hour, day of month, month,
+// day of week) with lists, ranges, steps, and month and weekday names. A window whose
+// end is not after its start ends the next day; the days select the day it starts.
+// Fixed intervals accept RFC3339 times, or dates and times without an offset.
+//
+// Times are interpreted in loc, or UTC when loc is nil.
+func ParseSchedule(spec string, loc *time.Location) (Schedule, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return Schedule{}, fmt.Errorf("empty schedule")
+	}
+
+	if expr, length, ok := strings.Cut(spec, " for "); ok {
+		cron, err := parseCron(expr)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		duration, err := Parse(length)
+		if err != nil || duration <= 0 {
+			return Schedule{}, fmt.Errorf("invalid schedule %q: duration must be a positive duration", spec)
+		}
+		return Schedule{cron: cron, duration: duration, loc: loc}, nil
+	}
+
+	if from, to, ok := strings.Cut(spec, "/"); ok {
+		start, err := parseTime(from, loc)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		end, err := parseTime(to, loc)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		if !end.After(start) {
+			return Schedule{}, fmt.Errorf("invalid schedule %q: end must be after start", spec)
+		}
+		return Schedule{start: start, end: end, loc: loc}, nil
+	}
+
+	schedule, err := parseWindow(spec, loc)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	return schedule, nil
+}
+
+// Active reports whether t falls inside one of the schedule's windows.
+func (s Schedule) Active(t time.Time) bool {
+	if s.cron == nil {
+		return !t.Before(s.start) && t.Before(s.end)
+	}
+	fire, ok := s.cron.latest(t.In(s.loc), t.Add(-s.duration))
+	return ok && t.Before(fire.Add(s.duration))
+}
+
+// parseWindow parses "[<days>] HH:MM-HH:MM" into the equivalent cron schedule.
+func parseWindow(spec string, loc *time.Location) (Schedule, error) {
+	days, window := "*", spec
+	if fields := strings.Fields(spec); len(fields) == 2 {
+		days, window = fields[0], fields[1]
+	} else if len(fields) != 1 {
+		return Schedule{}, fmt.Errorf("want \"<cron> for <duration>\", \"[days] HH:MM-HH:MM\", or \"<start>/<end>\"")
+	}
+
+	from, to, ok := strings.Cut(window, "-")
+	if !ok {
+		return Schedule{}, fmt.Errorf("time window %q must be HH:MM-HH:MM", window)
+	}
+	start, err := time.Parse("15:04", from)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("invalid start time %q", from)
+	}
+	end, err := time.Parse("15:04", to)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("invalid end time %q", to)
+	}
+	duration := end.Sub(start)
+	if duration <= 0 {
+		duration += day
+	}
+
+	cron, err := parseCron(fmt.Sprintf("%d %d * * %s", start.Minute(), start.Hour(), days))
+	if err != nil {
+		return Schedule{}, err
+	}
+	return Schedule{cron: cron, duration: duration, loc: loc}, nil
+}
+
+// parseTime parses an RFC3339 time, or a date or date-time without offset in loc.
+func parseTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (want RFC3339, YYYY-MM-DD, or YYYY-MM-DDTHH:MM)", value)
+}
+
+// cronExpr is a parsed five-field cron expression; each field is a bitmask of the
+// values it matches.
+type cronExpr struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny record "*" fields: when both day fields are restricted, a day
+	// matching either one matches, as in standard cron.
+	domAny, dowAny bool
+}
+
+var (
+	monthNames   = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	weekdayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+func parseCron(expr string) (*cronExpr, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	var c cronExpr
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if c.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if c.dow, err = parseCronField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	// 7 is an alias for Sunday.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domAny = strings.HasPrefix(fields[2], "*")
+	c.dowAny = strings.HasPrefix(fields[4], "*")
+	return &c, nil
+}
+
+// parseCronField parses a comma-separated list of "*", values, and ranges, each with
+// an optional "/step", into a bitmask.
+func parseCronField(field string, lo, hi int, names map[string]int) (uint64, error) {
+	var mask uint64
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		from, to := lo, hi
+		if rangePart != "*" {
+			first, last, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if from, err = cronValue(first, lo, hi, names); err != nil {
+				return 0, err
+			}
+			to = from
+			if isRange {
+				if to, err = cronValue(last, lo, hi, names); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				to = hi
+			}
+			if to < from {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		}
+
+		for v := from; v <= to; v += step {
+			mask |= 1 << v
+		}
+	}
+	return mask, nil
+}
+
+func cronValue(value string, lo, hi int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil || v < lo || v > hi {
+		return 0, fmt.Errorf("value %q out of range [%d, %d]", value, lo, hi)
+	}
+	return v, nil
+}
+
+func (c *cronExpr) dayMatches(t time.Time) bool {
+	if c.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	dom := c.dom&(1<<t.Day()) != 0
+	dow := c.dow&(1<<int(t.Weekday())) != 0
+	if !c.domAny && !c.dowAny {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// latest returns the latest time the expression fires at or before t, searching no
+// further back than notBefore.
+func (c *cronExpr) latest(t, notBefore time.Time) (time.Time, bool) {
+	loc := t.Location()
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	for first := true; !date.Add(24 * time.Hour).Before(notBefore); first = false {
+		if c.dayMatches(date) {
+			lastHour := 23
+			if first {
+				lastHour = t.Hour()
+			}
+			for h := lastHour; h >= 0; h-- {
+				if c.hour&(1<<h) == 0 {
+					continue
+				}
+				lastMinute := 59
+				if first && h == t.Hour() {
+					lastMinute = t.Minute()
+				}
+				for m := lastMinute; m >= 0; m-- {
+					if c.minute&(1<<m) == 0 {
+						continue
+					}
+					fire := time.Date(date.Year(), date.Month(), date.Day(), h, m, 0, 0, loc)
+					if fire.After(t) {
+						continue
+					}
+					if fire.Before(notBefore) {
+						return time.Time{}, false
+					}
+					return fire, true
+				}
+			}
+		}
+		date = date.AddDate(0, 0, -1)
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,88 @@
+package durationx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule_Active(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		spec string
+		loc  *time.Location
+		at   time.Time
+		want bool
+	}{
+		// 2026-10-19 is a Monday.
+		{"weekday window inside", "Mon-Fri 08:00-10:00", paris, time.Date(2026, 10, 19, 9, 30, 0, 0, paris), true},
+		{"weekday window end is exclusive", "Mon-Fri 08:00-10:00", paris, time.Date(2026, 10, 19, 10, 0, 0, 0, paris), false},
+		{"weekday window on saturday", "Mon-Fri 08:00-10:00", paris, time.Date(2026, 10, 24, 9, 0, 0, 0, paris), false},
+		{"window in another zone", "Mon-Fri 08:00-10:00", paris, time.Date(2026, 10, 19, 7, 30, 0, 0, time.UTC), true},
+		{"overnight window after midnight", "Fri 22:00-06:00", nil, time.Date(2026, 10, 24, 5, 0, 0, 0, time.UTC), true},
+		{"overnight window starts friday only", "Fri 22:00-06:00", nil, time.Date(2026, 10, 25, 5, 0, 0, 0, time.UTC), false},
+		{"daily window", "12:00-13:00", nil, time.Date(2026, 10, 25, 12, 59, 0, 0, time.UTC), true},
+		{"cron with duration", "30 2 * * sun for 90m", nil, time.Date(2026, 10, 25, 3, 45, 0, 0, time.UTC), true},
+		{"cron before first fire", "30 2 * * sun for 90m", nil, time.Date(2026, 10, 25, 2, 29, 0, 0, time.UTC), false},
+		{"cron spanning days", "0 0 27 nov * for 4d", nil, time.Date(2026, 11, 30, 23, 0, 0, 0, time.UTC), true},
+		{"cron step", "*/15 * * * * for 5m", nil, time.Date(2026, 10, 25, 3, 47, 0, 0, time.UTC), true},
+		{"cron step gap", "*/15 * * * * for 5m", nil, time.Date(2026, 10, 25, 3, 52, 0, 0, time.UTC), false},
+		{"fixed interval", "2026-11-27/2026-12-01T06:00", nil, time.Date(2026, 11, 28, 0, 0, 0, 0, time.UTC), true},
+		{"fixed interval after end", "2026-11-27/2026-12-01T06:00", nil, time.Date(2026, 12, 1, 6, 0, 0, 0, time.UTC), false},
+		{"fixed interval RFC3339", "2026-11-27T00:00:00+01:00/2026-11-28T00:00:00+01:00", nil, time.Date(2026, 11, 26, 23, 30, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec, tt.loc)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q) error = %v", tt.spec, err)
+			}
+			if got := schedule.Active(tt.at); got != tt.want {
+				t.Errorf("Active(%v) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSchedule_DayFields(t *testing.T) {
+	// With both day fields restricted, either matches: the 1st of the month or a Monday.
+	schedule, err := ParseSchedule("0 0 1 * mon for 1h", nil)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+	for _, at := range []time.Time{
+		time.Date(2026, 11, 1, 0, 30, 0, 0, time.UTC),  // Sunday the 1st
+		time.Date(2026, 11, 2, 0, 30, 0, 0, time.UTC),  // Monday the 2nd
+		time.Date(2026, 11, 10, 0, 30, 0, 0, time.UTC), // Tuesday the 10th
+	} {
+		want := at.Day() == 1 || at.Weekday() == time.Monday
+		if got := schedule.Active(at); got != want {
+			t.Errorf("Active(%v) = %v, want %v", at, got, want)
+		}
+	}
+}
+
+func TestParseSchedule_Errors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"0 8 * * 1-5",
+		"0 8 * * for 1h",
+		"0 25 * * * for 1h",
+		"0 8 * * 1-5 for 0s",
+		"0 8 * * 5-1 for 1h",
+		"*/0 * * * * for 1h",
+		"Mon-Fri 08:00",
+		"Funday 08:00-10:00",
+		"08:00-25:00",
+		"2026-12-01/2026-11-27",
+		"tomorrow/2026-11-27",
+	} {
+		if _, err := ParseSchedule(spec, nil); err == nil {
+			t.Errorf("ParseSchedule(%q) expected error, got nil", spec)
+		}
+	}
+}
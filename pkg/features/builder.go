@@ -54,10 +54,12 @@ func (b *Builder) BuildFeatures(df adapters.DataFrame) (models.FeatureFrame, err
 			if timestamp, err := parseTimestamp(tsRaw); err == nil {
 				features["timestamp"] = float64(timestamp.Unix())
 
-				// Extract time-based features
-				features["hour"] = float64(timestamp.Hour())
-				features["minute"] = float64(timestamp.Minute())
-				features["day"] = float64(timestamp.Weekday())
+				// Extract time-based features in UTC, so they do not depend on the
+				// process time zone and match those of filled and resampled rows
+				utc := timestamp.UTC()
+				features["hour"] = float64(utc.Hour())
+				features["minute"] = float64(utc.Minute())
+				features["day"] = float64(utc.Weekday())
 			}
 		}
 
@@ -125,8 +127,8 @@ func parseTimestamp(v any) (time.Time, error) {
 // For each feature column, missing values (represented as NaN or not present) are
 // replaced with the last valid value seen.
 //
-// This is a simple implementation for v0.1. More sophisticated imputation
-// strategies (mean, interpolation) can be added later if needed.
+// It does not add rows for missing timestamps; use a Resampler to put the frame on
+// a fixed step grid with interpolated gaps.
 func FillMissingValues(frame models.FeatureFrame) models.FeatureFrame {
 	if len(frame.Rows) == 0 {
 		return frame
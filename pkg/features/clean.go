@@ -0,0 +1,274 @@
+package features
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/models"
+)
+
+// Outlier replacement strategies supported by the Cleaner.
+const (
+	ReplaceMedian      = "median"
+	ReplaceInterpolate = "interpolate"
+	ReplaceClip        = "clip"
+)
+
+// madScale converts a median absolute deviation into a standard deviation estimate
+// for normally distributed data.
+const madScale = 1.4826
+
+// CleanOptions configures the Cleaner.
+type CleanOptions struct {
+	// OutlierWindow is the half-width, in points, of the Hampel filter window. Each
+	// point is compared against the median of the 2*OutlierWindow+1 points centered on
+	// it. Zero disables outlier detection.
+	OutlierWindow int
+
+	// OutlierThreshold is how many scaled MADs a point may deviate from its window
+	// median before it is treated as an outlier. Defaults to 3 when <= 0.
+	OutlierThreshold float64
+
+	// Replacement selects how outliers are replaced: "median" (window median, default),
+	// "interpolate" (linear between the nearest clean neighbors), or "clip" (clamped to
+	// the threshold band around the window median).
+	Replacement string
+
+	// StepSeconds is the expected spacing between rows. Zero disables gap detection.
+	StepSeconds int
+
+	// MaxGapSteps is the longest run of missing steps that is filled by linear
+	// interpolation. Longer gaps are detected and reported but left unfilled, since
+	// inventing a long stretch of data would teach the model a pattern that never
+	// happened. Zero disables gap filling.
+	MaxGapSteps int
+}
+
+// CleanStats reports what a Clean call changed.
+type CleanStats struct {
+	// Outliers is the number of points replaced by the outlier filter.
+	Outliers int
+	// FilledPoints is the number of rows inserted to fill gaps.
+	FilledPoints int
+	// UnfilledGaps is the number of gaps longer than MaxGapSteps that were left as-is.
+	UnfilledGaps int
+}
+
+// Cleaner removes incident spikes and metrics-pipeline dropouts from a FeatureFrame
+// before it reaches a model, so seasonal buckets and fitted coefficients reflect
+// normal behaviour. It runs a Hampel (rolling median/MAD) filter over the "value"
+// feature and then fills short timestamp gaps by linear interpolation.
+//
+// A Cleaner holds no state between calls and is safe for concurrent use.
+type Cleaner struct {
+	opts CleanOptions
+}
+
+// NewCleaner creates a Cleaner, applying defaults for unset options.
+func NewCleaner(opts CleanOptions) *Cleaner {
+	if opts.OutlierWindow < 0 {
+		opts.OutlierWindow = 0
+	}
+	if opts.OutlierThreshold <= 0 {
+		opts.OutlierThreshold = 3
+	}
+	if opts.Replacement == "" {
+		opts.Replacement = ReplaceMedian
+	}
+	if opts.MaxGapSteps < 0 {
+		opts.MaxGapSteps = 0
+	}
+	return &Cleaner{opts: opts}
+}
+
+// Clean returns a cleaned copy of frame and statistics about the changes made.
+// Rows are expected in ascending timestamp order, as produced by BuildFeatures.
+// The input frame is not modified.
+func (c *Cleaner) Clean(frame models.FeatureFrame) (models.FeatureFrame, CleanStats) {
+	var stats CleanStats
+	if len(frame.Rows) == 0 {
+		return frame, stats
+	}
+
+	rows := make([]map[string]float64, len(frame.Rows))
+	for i, row := range frame.Rows {
+		copied := make(map[string]float64, len(row))
+		for k, v := range row {
+			copied[k] = v
+		}
+		rows[i] = copied
+	}
+
+	stats.Outliers = c.replaceOutliers(rows)
+	rows, stats.FilledPoints, stats.UnfilledGaps = c.fillGaps(rows)
+
+	return models.FeatureFrame{Rows: rows}, stats
+}
+
+// replaceOutliers runs the Hampel filter over the "value" feature in place and
+// returns the number of points replaced. Detection uses the original values so that
+// one replacement does not mask or create another.
+func (c *Cleaner) replaceOutliers(rows []map[string]float64) int {
+	if c.opts.OutlierWindow == 0 {
+		return 0
+	}
+
+	idx := make([]int, 0, len(rows))
+	values := make([]float64, 0, len(rows))
+	for i, row := range rows {
+		if v, ok := row["value"]; ok {
+			idx = append(idx, i)
+			values = append(values, v)
+		}
+	}
+	if len(values) < 3 {
+		return 0
+	}
+
+	outlier := make([]bool, len(values))
+	medians := make([]float64, len(values))
+	sigmas := make([]float64, len(values))
+	window := make([]float64, 0, 2*c.opts.OutlierWindow+1)
+
+	for i := range values {
+		lo := max(0, i-c.opts.OutlierWindow)
+		hi := min(len(values)-1, i+c.opts.OutlierWindow)
+
+		window = append(window[:0], values[lo:hi+1]...)
+		med := median(window)
+		for j := range window {
+			window[j] = math.Abs(window[j] - med)
+		}
+		sigma := madScale * median(window)
+
+		medians[i] = med
+		sigmas[i] = sigma
+		// A zero MAD means most of the window is identical; any deviation would be
+		// flagged, so the point is only treated as an outlier when the window is not flat.
+		if sigma > 0 && math.Abs(values[i]-med) > c.opts.OutlierThreshold*sigma {
+			outlier[i] = true
+		}
+	}
+
+	replaced := 0
+	for i := range values {
+		if !outlier[i] {
+			continue
+		}
+
+		var replacement float64
+		switch c.opts.Replacement {
+		case ReplaceInterpolate:
+			replacement = interpolateClean(values, outlier, i, medians[i])
+		case ReplaceClip:
+			band := c.opts.OutlierThreshold * sigmas[i]
+			replacement = math.Max(medians[i]-band, math.Min(values[i], medians[i]+band))
+		default:
+			replacement = medians[i]
+		}
+
+		rows[idx[i]]["value"] = replacement
+		replaced++
+	}
+
+	return replaced
+}
+
+// interpolateClean linearly interpolates position i between its nearest non-outlier
+// neighbors, falling back to the window median at the edges of the series.
+func interpolateClean(values []float64, outlier []bool, i int, fallback float64) float64 {
+	left := i - 1
+	for left >= 0 && outlier[left] {
+		left--
+	}
+	right := i + 1
+	for right < len(values) && outlier[right] {
+		right++
+	}
+
+	switch {
+	case left >= 0 && right < len(values):
+		frac := float64(i-left) / float64(right-left)
+		return values[left] + frac*(values[right]-values[left])
+	case left >= 0:
+		return values[left]
+	case right < len(values):
+		return values[right]
+	default:
+		return fallback
+	}
+}
+
+// fillGaps inserts linearly interpolated rows where consecutive timestamps are more
+// than one step apart. It returns the new rows, the number of inserted rows, and the
+// number of gaps that exceeded MaxGapSteps and were left unfilled.
+func (c *Cleaner) fillGaps(rows []map[string]float64) ([]map[string]float64, int, int) {
+	if c.opts.StepSeconds <= 0 || len(rows) < 2 {
+		return rows, 0, 0
+	}
+
+	step := float64(c.opts.StepSeconds)
+	out := make([]map[string]float64, 0, len(rows))
+	filled, unfilled := 0, 0
+
+	out = append(out, rows[0])
+	for i := 1; i < len(rows); i++ {
+		prev, next := rows[i-1], rows[i]
+
+		prevTS, okPrev := prev["timestamp"]
+		nextTS, okNext := next["timestamp"]
+		prevValue, okPrevValue := prev["value"]
+		nextValue, okNextValue := next["value"]
+
+		if okPrev && okNext && okPrevValue && okNextValue {
+			missing := int(math.Round((nextTS-prevTS)/step)) - 1
+			switch {
+			case missing > 0 && c.opts.MaxGapSteps > 0 && missing <= c.opts.MaxGapSteps:
+				for k := 1; k <= missing; k++ {
+					frac := float64(k) / float64(missing+1)
+					out = append(out, gapRow(prev, prevTS+float64(k)*step, prevValue+frac*(nextValue-prevValue)))
+					filled++
+				}
+			case missing > 0:
+				unfilled++
+			}
+		}
+
+		out = append(out, next)
+	}
+
+	return out, filled, unfilled
+}
+
+// gapRow builds an interpolated row at ts, deriving the same time-based features that
+// BuildFeatures extracts when the neighboring row carries them. Both compute them in
+// UTC, so filled rows match real ones whatever the process time zone.
+func gapRow(prev map[string]float64, ts, value float64) map[string]float64 {
+	row := map[string]float64{
+		"value":     value,
+		"timestamp": ts,
+	}
+
+	t := time.Unix(int64(ts), 0).UTC()
+	if _, ok := prev["hour"]; ok {
+		row["hour"] = float64(t.Hour())
+	}
+	if _, ok := prev["minute"]; ok {
+		row["minute"] = float64(t.Minute())
+	}
+	if _, ok := prev["day"]; ok {
+		row["day"] = float64(t.Weekday())
+	}
+	return row
+}
+
+// median returns the median of values, reordering the slice in place.
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
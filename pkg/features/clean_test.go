@@ -0,0 +1,167 @@
+package features
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/adapters"
+	"github.com/HatiCode/kedastral/pkg/models"
+)
+
+func seriesFrame(values []float64, stepSec int) models.FeatureFrame {
+	rows := make([]map[string]float64, len(values))
+	for i, v := range values {
+		rows[i] = map[string]float64{
+			"value":     v,
+			"timestamp": float64(1700000000 + i*stepSec),
+		}
+	}
+	return models.FeatureFrame{Rows: rows}
+}
+
+func TestCleaner_ReplacesSpikeWithMedian(t *testing.T) {
+	values := []float64{100, 102, 98, 101, 99, 1000, 100, 103, 97, 100, 101}
+	cleaner := NewCleaner(CleanOptions{OutlierWindow: 3})
+
+	cleaned, stats := cleaner.Clean(seriesFrame(values, 60))
+
+	if stats.Outliers != 1 {
+		t.Fatalf("Outliers = %d, want 1", stats.Outliers)
+	}
+	if got := cleaned.Rows[5]["value"]; got > 110 {
+		t.Errorf("spike not replaced: value = %v", got)
+	}
+	if got := cleaned.Rows[4]["value"]; got != 99 {
+		t.Errorf("clean point modified: value = %v, want 99", got)
+	}
+}
+
+func TestCleaner_ReplacesOutageZeros(t *testing.T) {
+	values := []float64{200, 210, 205, 0, 0, 208, 202, 207, 199, 204}
+	cleaner := NewCleaner(CleanOptions{OutlierWindow: 4, Replacement: ReplaceInterpolate})
+
+	cleaned, stats := cleaner.Clean(seriesFrame(values, 60))
+
+	if stats.Outliers != 2 {
+		t.Fatalf("Outliers = %d, want 2", stats.Outliers)
+	}
+	for _, i := range []int{3, 4} {
+		if v := cleaned.Rows[i]["value"]; v < 200 || v > 210 {
+			t.Errorf("row %d = %v, want interpolated between 205 and 208", i, v)
+		}
+	}
+}
+
+func TestCleaner_Clip(t *testing.T) {
+	values := []float64{10, 11, 9, 10, 12, 50, 11, 10, 9, 11}
+	cleaner := NewCleaner(CleanOptions{OutlierWindow: 3, Replacement: ReplaceClip})
+
+	cleaned, stats := cleaner.Clean(seriesFrame(values, 60))
+
+	if stats.Outliers != 1 {
+		t.Fatalf("Outliers = %d, want 1", stats.Outliers)
+	}
+	got := cleaned.Rows[5]["value"]
+	if got >= 50 || got <= 11 {
+		t.Errorf("clipped value = %v, want within (11, 50)", got)
+	}
+}
+
+func TestCleaner_FlatWindowIsNotOutlier(t *testing.T) {
+	values := []float64{5, 5, 5, 5, 6, 5, 5, 5, 5}
+	cleaner := NewCleaner(CleanOptions{OutlierWindow: 3})
+
+	_, stats := cleaner.Clean(seriesFrame(values, 60))
+	if stats.Outliers != 0 {
+		t.Errorf("Outliers = %d, want 0 for zero-MAD window", stats.Outliers)
+	}
+}
+
+func TestCleaner_DoesNotModifyInput(t *testing.T) {
+	frame := seriesFrame([]float64{1, 1.1, 0.9, 100, 1, 1.2, 0.8}, 60)
+	NewCleaner(CleanOptions{OutlierWindow: 3}).Clean(frame)
+
+	if frame.Rows[3]["value"] != 100 {
+		t.Errorf("input frame modified: value = %v", frame.Rows[3]["value"])
+	}
+}
+
+func TestCleaner_FillsShortGaps(t *testing.T) {
+	frame := models.FeatureFrame{Rows: []map[string]float64{
+		{"value": 10, "timestamp": 0, "hour": 0, "minute": 0, "day": 4},
+		{"value": 40, "timestamp": 180, "hour": 0, "minute": 3, "day": 4},
+		{"value": 50, "timestamp": 240, "hour": 0, "minute": 4, "day": 4},
+	}}
+	cleaner := NewCleaner(CleanOptions{StepSeconds: 60, MaxGapSteps: 5})
+
+	cleaned, stats := cleaner.Clean(frame)
+
+	if stats.FilledPoints != 2 {
+		t.Fatalf("FilledPoints = %d, want 2", stats.FilledPoints)
+	}
+	if len(cleaned.Rows) != 5 {
+		t.Fatalf("rows = %d, want 5", len(cleaned.Rows))
+	}
+
+	wantValues := []float64{10, 20, 30, 40, 50}
+	for i, want := range wantValues {
+		if got := cleaned.Rows[i]["value"]; math.Abs(got-want) > 1e-9 {
+			t.Errorf("row %d value = %v, want %v", i, got, want)
+		}
+	}
+	if got := cleaned.Rows[1]["minute"]; got != 1 {
+		t.Errorf("filled row minute = %v, want 1", got)
+	}
+	if got := cleaned.Rows[2]["timestamp"]; got != 120 {
+		t.Errorf("filled row timestamp = %v, want 120", got)
+	}
+}
+
+func TestCleaner_GapTimeFeaturesMatchBuilder(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("UTC+5", 5*60*60)
+	defer func() { time.Local = local }()
+
+	start := time.Date(2024, 1, 15, 22, 0, 0, 0, time.UTC)
+	df := adapters.DataFrame{Rows: []adapters.Row{
+		{"ts": float64(start.Unix()), "value": 100.0},
+		{"ts": float64(start.Add(2 * time.Minute).Unix()), "value": 120.0},
+		{"ts": float64(start.Add(3 * time.Minute).Unix()), "value": 130.0},
+	}}
+	frame, err := NewBuilder().BuildFeatures(df)
+	if err != nil {
+		t.Fatalf("BuildFeatures() error = %v", err)
+	}
+
+	cleaned, stats := NewCleaner(CleanOptions{StepSeconds: 60, MaxGapSteps: 5}).Clean(frame)
+	if stats.FilledPoints != 1 {
+		t.Fatalf("FilledPoints = %d, want 1", stats.FilledPoints)
+	}
+	for i, row := range cleaned.Rows {
+		if row["hour"] != 22 || row["day"] != 1 || row["minute"] != float64(i) {
+			t.Errorf("row %d: hour %v, minute %v, day %v, want 22:%02d on Monday in UTC", i, row["hour"], row["minute"], row["day"], i)
+		}
+	}
+}
+
+func TestCleaner_LeavesLongGaps(t *testing.T) {
+	frame := seriesFrame([]float64{1, 2}, 600)
+	cleaner := NewCleaner(CleanOptions{StepSeconds: 60, MaxGapSteps: 3})
+
+	cleaned, stats := cleaner.Clean(frame)
+
+	if stats.UnfilledGaps != 1 {
+		t.Errorf("UnfilledGaps = %d, want 1", stats.UnfilledGaps)
+	}
+	if stats.FilledPoints != 0 || len(cleaned.Rows) != 2 {
+		t.Errorf("long gap should not be filled: filled=%d rows=%d", stats.FilledPoints, len(cleaned.Rows))
+	}
+}
+
+func TestCleaner_Empty(t *testing.T) {
+	cleaned, stats := NewCleaner(CleanOptions{OutlierWindow: 3, StepSeconds: 60, MaxGapSteps: 5}).Clean(models.FeatureFrame{})
+	if len(cleaned.Rows) != 0 || stats != (CleanStats{}) {
+		t.Errorf("Clean(empty) = %v, %+v", cleaned, stats)
+	}
+}
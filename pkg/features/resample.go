@@ -0,0 +1,207 @@
+package features
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/HatiCode/kedastral/pkg/models"
+)
+
+// Aggregation strategies for rows that snap to the same grid point.
+const (
+	AggregateMean = "mean"
+	AggregateSum  = "sum"
+	AggregateLast = "last"
+)
+
+// Fill strategies for grid points with no observations.
+const (
+	FillLinear   = "linear"
+	FillSeasonal = "seasonal"
+	FillForward  = "forward"
+)
+
+// ErrGapTooLong is returned by Resample when the series has a run of missing grid
+// points longer than ResampleOptions.MaxGapSteps.
+var ErrGapTooLong = errors.New("gap exceeds maximum fillable length")
+
+// ResampleOptions configures the Resampler.
+type ResampleOptions struct {
+	// StepSeconds is the grid spacing. Required.
+	StepSeconds int
+
+	// Aggregate selects how rows snapping to the same grid point are combined:
+	// "mean" (default), "sum", or "last".
+	Aggregate string
+
+	// Fill selects how missing grid points are filled: "linear" (default), "seasonal"
+	// (the value one season earlier, falling back to linear when that point is not
+	// in the series), or "forward" (the previous value).
+	Fill string
+
+	// SeasonSteps is the season length in steps used by the "seasonal" fill.
+	SeasonSteps int
+
+	// MaxGapSteps is the longest run of missing grid points that is filled. Longer
+	// gaps make Resample fail with ErrGapTooLong rather than hand the model a
+	// fabricated stretch of data.
+	MaxGapSteps int
+}
+
+// ResampleStats reports what a Resample call changed.
+type ResampleStats struct {
+	// Aggregated is the number of input rows merged into another row on the same grid point.
+	Aggregated int
+	// Filled is the number of grid points synthesized to fill gaps.
+	Filled int
+	// Dropped is the number of input rows discarded because they had no timestamp.
+	Dropped int
+}
+
+// Resampler snaps a FeatureFrame onto a fixed step grid so models that assume evenly
+// spaced observations (ARIMA, SARIMA) see one row per step. Timestamps are rounded
+// to the nearest multiple of the step, duplicates are aggregated, and missing grid
+// points are filled.
+//
+// A Resampler holds no state between calls and is safe for concurrent use.
+type Resampler struct {
+	opts ResampleOptions
+}
+
+// NewResampler creates a Resampler, applying defaults for unset options.
+func NewResampler(opts ResampleOptions) *Resampler {
+	if opts.Aggregate == "" {
+		opts.Aggregate = AggregateMean
+	}
+	if opts.Fill == "" {
+		opts.Fill = FillLinear
+	}
+	if opts.MaxGapSteps < 0 {
+		opts.MaxGapSteps = 0
+	}
+	return &Resampler{opts: opts}
+}
+
+// Resample returns frame snapped to the step grid, in ascending timestamp order.
+// Rows without a timestamp are dropped; a frame with no timestamps at all is
+// returned unchanged because there is no grid to snap to. The input frame is not
+// modified.
+func (r *Resampler) Resample(frame models.FeatureFrame) (models.FeatureFrame, ResampleStats, error) {
+	var stats ResampleStats
+	if r.opts.StepSeconds <= 0 {
+		return models.FeatureFrame{}, stats, fmt.Errorf("resample step must be > 0, got %d", r.opts.StepSeconds)
+	}
+
+	step := int64(r.opts.StepSeconds)
+	buckets := make(map[int64][]map[string]float64)
+	for _, row := range frame.Rows {
+		ts, ok := row["timestamp"]
+		if !ok {
+			stats.Dropped++
+			continue
+		}
+		slot := int64(math.Round(ts/float64(step))) * step
+		buckets[slot] = append(buckets[slot], row)
+	}
+	if len(buckets) == 0 {
+		return frame, ResampleStats{}, nil
+	}
+
+	slots := make([]int64, 0, len(buckets))
+	for slot := range buckets {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+
+	first, last := slots[0], slots[len(slots)-1]
+	rows := make([]map[string]float64, 0, (last-first)/step+1)
+	index := make(map[int64]int, cap(rows))
+
+	for i, slot := range slots {
+		if i > 0 {
+			prev := slots[i-1]
+			missing := int((slot-prev)/step) - 1
+			if missing > r.opts.MaxGapSteps {
+				return models.FeatureFrame{}, stats, fmt.Errorf("%w: %d missing steps between %s and %s (max %d)",
+					ErrGapTooLong, missing,
+					time.Unix(prev, 0).UTC().Format(time.RFC3339),
+					time.Unix(slot, 0).UTC().Format(time.RFC3339),
+					r.opts.MaxGapSteps)
+			}
+			if missing > 0 {
+				next := r.aggregate(buckets[slot], slot)
+				for k := 1; k <= missing; k++ {
+					ts := prev + int64(k)*step
+					value := r.fill(rows, index, ts, k, missing, next["value"])
+					index[ts] = len(rows)
+					rows = append(rows, gapRow(rows[len(rows)-1], float64(ts), value))
+					stats.Filled++
+				}
+			}
+		}
+
+		stats.Aggregated += len(buckets[slot]) - 1
+		index[slot] = len(rows)
+		rows = append(rows, r.aggregate(buckets[slot], slot))
+	}
+
+	return models.FeatureFrame{Rows: rows}, stats, nil
+}
+
+// aggregate combines the rows that snapped to slot into one row. Time-derived
+// features are recomputed for the grid timestamp; every other feature is combined
+// with the configured aggregation.
+func (r *Resampler) aggregate(group []map[string]float64, slot int64) map[string]float64 {
+	out := make(map[string]float64, len(group[0]))
+	counts := make(map[string]int, len(group[0]))
+
+	for _, row := range group {
+		for k, v := range row {
+			switch k {
+			case "timestamp", "hour", "minute", "day":
+				continue
+			}
+			switch r.opts.Aggregate {
+			case AggregateLast:
+				out[k] = v
+			default:
+				out[k] += v
+			}
+			counts[k]++
+		}
+	}
+	if r.opts.Aggregate == AggregateMean {
+		for k, n := range counts {
+			out[k] /= float64(n)
+		}
+	}
+
+	derived := gapRow(group[len(group)-1], float64(slot), out["value"])
+	for k, v := range derived {
+		out[k] = v
+	}
+	return out
+}
+
+// fill returns the value for the k-th of missing grid points at ts, given the rows
+// emitted so far and the value at the next observed grid point.
+func (r *Resampler) fill(rows []map[string]float64, index map[int64]int, ts int64, k, missing int, next float64) float64 {
+	prev := rows[len(rows)-k]["value"]
+
+	switch r.opts.Fill {
+	case FillForward:
+		return prev
+	case FillSeasonal:
+		if r.opts.SeasonSteps > 0 {
+			if i, ok := index[ts-int64(r.opts.SeasonSteps*r.opts.StepSeconds)]; ok {
+				return rows[i]["value"]
+			}
+		}
+	}
+
+	frac := float64(k) / float64(missing+1)
+	return prev + frac*(next-prev)
+}
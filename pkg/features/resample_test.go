@@ -0,0 +1,170 @@
+package features
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/HatiCode/kedastral/pkg/models"
+)
+
+func tsFrame(points ...[2]float64) models.FeatureFrame {
+	rows := make([]map[string]float64, len(points))
+	for i, p := range points {
+		rows[i] = map[string]float64{"timestamp": p[0], "value": p[1]}
+	}
+	return models.FeatureFrame{Rows: rows}
+}
+
+func frameValues(frame models.FeatureFrame) []float64 {
+	values := make([]float64, len(frame.Rows))
+	for i, row := range frame.Rows {
+		values[i] = row["value"]
+	}
+	return values
+}
+
+func assertValues(t *testing.T, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("values = %v, want %v", got, want)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("values = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestResampler_SnapsAndSorts(t *testing.T) {
+	frame := tsFrame([2]float64{122, 3}, [2]float64{1, 1}, [2]float64{59, 2})
+	r := NewResampler(ResampleOptions{StepSeconds: 60})
+
+	out, _, err := r.Resample(frame)
+	if err != nil {
+		t.Fatalf("Resample() error = %v", err)
+	}
+
+	assertValues(t, frameValues(out), []float64{1, 2, 3})
+	for i, want := range []float64{0, 60, 120} {
+		if got := out.Rows[i]["timestamp"]; got != want {
+			t.Errorf("row %d timestamp = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestResampler_Aggregate(t *testing.T) {
+	frame := tsFrame([2]float64{0, 10}, [2]float64{10, 20}, [2]float64{60, 5})
+
+	tests := []struct {
+		aggregate string
+		want      float64
+	}{
+		{AggregateMean, 15},
+		{AggregateSum, 30},
+		{AggregateLast, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.aggregate, func(t *testing.T) {
+			out, stats, err := NewResampler(ResampleOptions{StepSeconds: 60, Aggregate: tt.aggregate}).Resample(frame)
+			if err != nil {
+				t.Fatalf("Resample() error = %v", err)
+			}
+			if stats.Aggregated != 1 {
+				t.Errorf("Aggregated = %d, want 1", stats.Aggregated)
+			}
+			assertValues(t, frameValues(out), []float64{tt.want, 5})
+		})
+	}
+}
+
+func TestResampler_Fill(t *testing.T) {
+	frame := tsFrame(
+		[2]float64{0, 1}, [2]float64{60, 2}, [2]float64{120, 3},
+		[2]float64{180, 10}, [2]float64{240, 20},
+		[2]float64{420, 40},
+	)
+
+	tests := []struct {
+		fill string
+		want []float64
+	}{
+		{FillLinear, []float64{1, 2, 3, 10, 20, 26.666666666666668, 33.333333333333336, 40}},
+		{FillForward, []float64{1, 2, 3, 10, 20, 20, 20, 40}},
+		// With a 3-step season the missing points at 300 and 360 take the values at
+		// 120 and 180.
+		{FillSeasonal, []float64{1, 2, 3, 10, 20, 3, 10, 40}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fill, func(t *testing.T) {
+			r := NewResampler(ResampleOptions{StepSeconds: 60, Fill: tt.fill, SeasonSteps: 3, MaxGapSteps: 2})
+			out, stats, err := r.Resample(frame)
+			if err != nil {
+				t.Fatalf("Resample() error = %v", err)
+			}
+			if stats.Filled != 2 {
+				t.Errorf("Filled = %d, want 2", stats.Filled)
+			}
+			assertValues(t, frameValues(out), tt.want)
+		})
+	}
+}
+
+func TestResampler_SeasonalFallsBackToLinear(t *testing.T) {
+	frame := tsFrame([2]float64{0, 0}, [2]float64{120, 10})
+	r := NewResampler(ResampleOptions{StepSeconds: 60, Fill: FillSeasonal, SeasonSteps: 24, MaxGapSteps: 1})
+
+	out, _, err := r.Resample(frame)
+	if err != nil {
+		t.Fatalf("Resample() error = %v", err)
+	}
+	assertValues(t, frameValues(out), []float64{0, 5, 10})
+}
+
+func TestResampler_GapTooLong(t *testing.T) {
+	frame := tsFrame([2]float64{0, 1}, [2]float64{600, 2})
+	r := NewResampler(ResampleOptions{StepSeconds: 60, MaxGapSteps: 3})
+
+	_, _, err := r.Resample(frame)
+	if !errors.Is(err, ErrGapTooLong) {
+		t.Fatalf("Resample() error = %v, want ErrGapTooLong", err)
+	}
+}
+
+func TestResampler_DerivesTimeFeatures(t *testing.T) {
+	frame := models.FeatureFrame{Rows: []map[string]float64{
+		{"timestamp": 0, "value": 1, "hour": 0, "minute": 0, "day": 4},
+		{"timestamp": 180, "value": 4, "hour": 0, "minute": 3, "day": 4},
+	}}
+
+	out, _, err := NewResampler(ResampleOptions{StepSeconds: 60, MaxGapSteps: 5}).Resample(frame)
+	if err != nil {
+		t.Fatalf("Resample() error = %v", err)
+	}
+	if got := out.Rows[2]["minute"]; got != 2 {
+		t.Errorf("filled row minute = %v, want 2", got)
+	}
+	if got := out.Rows[2]["day"]; got != 4 {
+		t.Errorf("filled row day = %v, want 4", got)
+	}
+}
+
+func TestResampler_NoTimestamps(t *testing.T) {
+	frame := models.FeatureFrame{Rows: []map[string]float64{{"value": 1}, {"value": 2}}}
+
+	out, stats, err := NewResampler(ResampleOptions{StepSeconds: 60}).Resample(frame)
+	if err != nil {
+		t.Fatalf("Resample() error = %v", err)
+	}
+	if len(out.Rows) != 2 || stats != (ResampleStats{}) {
+		t.Errorf("frame without timestamps should pass through: rows=%d stats=%+v", len(out.Rows), stats)
+	}
+}
+
+func TestResampler_InvalidStep(t *testing.T) {
+	if _, _, err := NewResampler(ResampleOptions{}).Resample(tsFrame([2]float64{0, 1})); err == nil {
+		t.Fatal("expected error for zero step, got nil")
+	}
+}
@@ -1,133 +1,469 @@
 package models
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
 	"time"
+
+	kedastraltls "github.com/HatiCode/kedastral/pkg/tls"
+)
+
+// BYOMProtocolVersion is the version of the BYOM HTTP contract this client speaks.
+// It is sent in every request and checked against the version a service declares
+// in its /info response.
+const BYOMProtocolVersion = "v1"
+
+// Optional BYOM capabilities a service declares in its /info response.
+const (
+	BYOMFeatureQuantiles = "quantiles"
+	BYOMFeatureTrain     = "train"
 )
 
-// BYOMModel implements a model that delegates predictions to an external HTTP service.
+// byomHandshakeInterval is how long a successful handshake is trusted before Train
+// repeats it.
+const byomHandshakeInterval = 5 * time.Minute
+
+// errBYOMNotImplemented marks an optional endpoint the service does not serve.
+var errBYOMNotImplemented = errors.New("endpoint not implemented")
+
+// BYOMGRPCScheme is the endpoint scheme that selects the gRPC transport, e.g.
+// "byom+grpc://prophet.models.svc:9090". Any other endpoint is called over HTTP.
+const BYOMGRPCScheme = "byom+grpc://"
+
+// BYOMModel implements a model that delegates predictions to an external service.
 // This allows integration with any forecasting model (Prophet, TensorFlow, custom models)
-// as long as the service implements the BYOM HTTP contract defined in CLAUDE.md section 4.2.
+// as long as the service implements the BYOM contract described in docs/byom.md, over
+// HTTP/JSON or, for "byom+grpc://" endpoints, gRPC (pkg/api/byom).
+//
+// Only predict is required. Services may also report health, serve info (declaring
+// supported horizons, steps, and optional features), and train. Train performs the
+// health and info handshake and calls train when the service declares the "train"
+// feature; services that serve neither are treated as predict-only, so older
+// services keep working unchanged.
+//
+// Calls carry the credentials set with WithAuth. Transient failures are retried
+// with jittered backoff, and a circuit breaker fast-fails with ErrBYOMCircuitOpen
+// after repeated failures so a flapping service does not stall every tick (see
+// BYOMResilience).
 type BYOMModel struct {
-	endpoint string
-	metric   string
-	stepSec  int
-	horizon  int
-	client   *http.Client
+	endpoint   string
+	metric     string
+	stepSec    int
+	horizon    int
+	workload   string
+	policy     string
+	auth       BYOMAuth
+	resilience BYOMResilience
+	breaker    *byomBreaker
+	transport  byomTransport
+	// transportErr is returned by every call when the transport could not be built,
+	// for example because the TLS files are unreadable.
+	transportErr error
+
+	mu          sync.Mutex
+	info        *BYOMInfo
+	handshakeAt time.Time
+	status      BYOMStatus
+}
+
+// BYOMInfo is a service's /info response.
+type BYOMInfo struct {
+	// Protocol is the BYOM contract version the service implements, e.g. "v1".
+	Protocol string `json:"protocol"`
+	// Name identifies the model behind the service, e.g. "prophet".
+	Name string `json:"name,omitempty"`
+	// MaxHorizonSeconds is the longest horizon the service forecasts; 0 means unbounded.
+	MaxHorizonSeconds int `json:"maxHorizonSeconds,omitempty"`
+	// StepSeconds lists the supported step sizes; empty means any step.
+	StepSeconds []int `json:"stepSeconds,omitempty"`
+	// Features lists optional capabilities: "quantiles" and "train".
+	Features []string `json:"features,omitempty"`
+}
+
+// Supports reports whether the service declared the named optional feature.
+func (i BYOMInfo) Supports(feature string) bool {
+	return slices.Contains(i.Features, feature)
+}
+
+// BYOMStatus reports the outcome of the most recent interaction with a BYOM service.
+type BYOMStatus struct {
+	// Endpoint is the configured prediction URL.
+	Endpoint string
+	// Healthy is true when the most recent handshake, training, or prediction call succeeded.
+	Healthy bool
+	// Info is the service's declared metadata, or nil if it does not serve /info or
+	// no handshake has completed yet.
+	Info *BYOMInfo
+	// LastError describes the most recent failure; empty when Healthy.
+	LastError string
+	// CheckedAt is when the service was last contacted; zero if never.
+	CheckedAt time.Time
+	// CircuitOpen is true while calls are rejected after repeated failures.
+	CircuitOpen bool
+}
+
+// byomTransport carries BYOM calls to a service over one wire protocol.
+type byomTransport interface {
+	// health returns errBYOMNotImplemented if the service has no health endpoint.
+	health(ctx context.Context) error
+	// info returns errBYOMNotImplemented if the service has no info endpoint.
+	info(ctx context.Context) (BYOMInfo, error)
+	train(ctx context.Context, in byomInput) error
+	predict(ctx context.Context, in byomInput) (byomOutput, error)
 }
 
-type byomRequest struct {
-	Now            string           `json:"now"`
-	HorizonSeconds int              `json:"horizonSeconds"`
-	StepSeconds    int              `json:"stepSeconds"`
-	Features       []map[string]any `json:"features"`
+// byomInput is a train or predict call, independent of the wire format.
+type byomInput struct {
+	Workload       string
+	Policy         string
+	Now            time.Time
+	HorizonSeconds int
+	StepSeconds    int
+	Features       FeatureFrame
 }
 
-type byomResponse struct {
-	Metric string    `json:"metric"`
-	Values []float64 `json:"values"`
+// byomOutput is a predict result, independent of the wire format.
+type byomOutput struct {
+	Values    []float64
+	Quantiles map[float64][]float64
 }
 
-// NewBYOMModel creates a new BYOM model that delegates to an external HTTP service.
+// NewBYOMModel creates a new BYOM model that delegates to an external service.
+// For HTTP, endpoint is the /predict URL and the optional endpoints are resolved
+// relative to it. For gRPC, endpoint is "byom+grpc://host:port".
 func NewBYOMModel(endpoint, metric string, stepSec, horizon int) *BYOMModel {
-	return &BYOMModel{
+	m := &BYOMModel{
 		endpoint: endpoint,
 		metric:   metric,
 		stepSec:  stepSec,
 		horizon:  horizon,
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        10,
-				IdleConnTimeout:     90 * time.Second,
-				DisableCompression:  false,
-				DisableKeepAlives:   false,
-				MaxIdleConnsPerHost: 2,
-			},
-		},
+		status:   BYOMStatus{Endpoint: endpoint},
 	}
+	m.WithResilience(DefaultBYOMResilience)
+	m.transport, m.transportErr = newBYOMTransport(endpoint, kedastraltls.Config{})
+	return m
+}
+
+// WithTLS enables mutual TLS to the service using cfg's client certificate, key,
+// and CA files. It is a no-op when cfg is not enabled. It returns m.
+func (m *BYOMModel) WithTLS(cfg kedastraltls.Config) *BYOMModel {
+	if cfg.Enabled {
+		m.transport, m.transportErr = newBYOMTransport(m.endpoint, cfg)
+	}
+	return m
+}
+
+func newBYOMTransport(endpoint string, tlsCfg kedastraltls.Config) (byomTransport, error) {
+	if target, ok := strings.CutPrefix(endpoint, BYOMGRPCScheme); ok {
+		return newBYOMGRPCTransport(target, tlsCfg)
+	}
+	return newBYOMHTTPTransport(endpoint, tlsCfg)
+}
+
+// WithAuth sets the credentials sent with every request. It returns m.
+func (m *BYOMModel) WithAuth(auth BYOMAuth) *BYOMModel {
+	m.auth = auth
+	return m
+}
+
+// WithResilience replaces the retry and circuit breaker configuration, resetting
+// the breaker. It returns m.
+func (m *BYOMModel) WithResilience(r BYOMResilience) *BYOMModel {
+	m.resilience = r
+	m.breaker = &byomBreaker{threshold: r.BreakerThreshold, cooldown: r.BreakerCooldown}
+	return m
 }
 
-// Name returns the model identifier.
+// WithIdentity sets the workload and policy identifiers sent with every request, so
+// a service shared by several workloads can tell them apart. It returns m.
+func (m *BYOMModel) WithIdentity(workload, policy string) *BYOMModel {
+	m.workload = workload
+	m.policy = policy
+	return m
+}
+
+// Name returns the model identifier, including the service's host (and port) so
+// that logs and metrics can tell BYOM services apart, e.g. "byom(prophet:8000)".
 func (m *BYOMModel) Name() string {
-	return "byom"
+	return fmt.Sprintf("byom(%s)", byomEndpointIdentity(m.endpoint))
 }
 
-// Train is a no-op for BYOM models since the external service handles training.
+// byomEndpointIdentity returns the host:port of endpoint, leaving out any
+// credentials, path, and query string.
+func byomEndpointIdentity(endpoint string) string {
+	if target, ok := strings.CutPrefix(endpoint, BYOMGRPCScheme); ok {
+		return target
+	}
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return "unknown"
+}
+
+// Status returns the outcome of the most recent interaction with the service.
+func (m *BYOMModel) Status() BYOMStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status := m.status
+	status.CircuitOpen = m.breaker.open()
+	if m.info != nil {
+		info := *m.info
+		status.Info = &info
+	}
+	return status
+}
+
+// Train handshakes with the service when the last handshake is missing or stale,
+// then sends history to POST /train if the service declares the "train" feature.
+// For predict-only services it does nothing.
 func (m *BYOMModel) Train(ctx context.Context, history FeatureFrame) error {
+	if err := m.breaker.allow(); err != nil {
+		return err
+	}
+	defer m.breaker.release()
+
+	info, err := m.handshake(ctx)
+	if err != nil {
+		return m.fail(err)
+	}
+	if info == nil || !info.Supports(BYOMFeatureTrain) {
+		return nil
+	}
+	if len(history.Rows) == 0 {
+		return fmt.Errorf("byom: training features cannot be empty")
+	}
+
+	ctx, err = m.authorize(ctx)
+	if err != nil {
+		return m.fail(err)
+	}
+	in := m.input(history)
+	if err := m.resilience.retry(ctx, func() error { return m.transport.train(ctx, in) }); err != nil {
+		return m.fail(fmt.Errorf("byom: train: %w", err))
+	}
+
+	m.succeed()
 	return nil
 }
 
-// Predict generates a forecast by calling the external BYOM HTTP service.
-func (m *BYOMModel) Predict(ctx context.Context, features FeatureFrame) (Forecast, error) {
-	if len(features.Rows) == 0 {
-		return Forecast{}, fmt.Errorf("byom: features cannot be empty")
+// Handshake checks the service's health and fetches its info, verifying that the
+// service supports this model's horizon, step, and protocol version. It returns nil
+// info for services that do not serve info.
+func (m *BYOMModel) Handshake(ctx context.Context) (*BYOMInfo, error) {
+	if m.transportErr != nil {
+		return nil, m.transportErr
+	}
+	ctx, err := m.authorize(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	reqFeatures := make([]map[string]any, len(features.Rows))
-	for i, row := range features.Rows {
-		feature := make(map[string]any)
-		for k, v := range row {
-			feature[k] = v
+	err = m.resilience.retry(ctx, func() error { return m.transport.health(ctx) })
+	if err != nil && !errors.Is(err, errBYOMNotImplemented) {
+		return nil, fmt.Errorf("byom: health check: %w", err)
+	}
+
+	var info BYOMInfo
+	err = m.resilience.retry(ctx, func() (err error) {
+		info, err = m.transport.info(ctx)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errBYOMNotImplemented) {
+			return nil, nil
 		}
-		reqFeatures[i] = feature
+		return nil, fmt.Errorf("byom: info: %w", err)
+	}
+	if err := m.checkInfo(info); err != nil {
+		return nil, err
 	}
+	return &info, nil
+}
 
-	req := byomRequest{
-		Now:            time.Now().UTC().Format(time.RFC3339),
-		HorizonSeconds: m.horizon,
-		StepSeconds:    m.stepSec,
-		Features:       reqFeatures,
+// handshake runs Handshake unless a recent one succeeded, caching its result.
+func (m *BYOMModel) handshake(ctx context.Context) (*BYOMInfo, error) {
+	m.mu.Lock()
+	fresh := !m.handshakeAt.IsZero() && time.Since(m.handshakeAt) < byomHandshakeInterval
+	info := m.info
+	m.mu.Unlock()
+	if fresh {
+		return info, nil
 	}
 
-	body, err := json.Marshal(req)
+	info, err := m.Handshake(ctx)
 	if err != nil {
-		return Forecast{}, fmt.Errorf("byom: marshal request: %w", err)
+		return nil, err
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", m.endpoint, bytes.NewReader(body))
-	if err != nil {
-		return Forecast{}, fmt.Errorf("byom: create request: %w", err)
+	m.mu.Lock()
+	m.info = info
+	m.handshakeAt = time.Now()
+	m.mu.Unlock()
+	m.succeed()
+	return info, nil
+}
+
+// checkInfo verifies that a service's declared metadata is compatible with this model.
+func (m *BYOMModel) checkInfo(info BYOMInfo) error {
+	if info.Protocol != "" && info.Protocol != BYOMProtocolVersion {
+		return fmt.Errorf("byom: service speaks protocol %q, want %q", info.Protocol, BYOMProtocolVersion)
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
+	if info.MaxHorizonSeconds > 0 && m.horizon > info.MaxHorizonSeconds {
+		return fmt.Errorf("byom: horizon %ds exceeds service maximum %ds", m.horizon, info.MaxHorizonSeconds)
+	}
+	if len(info.StepSeconds) > 0 && !slices.Contains(info.StepSeconds, m.stepSec) {
+		return fmt.Errorf("byom: step %ds not supported by service (supports %v)", m.stepSec, info.StepSeconds)
+	}
+	return nil
+}
 
-	resp, err := m.client.Do(httpReq)
-	if err != nil {
-		return Forecast{}, fmt.Errorf("byom: http request failed: %w", err)
+// Predict generates a forecast by calling the external BYOM service. The context's
+// deadline bounds the call and, over gRPC, is propagated to the service.
+func (m *BYOMModel) Predict(ctx context.Context, features FeatureFrame) (Forecast, error) {
+	if len(features.Rows) == 0 {
+		return Forecast{}, fmt.Errorf("byom: features cannot be empty")
+	}
+	if err := m.breaker.allow(); err != nil {
+		return Forecast{}, err
 	}
-	defer resp.Body.Close()
+	defer m.breaker.release()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
-		return Forecast{}, fmt.Errorf("byom: http %d: %s", resp.StatusCode, string(bodyBytes))
+	if m.transportErr != nil {
+		return Forecast{}, m.fail(m.transportErr)
+	}
+	ctx, err := m.authorize(ctx)
+	if err != nil {
+		return Forecast{}, m.fail(err)
 	}
 
-	var byomResp byomResponse
-	if err := json.NewDecoder(resp.Body).Decode(&byomResp); err != nil {
-		return Forecast{}, fmt.Errorf("byom: decode response: %w", err)
+	in := m.input(features)
+	var out byomOutput
+	err = m.resilience.retry(ctx, func() (err error) {
+		out, err = m.transport.predict(ctx, in)
+		return err
+	})
+	if err != nil {
+		return Forecast{}, m.fail(err)
 	}
 
 	expectedLen := m.horizon / m.stepSec
-	if len(byomResp.Values) != expectedLen {
-		return Forecast{}, fmt.Errorf("byom: expected %d predictions, got %d", expectedLen, len(byomResp.Values))
+	if len(out.Values) != expectedLen {
+		return Forecast{}, m.fail(fmt.Errorf("byom: expected %d predictions, got %d", expectedLen, len(out.Values)))
 	}
+	clampNonNegative(out.Values)
 
-	for i := range byomResp.Values {
-		if byomResp.Values[i] < 0 {
-			byomResp.Values[i] = 0
+	for level, values := range out.Quantiles {
+		if level <= 0 || level >= 1 {
+			return Forecast{}, m.fail(fmt.Errorf("byom: invalid quantile level %v", level))
 		}
+		if len(values) != expectedLen {
+			return Forecast{}, m.fail(fmt.Errorf("byom: quantile %v: expected %d predictions, got %d", level, expectedLen, len(values)))
+		}
+		clampNonNegative(values)
+	}
+	if len(out.Quantiles) == 0 {
+		out.Quantiles = nil
 	}
 
+	m.succeed()
 	return Forecast{
 		Metric:    m.metric,
-		Values:    byomResp.Values,
+		Values:    out.Values,
 		StepSec:   m.stepSec,
 		Horizon:   m.horizon,
-		Quantiles: nil,
+		Quantiles: out.Quantiles,
 	}, nil
 }
+
+// authorize attaches the auth headers to ctx.
+func (m *BYOMModel) authorize(ctx context.Context) (context.Context, error) {
+	headers, err := m.auth.headers()
+	if err != nil {
+		return nil, fmt.Errorf("byom: auth: %w", err)
+	}
+	return withBYOMHeaders(ctx, headers), nil
+}
+
+// input builds a transport call for features.
+func (m *BYOMModel) input(features FeatureFrame) byomInput {
+	return byomInput{
+		Workload:       m.workload,
+		Policy:         m.policy,
+		Now:            time.Now().UTC(),
+		HorizonSeconds: m.horizon,
+		StepSeconds:    m.stepSec,
+		Features:       features,
+	}
+}
+
+// fail records err in the status and as a breaker failure, and returns it.
+func (m *BYOMModel) fail(err error) error {
+	m.breaker.failure()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status.Healthy = false
+	m.status.LastError = err.Error()
+	m.status.CheckedAt = time.Now()
+	return err
+}
+
+// succeed records a successful interaction in the status and closes the breaker.
+func (m *BYOMModel) succeed() {
+	m.breaker.success()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status.Healthy = true
+	m.status.LastError = ""
+	m.status.CheckedAt = time.Now()
+}
+
+// CarryState carries the runtime state of the BYOM models in src over to those at the
+// same place in dst, a fresh instance of the same configuration: dst shares their
+// circuit breakers and starts from their handshake and status. Without it, a model
+// rebuilt for every training pass would reset the breaker each time, so it could
+// never stay open across passes. Wrappers and fallback chains are walked; other
+// models are left alone.
+func CarryState(dst, src Model) {
+	switch d := dst.(type) {
+	case *BYOMModel:
+		if s, ok := src.(*BYOMModel); ok && s != d && s.endpoint == d.endpoint {
+			d.carryState(s)
+		}
+	case *ConformalModel:
+		if s, ok := src.(*ConformalModel); ok {
+			CarryState(d.inner, s.inner)
+		}
+	case *FallbackModel:
+		if s, ok := src.(*FallbackModel); ok && len(s.models) == len(d.models) {
+			for i := range d.models {
+				CarryState(d.models[i], s.models[i])
+			}
+		}
+	}
+}
+
+// carryState makes m share from's breaker and copies its handshake and status. m
+// must not be in use yet.
+func (m *BYOMModel) carryState(from *BYOMModel) {
+	from.mu.Lock()
+	info, handshakeAt, status := from.info, from.handshakeAt, from.status
+	from.mu.Unlock()
+
+	m.breaker = from.breaker
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.info, m.handshakeAt, m.status = info, handshakeAt, status
+}
+
+func clampNonNegative(values []float64) {
+	for i := range values {
+		if values[i] < 0 {
+			values[i] = 0
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	byompb "github.com/HatiCode/kedastral/pkg/api/byom"
+	kedastraltls "github.com/HatiCode/kedastral/pkg/tls"
+)
+
+// byomGRPCConns shares one client connection per target and TLS configuration, so
+// the fresh model instances built for each background training pass do not each
+// open (and leak) their own connection.
+var byomGRPCConns = struct {
+	sync.Mutex
+	conns map[kedastraltls.Config]map[string]*grpc.ClientConn
+}{conns: make(map[kedastraltls.Config]map[string]*grpc.ClientConn)}
+
+// byomGRPCTransport speaks the gRPC form of the BYOM contract (pkg/api/byom). Features
+// are sent column by column as packed doubles, which is far smaller and faster to
+// encode than the row-oriented JSON of the HTTP transport for long windows. Call
+// deadlines come from the context and are propagated to the service by gRPC.
+type byomGRPCTransport struct {
+	client       byompb.BYOMClient
+	healthClient healthpb.HealthClient
+}
+
+func newBYOMGRPCTransport(target string, tlsCfg kedastraltls.Config) (*byomGRPCTransport, error) {
+	conn, err := byomGRPCConn(target, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+	return &byomGRPCTransport{
+		client:       byompb.NewBYOMClient(conn),
+		healthClient: healthpb.NewHealthClient(conn),
+	}, nil
+}
+
+// byomGRPCConn returns the shared connection for target, creating it on first use.
+func byomGRPCConn(target string, tlsCfg kedastraltls.Config) (*grpc.ClientConn, error) {
+	byomGRPCConns.Lock()
+	defer byomGRPCConns.Unlock()
+
+	if conn, ok := byomGRPCConns.conns[tlsCfg][target]; ok {
+		return conn, nil
+	}
+
+	creds := insecure.NewCredentials()
+	if tlsCfg.Enabled {
+		clientTLS, err := kedastraltls.NewClientTLSConfig(tlsCfg.CertFile, tlsCfg.KeyFile, tlsCfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("byom: tls: %w", err)
+		}
+		creds = credentials.NewTLS(clientTLS)
+	}
+
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("byom: grpc client for %q: %w", target, err)
+	}
+	if byomGRPCConns.conns[tlsCfg] == nil {
+		byomGRPCConns.conns[tlsCfg] = make(map[string]*grpc.ClientConn)
+	}
+	byomGRPCConns.conns[tlsCfg][target] = conn
+	return conn, nil
+}
+
+func (t *byomGRPCTransport) health(ctx context.Context) error {
+	resp, err := t.healthClient.Check(outgoing(ctx), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return grpcError(err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("service status %s", resp.GetStatus())
+	}
+	return nil
+}
+
+func (t *byomGRPCTransport) info(ctx context.Context) (BYOMInfo, error) {
+	resp, err := t.client.Info(outgoing(ctx), &byompb.InfoRequest{})
+	if err != nil {
+		return BYOMInfo{}, grpcError(err)
+	}
+
+	info := BYOMInfo{
+		Protocol:          resp.GetProtocol(),
+		Name:              resp.GetName(),
+		MaxHorizonSeconds: int(resp.GetMaxHorizonSeconds()),
+		Features:          resp.GetFeatures(),
+	}
+	for _, step := range resp.GetStepSeconds() {
+		info.StepSeconds = append(info.StepSeconds, int(step))
+	}
+	return info, nil
+}
+
+func (t *byomGRPCTransport) train(ctx context.Context, in byomInput) error {
+	if _, err := t.client.Train(outgoing(ctx), forecastRequest(in)); err != nil {
+		return fmt.Errorf("byom: grpc: %w", grpcError(err))
+	}
+	return nil
+}
+
+func (t *byomGRPCTransport) predict(ctx context.Context, in byomInput) (byomOutput, error) {
+	resp, err := t.client.Predict(outgoing(ctx), forecastRequest(in))
+	if err != nil {
+		return byomOutput{}, fmt.Errorf("byom: grpc: %w", grpcError(err))
+	}
+
+	out := byomOutput{Values: resp.GetValues()}
+	if len(resp.GetQuantiles()) > 0 {
+		out.Quantiles = make(map[float64][]float64, len(resp.GetQuantiles()))
+		for _, q := range resp.GetQuantiles() {
+			out.Quantiles[q.GetLevel()] = q.GetValues()
+		}
+	}
+	return out, nil
+}
+
+// forecastRequest converts in to its columnar protobuf form. Columns are sorted by
+// name; rows missing a feature get NaN in that column.
+func forecastRequest(in byomInput) *byompb.ForecastRequest {
+	rows := in.Features.Rows
+
+	names := make(map[string]struct{})
+	for _, row := range rows {
+		for k := range row {
+			names[k] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(names))
+	for k := range names {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	columns := make([]*byompb.Column, len(sorted))
+	for i, name := range sorted {
+		values := make([]float64, len(rows))
+		for j, row := range rows {
+			v, ok := row[name]
+			if !ok {
+				v = math.NaN()
+			}
+			values[j] = v
+		}
+		columns[i] = &byompb.Column{Name: name, Values: values}
+	}
+
+	return &byompb.ForecastRequest{
+		Protocol:       BYOMProtocolVersion,
+		Workload:       in.Workload,
+		Policy:         in.Policy,
+		NowUnix:        in.Now.Unix(),
+		HorizonSeconds: int32(in.HorizonSeconds),
+		StepSeconds:    int32(in.StepSeconds),
+		Features:       columns,
+	}
+}
+
+// outgoing attaches the call's auth headers to ctx as gRPC metadata. Keys are
+// lowercased, as gRPC requires.
+func outgoing(ctx context.Context) context.Context {
+	headers := byomHeaders(ctx)
+	if len(headers) == 0 {
+		return ctx
+	}
+	pairs := make([]string, 0, 2*len(headers))
+	for k, v := range headers {
+		pairs = append(pairs, strings.ToLower(k), v)
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// grpcError maps UNIMPLEMENTED to errBYOMNotImplemented for the optional RPCs and
+// marks UNAVAILABLE, RESOURCE_EXHAUSTED, and ABORTED as transient.
+func grpcError(err error) error {
+	switch status.Code(err) {
+	case codes.Unimplemented:
+		return errBYOMNotImplemented
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return transient(err)
+	}
+	return err
+}
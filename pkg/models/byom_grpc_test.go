@@ -0,0 +1,177 @@
+package models
+
+import (
+	"context"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	byompb "github.com/HatiCode/kedastral/pkg/api/byom"
+	kedastraltls "github.com/HatiCode/kedastral/pkg/tls"
+)
+
+// fakeBYOMServer is an in-process gRPC BYOM service that records the last request.
+type fakeBYOMServer struct {
+	byompb.UnimplementedBYOMServer
+
+	info        *byompb.InfoResponse
+	last        *byompb.ForecastRequest
+	hadDeadline bool
+	md          metadata.MD
+	trains      int
+}
+
+func (s *fakeBYOMServer) Info(ctx context.Context, req *byompb.InfoRequest) (*byompb.InfoResponse, error) {
+	if s.info == nil {
+		return s.UnimplementedBYOMServer.Info(ctx, req)
+	}
+	return s.info, nil
+}
+
+func (s *fakeBYOMServer) Train(_ context.Context, req *byompb.ForecastRequest) (*byompb.TrainResponse, error) {
+	s.trains++
+	s.last = req
+	return &byompb.TrainResponse{}, nil
+}
+
+func (s *fakeBYOMServer) Predict(ctx context.Context, req *byompb.ForecastRequest) (*byompb.PredictResponse, error) {
+	s.last = req
+	_, s.hadDeadline = ctx.Deadline()
+	s.md, _ = metadata.FromIncomingContext(ctx)
+
+	n := int(req.GetHorizonSeconds() / req.GetStepSeconds())
+	values := make([]float64, n)
+	upper := make([]float64, n)
+	for i := range values {
+		values[i] = 100
+		upper[i] = 130
+	}
+	return &byompb.PredictResponse{
+		Values:    values,
+		Quantiles: []*byompb.Quantile{{Level: 0.9, Values: upper}},
+	}, nil
+}
+
+func startFakeBYOMServer(t *testing.T, srv *fakeBYOMServer) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	server := grpc.NewServer()
+	byompb.RegisterBYOMServer(server, srv)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return BYOMGRPCScheme + lis.Addr().String()
+}
+
+func TestBYOMModel_GRPC_Predict(t *testing.T) {
+	srv := &fakeBYOMServer{}
+	model := NewBYOMModel(startFakeBYOMServer(t, srv), "test_metric", 60, 1800).
+		WithIdentity("shop-web", "shop/web").
+		WithAuth(BYOMAuth{Token: "s3cret", Headers: map[string]string{"X-Tenant": "shop"}})
+
+	features := FeatureFrame{Rows: []map[string]float64{
+		{"timestamp": 1609459200, "value": 100, "hour": 0},
+		{"timestamp": 1609459260, "value": 105},
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	forecast, err := model.Predict(ctx, features)
+	if err != nil {
+		t.Fatalf("Predict failed: %v", err)
+	}
+
+	if len(forecast.Values) != 30 || forecast.Values[0] != 100 {
+		t.Errorf("unexpected values: %v", forecast.Values)
+	}
+	if p90 := forecast.Quantiles[0.9]; len(p90) != 30 || p90[0] != 130 {
+		t.Errorf("unexpected p90: %v", p90)
+	}
+	if !srv.hadDeadline {
+		t.Error("expected the context deadline to reach the service")
+	}
+	if got := srv.md.Get("authorization"); len(got) != 1 || got[0] != "Bearer s3cret" {
+		t.Errorf("authorization metadata = %v, want [Bearer s3cret]", got)
+	}
+	if got := srv.md.Get("x-tenant"); len(got) != 1 || got[0] != "shop" {
+		t.Errorf("x-tenant metadata = %v, want [shop]", got)
+	}
+
+	req := srv.last
+	if req.GetWorkload() != "shop-web" || req.GetPolicy() != "shop/web" || req.GetProtocol() != BYOMProtocolVersion {
+		t.Errorf("unexpected request identity: %v", req)
+	}
+	columns := make(map[string][]float64)
+	for _, c := range req.GetFeatures() {
+		columns[c.GetName()] = c.GetValues()
+	}
+	if got := columns["value"]; len(got) != 2 || got[1] != 105 {
+		t.Errorf("value column = %v, want [100 105]", got)
+	}
+	if got := columns["hour"]; len(got) != 2 || got[0] != 0 || !math.IsNaN(got[1]) {
+		t.Errorf("hour column = %v, want [0 NaN]", got)
+	}
+}
+
+func TestBYOMModel_GRPC_Train(t *testing.T) {
+	tests := []struct {
+		name       string
+		info       *byompb.InfoResponse
+		wantTrains int
+	}{
+		{"predict only", nil, 0},
+		{"train", &byompb.InfoResponse{Protocol: BYOMProtocolVersion, Name: "fake", Features: []string{BYOMFeatureTrain}}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &fakeBYOMServer{info: tt.info}
+			model := NewBYOMModel(startFakeBYOMServer(t, srv), "test_metric", 60, 1800)
+
+			// The fake does not register the health service, so the health check is
+			// treated as not implemented.
+			if err := model.Train(context.Background(), FeatureFrame{Rows: []map[string]float64{{"value": 1}}}); err != nil {
+				t.Fatalf("Train failed: %v", err)
+			}
+			if srv.trains != tt.wantTrains {
+				t.Errorf("Train RPCs = %d, want %d", srv.trains, tt.wantTrains)
+			}
+			if status := model.Status(); !status.Healthy {
+				t.Errorf("expected healthy status, got %+v", status)
+			}
+		})
+	}
+}
+
+func TestBYOMModel_GRPC_IncompatibleStep(t *testing.T) {
+	srv := &fakeBYOMServer{info: &byompb.InfoResponse{Protocol: BYOMProtocolVersion, StepSeconds: []int32{300}}}
+	model := NewBYOMModel(startFakeBYOMServer(t, srv), "test_metric", 60, 1800)
+
+	if err := model.Train(context.Background(), FeatureFrame{Rows: []map[string]float64{{"value": 1}}}); err == nil {
+		t.Fatal("expected error for unsupported step, got nil")
+	}
+}
+
+func TestBYOMModel_GRPC_TLSError(t *testing.T) {
+	model := NewBYOMModel(BYOMGRPCScheme+"127.0.0.1:1", "test_metric", 60, 1800)
+	model.WithTLS(kedastraltls.Config{
+		Enabled:  true,
+		CertFile: "/nonexistent/cert.pem",
+		KeyFile:  "/nonexistent/key.pem",
+		CAFile:   "/nonexistent/ca.pem",
+	})
+
+	_, err := model.Predict(context.Background(), FeatureFrame{Rows: []map[string]float64{{"value": 1}}})
+	if err == nil {
+		t.Fatal("expected TLS setup error, got nil")
+	}
+	if status := model.Status(); status.Healthy || status.LastError == "" {
+		t.Errorf("expected unhealthy status, got %+v", status)
+	}
+}
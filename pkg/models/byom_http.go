@@ -0,0 +1,210 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	kedastraltls "github.com/HatiCode/kedastral/pkg/tls"
+)
+
+// byomHTTPTransport speaks the HTTP/JSON form of the BYOM contract.
+type byomHTTPTransport struct {
+	endpoint string
+	baseURL  string
+	client   *http.Client
+}
+
+type byomRequest struct {
+	Protocol       string           `json:"protocol"`
+	Workload       string           `json:"workload,omitempty"`
+	Policy         string           `json:"policy,omitempty"`
+	Now            string           `json:"now"`
+	HorizonSeconds int              `json:"horizonSeconds"`
+	StepSeconds    int              `json:"stepSeconds"`
+	Features       []map[string]any `json:"features"`
+}
+
+type byomResponse struct {
+	Metric    string               `json:"metric"`
+	Values    []float64            `json:"values"`
+	Quantiles map[string][]float64 `json:"quantiles,omitempty"`
+}
+
+func newBYOMHTTPTransport(endpoint string, tlsCfg kedastraltls.Config) (*byomHTTPTransport, error) {
+	transport := &http.Transport{
+		MaxIdleConns:        10,
+		IdleConnTimeout:     90 * time.Second,
+		DisableCompression:  false,
+		DisableKeepAlives:   false,
+		MaxIdleConnsPerHost: 2,
+	}
+	if tlsCfg.Enabled {
+		clientTLS, err := kedastraltls.NewClientTLSConfig(tlsCfg.CertFile, tlsCfg.KeyFile, tlsCfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("byom: tls: %w", err)
+		}
+		transport.TLSClientConfig = clientTLS
+	}
+
+	return &byomHTTPTransport{
+		endpoint: endpoint,
+		baseURL:  strings.TrimSuffix(strings.TrimSuffix(endpoint, "/"), "/predict"),
+		client: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: transport,
+		},
+	}, nil
+}
+
+func (t *byomHTTPTransport) health(ctx context.Context) error {
+	return t.get(ctx, "/healthz", nil)
+}
+
+func (t *byomHTTPTransport) info(ctx context.Context) (BYOMInfo, error) {
+	var info BYOMInfo
+	err := t.get(ctx, "/info", &info)
+	return info, err
+}
+
+func (t *byomHTTPTransport) train(ctx context.Context, in byomInput) error {
+	resp, err := t.post(ctx, t.baseURL+"/train", in)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (t *byomHTTPTransport) predict(ctx context.Context, in byomInput) (byomOutput, error) {
+	resp, err := t.post(ctx, t.endpoint, in)
+	if err != nil {
+		return byomOutput{}, err
+	}
+	defer resp.Body.Close()
+
+	var byomResp byomResponse
+	if err := json.NewDecoder(resp.Body).Decode(&byomResp); err != nil {
+		return byomOutput{}, fmt.Errorf("byom: decode response: %w", err)
+	}
+
+	out := byomOutput{Values: byomResp.Values}
+	if len(byomResp.Quantiles) > 0 {
+		out.Quantiles = make(map[float64][]float64, len(byomResp.Quantiles))
+		for key, values := range byomResp.Quantiles {
+			level, err := strconv.ParseFloat(key, 64)
+			if err != nil {
+				return byomOutput{}, fmt.Errorf("byom: invalid quantile level %q", key)
+			}
+			out.Quantiles[level] = values
+		}
+	}
+	return out, nil
+}
+
+// post sends in to url in the request format shared by /predict and /train,
+// returning the response when its status is 200 OK.
+func (t *byomHTTPTransport) post(ctx context.Context, url string, in byomInput) (*http.Response, error) {
+	reqFeatures := make([]map[string]any, len(in.Features.Rows))
+	for i, row := range in.Features.Rows {
+		feature := make(map[string]any)
+		for k, v := range row {
+			feature[k] = v
+		}
+		reqFeatures[i] = feature
+	}
+
+	req := byomRequest{
+		Protocol:       BYOMProtocolVersion,
+		Workload:       in.Workload,
+		Policy:         in.Policy,
+		Now:            in.Now.Format(time.RFC3339),
+		HorizonSeconds: in.HorizonSeconds,
+		StepSeconds:    in.StepSeconds,
+		Features:       reqFeatures,
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("byom: marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("byom: create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	setHeaders(httpReq, byomHeaders(ctx))
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		return nil, requestError(ctx, fmt.Errorf("byom: http request failed: %w", err))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, statusError(resp.StatusCode, fmt.Errorf("byom: http %d: %s", resp.StatusCode, string(bodyBytes)))
+	}
+	return resp, nil
+}
+
+// get calls GET path on the service and decodes the JSON body into out when out is
+// not nil. A 404 or 405 response is reported as errBYOMNotImplemented.
+func (t *byomHTTPTransport) get(ctx context.Context, path string, out any) error {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", t.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	setHeaders(httpReq, byomHeaders(ctx))
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		return requestError(ctx, fmt.Errorf("http request failed: %w", err))
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed:
+		return errBYOMNotImplemented
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return statusError(resp.StatusCode, fmt.Errorf("http %d: %s", resp.StatusCode, string(bodyBytes)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func setHeaders(req *http.Request, headers map[string]string) {
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+}
+
+// requestError marks a failed request as transient unless ctx is done.
+func requestError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+	return transient(err)
+}
+
+// statusError marks err as transient for 429 and 5xx responses.
+func statusError(code int, err error) error {
+	if code == http.StatusTooManyRequests || code >= 500 {
+		return transient(err)
+	}
+	return err
+}
@@ -0,0 +1,202 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrBYOMCircuitOpen is returned without contacting the service while a BYOM model's
+// circuit breaker is open, so callers can fall back immediately instead of waiting
+// for another timeout.
+var ErrBYOMCircuitOpen = errors.New("byom: circuit open")
+
+// BYOMAuth holds the credentials sent with every request to a BYOM service, as HTTP
+// headers or gRPC metadata.
+type BYOMAuth struct {
+	// Token is sent as "Authorization: Bearer <token>".
+	Token string
+	// TokenFile is read on every request for the bearer token, so a rotated Secret
+	// mounted as a file is picked up without a restart. It takes precedence over Token.
+	TokenFile string
+	// Headers are sent as additional request headers.
+	Headers map[string]string
+}
+
+// headers returns the request headers for a, reading TokenFile if set.
+func (a BYOMAuth) headers() (map[string]string, error) {
+	token := a.Token
+	if a.TokenFile != "" {
+		data, err := os.ReadFile(a.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token == "" && len(a.Headers) == 0 {
+		return nil, nil
+	}
+
+	headers := make(map[string]string, len(a.Headers)+1)
+	for k, v := range a.Headers {
+		headers[k] = v
+	}
+	if token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+	return headers, nil
+}
+
+// byomHeadersKey carries a call's auth headers to the transport through its context.
+type byomHeadersKey struct{}
+
+func withBYOMHeaders(ctx context.Context, headers map[string]string) context.Context {
+	if len(headers) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, byomHeadersKey{}, headers)
+}
+
+func byomHeaders(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(byomHeadersKey{}).(map[string]string)
+	return headers
+}
+
+// BYOMResilience bounds retries of failed calls and configures the circuit breaker
+// of a BYOM model.
+type BYOMResilience struct {
+	// MaxRetries is the number of times a call failing with a transient error
+	// (connection failure, HTTP 429 or 5xx, gRPC UNAVAILABLE) is retried. 0 disables
+	// retries.
+	MaxRetries int
+	// RetryBackoff is the base delay before the first retry. It doubles on every
+	// retry, and each delay is drawn uniformly from [0, backoff) so that workloads
+	// sharing a service do not retry in lockstep.
+	RetryBackoff time.Duration
+	// BreakerThreshold is the number of consecutive failed calls that opens the
+	// circuit. 0 disables the breaker.
+	BreakerThreshold int
+	// BreakerCooldown is how long the circuit stays open before a single trial call
+	// is let through.
+	BreakerCooldown time.Duration
+}
+
+// DefaultBYOMResilience is the retry and circuit breaker configuration of a new BYOM model.
+var DefaultBYOMResilience = BYOMResilience{
+	MaxRetries:       2,
+	RetryBackoff:     100 * time.Millisecond,
+	BreakerThreshold: 5,
+	BreakerCooldown:  30 * time.Second,
+}
+
+// byomTransientError marks a failure worth retrying.
+type byomTransientError struct{ err error }
+
+func (e byomTransientError) Error() string { return e.err.Error() }
+func (e byomTransientError) Unwrap() error { return e.err }
+
+func transient(err error) error {
+	return byomTransientError{err: err}
+}
+
+func isTransient(err error) bool {
+	var t byomTransientError
+	return errors.As(err, &t)
+}
+
+// retry calls call until it succeeds, fails with a non-transient error, or has been
+// retried r.MaxRetries times, sleeping with jittered exponential backoff in between.
+// It gives up early, returning the last error, when ctx is done.
+func (r BYOMResilience) retry(ctx context.Context, call func() error) error {
+	backoff := r.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= r.MaxRetries || !isTransient(err) {
+			return err
+		}
+
+		var delay time.Duration
+		if backoff > 0 {
+			delay = rand.N(backoff)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// byomBreaker is a consecutive-failure circuit breaker. After threshold failed calls
+// in a row it opens and rejects calls for cooldown, then lets a single trial call
+// through: success closes the circuit, failure keeps it open for another cooldown.
+type byomBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// allow returns ErrBYOMCircuitOpen if a call must not contact the service.
+func (b *byomBreaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openedAt.IsZero() {
+		return nil
+	}
+	if b.trial || time.Since(b.openedAt) < b.cooldown {
+		return ErrBYOMCircuitOpen
+	}
+	b.trial = true
+	return nil
+}
+
+// success records a successful call, closing the circuit.
+func (b *byomBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openedAt = time.Time{}
+	b.trial = false
+}
+
+// failure records a failed call, opening the circuit at the threshold or when the
+// trial call failed.
+func (b *byomBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.threshold > 0 && (b.trial || b.failures >= b.threshold) {
+		b.openedAt = time.Now()
+	}
+	b.trial = false
+}
+
+// release ends a trial call that finished without contacting the service, so the
+// next call can be the trial instead.
+func (b *byomBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+// open reports whether the circuit is open.
+func (b *byomBreaker) open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.openedAt.IsZero()
+}
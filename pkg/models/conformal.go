@@ -0,0 +1,402 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// DefaultConformalLevels are the quantile levels a ConformalModel produces when none
+// are configured. They match the levels the built-in models emit.
+var DefaultConformalLevels = []float64{0.50, 0.75, 0.90, 0.95}
+
+// ConformalOptions configures a ConformalModel.
+type ConformalOptions struct {
+	// Window is the number of most recent out-of-sample errors kept per horizon step.
+	// Defaults to 100.
+	Window int
+
+	// MinSamples is the number of errors a horizon step needs before its quantiles are
+	// calibrated. Defaults to 10.
+	MinSamples int
+
+	// Levels are the quantile levels to produce. Defaults to DefaultConformalLevels.
+	Levels []float64
+}
+
+// ConformalModel wraps any Model and replaces its quantiles with horizon-specific
+// empirical quantiles computed by split conformal prediction.
+//
+// Every forecast is remembered. As later Predict calls bring in observations for
+// the times it covered, the signed error (actual - predicted) is recorded for each
+// horizon step. The quantile at level q for step h is the point forecast plus the
+// finite-sample-corrected q-quantile of the recent errors at step h, so intervals
+// widen with horizon exactly as much as the inner model's errors do, and are
+// asymmetric when the model is biased.
+//
+// Steps without MinSamples errors yet keep the inner model's quantile when it has
+// one, and otherwise borrow the offset of the nearest calibrated shorter step.
+// Until any step is calibrated the inner forecast is returned unchanged.
+//
+// Values[h] is taken to forecast the time of the newest observation plus h+1 steps,
+// as the built-in models do. Observations are read from the "timestamp"
+// and "value" features, so frames without timestamps are passed through uncalibrated.
+//
+// ConformalModel is safe for concurrent use if the inner model is.
+type ConformalModel struct {
+	inner   Model
+	stepSec int
+	opts    ConformalOptions
+
+	mu        sync.Mutex
+	residuals [][]float64
+	pending   []pendingForecast
+	hits      map[float64][]bool
+}
+
+// pendingForecast is a forecast awaiting observations to score against.
+type pendingForecast struct {
+	Origin    float64               `json:"origin"`
+	Values    []float64             `json:"values"`
+	Quantiles map[float64][]float64 `json:"quantiles,omitempty"`
+	Scored    int                   `json:"scored"`
+}
+
+// NewConformalModel wraps inner with conformal quantiles for a forecast step of
+// stepSec seconds.
+func NewConformalModel(inner Model, stepSec int, opts ConformalOptions) *ConformalModel {
+	if opts.Window <= 0 {
+		opts.Window = 100
+	}
+	if opts.MinSamples <= 0 {
+		opts.MinSamples = 10
+	}
+	if len(opts.Levels) == 0 {
+		opts.Levels = DefaultConformalLevels
+	}
+	opts.Levels = append([]float64(nil), opts.Levels...)
+	sort.Float64s(opts.Levels)
+
+	return &ConformalModel{
+		inner:   inner,
+		stepSec: stepSec,
+		opts:    opts,
+		hits:    make(map[float64][]bool),
+	}
+}
+
+// Name returns the inner model's name wrapped in "conformal(...)".
+func (m *ConformalModel) Name() string {
+	return fmt.Sprintf("conformal(%s)", m.inner.Name())
+}
+
+// Inner returns the wrapped model.
+func (m *ConformalModel) Inner() Model {
+	return m.inner
+}
+
+// Train trains the inner model. Calibration state is unaffected, since it is built
+// only from out-of-sample errors.
+func (m *ConformalModel) Train(ctx context.Context, history FeatureFrame) error {
+	return m.inner.Train(ctx, history)
+}
+
+// Predict scores earlier forecasts against the observations in features, asks the
+// inner model for a forecast, and attaches calibrated quantiles to it.
+func (m *ConformalModel) Predict(ctx context.Context, features FeatureFrame) (Forecast, error) {
+	origin, ok := m.observe(features)
+
+	forecast, err := m.inner.Predict(ctx, features)
+	if err != nil || !ok {
+		return forecast, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if quantiles := m.calibratedQuantiles(forecast); quantiles != nil {
+		forecast.Quantiles = quantiles
+	}
+
+	m.pending = append(m.pending, pendingForecast{
+		Origin:    origin,
+		Values:    append([]float64(nil), forecast.Values...),
+		Quantiles: copyQuantiles(forecast.Quantiles),
+	})
+	return forecast, nil
+}
+
+// Coverage returns, for each level, the fraction of recent scored observations that
+// fell at or below the quantile forecast for them. A well-calibrated model reports
+// coverage close to each level. Levels with no scored observations are omitted.
+func (m *ConformalModel) Coverage() map[float64]float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	coverage := make(map[float64]float64, len(m.hits))
+	for level, hits := range m.hits {
+		if len(hits) == 0 {
+			continue
+		}
+		covered := 0
+		for _, hit := range hits {
+			if hit {
+				covered++
+			}
+		}
+		coverage[level] = float64(covered) / float64(len(hits))
+	}
+	return coverage
+}
+
+// observe scores pending forecasts against new observations in frame and returns the
+// timestamp of the newest observation, which is the origin of the next forecast.
+func (m *ConformalModel) observe(frame FeatureFrame) (float64, bool) {
+	actuals := make(map[int64]float64, len(frame.Rows))
+	newest := math.Inf(-1)
+	for _, row := range frame.Rows {
+		ts, okTS := row["timestamp"]
+		value, okValue := row["value"]
+		if !okTS || !okValue {
+			continue
+		}
+		actuals[int64(math.Round(ts))] = value
+		newest = math.Max(newest, ts)
+	}
+	if len(actuals) == 0 {
+		return 0, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	step := float64(m.stepSec)
+	kept := m.pending[:0]
+	for _, p := range m.pending {
+		for p.Scored < len(p.Values) {
+			target := p.Origin + float64(p.Scored+1)*step
+			if target > newest {
+				break
+			}
+			if actual, ok := actuals[int64(math.Round(target))]; ok {
+				m.record(p, p.Scored, actual)
+			}
+			p.Scored++
+		}
+		if p.Scored < len(p.Values) {
+			kept = append(kept, p)
+		}
+	}
+	m.pending = kept
+
+	return newest, true
+}
+
+// record stores the error of forecast p at step h and updates coverage. The caller
+// must hold m.mu.
+func (m *ConformalModel) record(p pendingForecast, h int, actual float64) {
+	for len(m.residuals) <= h {
+		m.residuals = append(m.residuals, nil)
+	}
+	m.residuals[h] = appendBounded(m.residuals[h], actual-p.Values[h], m.opts.Window)
+
+	for _, level := range m.opts.Levels {
+		q, ok := p.Quantiles[level]
+		if !ok || h >= len(q) {
+			continue
+		}
+		m.hits[level] = appendBounded(m.hits[level], actual <= q[h], m.opts.Window)
+	}
+}
+
+// calibratedQuantiles builds conformal quantiles for forecast, or nil if no horizon
+// step is calibrated yet. The caller must hold m.mu.
+func (m *ConformalModel) calibratedQuantiles(forecast Forecast) map[float64][]float64 {
+	n := len(forecast.Values)
+	calibrated := false
+	offsets := make(map[float64][]float64, len(m.opts.Levels))
+	for _, level := range m.opts.Levels {
+		offsets[level] = make([]float64, n)
+	}
+
+	known := make([]bool, n)
+	for h := 0; h < n && h < len(m.residuals); h++ {
+		if len(m.residuals[h]) < m.opts.MinSamples {
+			continue
+		}
+		sorted := append([]float64(nil), m.residuals[h]...)
+		sort.Float64s(sorted)
+		for _, level := range m.opts.Levels {
+			offsets[level][h] = conformalQuantile(sorted, level)
+		}
+		known[h] = true
+		calibrated = true
+	}
+	if !calibrated {
+		return nil
+	}
+
+	quantiles := make(map[float64][]float64, len(m.opts.Levels))
+	for _, level := range m.opts.Levels {
+		values := make([]float64, n)
+		innerValues := forecast.Quantiles[level]
+		lastKnown := -1
+		for h := range n {
+			switch {
+			case known[h]:
+				lastKnown = h
+				values[h] = forecast.Values[h] + offsets[level][h]
+			case len(innerValues) == n:
+				values[h] = innerValues[h]
+			case lastKnown >= 0:
+				values[h] = forecast.Values[h] + offsets[level][lastKnown]
+			default:
+				values[h] = forecast.Values[h]
+			}
+			values[h] = math.Max(0, values[h])
+		}
+		quantiles[level] = values
+	}
+
+	// Quantile levels must not cross; calibration noise on small windows can make a
+	// lower level exceed a higher one at the same step.
+	for i := 1; i < len(m.opts.Levels); i++ {
+		lower, upper := quantiles[m.opts.Levels[i-1]], quantiles[m.opts.Levels[i]]
+		for h := range upper {
+			upper[h] = math.Max(upper[h], lower[h])
+		}
+	}
+
+	return quantiles
+}
+
+// conformalQuantile returns the finite-sample-corrected level quantile of sorted:
+// the ceil((n+1)*level)-th smallest value, capped at the largest.
+func conformalQuantile(sorted []float64, level float64) float64 {
+	n := len(sorted)
+	rank := int(math.Ceil(float64(n+1)*level)) - 1
+	rank = max(0, min(rank, n-1))
+	return sorted[rank]
+}
+
+func appendBounded[T any](values []T, v T, limit int) []T {
+	values = append(values, v)
+	if len(values) > limit {
+		values = values[len(values)-limit:]
+	}
+	return values
+}
+
+func copyQuantiles(quantiles map[float64][]float64) map[float64][]float64 {
+	if len(quantiles) == 0 {
+		return nil
+	}
+	out := make(map[float64][]float64, len(quantiles))
+	for level, values := range quantiles {
+		out[level] = append([]float64(nil), values...)
+	}
+	return out
+}
+
+// CarryCalibration replaces the calibration of dst, a conformal calibrator about to
+// replace src, with src's current one, so the errors src recorded while dst was
+// being trained are kept. It is a no-op unless both are conformal calibrators.
+func CarryCalibration(dst, src Model) {
+	d, ok := dst.(*ConformalModel)
+	if !ok {
+		return
+	}
+	s, ok := src.(*ConformalModel)
+	if !ok || s == d {
+		return
+	}
+
+	s.mu.Lock()
+	residuals := make([][]float64, len(s.residuals))
+	for h, r := range s.residuals {
+		residuals[h] = slices.Clone(r)
+	}
+	pending := make([]pendingForecast, len(s.pending))
+	for i, p := range s.pending {
+		p.Values = slices.Clone(p.Values)
+		p.Quantiles = copyQuantiles(p.Quantiles)
+		pending[i] = p
+	}
+	hits := make(map[float64][]bool, len(s.hits))
+	for level, h := range s.hits {
+		hits[level] = slices.Clone(h)
+	}
+	s.mu.Unlock()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.residuals, d.pending, d.hits = residuals, pending, hits
+}
+
+// conformalState is the persisted form of a ConformalModel.
+type conformalState struct {
+	Inner     json.RawMessage    `json:"inner,omitempty"`
+	Residuals [][]float64        `json:"residuals,omitempty"`
+	Pending   []pendingForecast  `json:"pending,omitempty"`
+	Hits      map[float64][]bool `json:"hits,omitempty"`
+}
+
+// MarshalState serializes the calibration errors and pending forecasts together with
+// the inner model's state when the inner model is Persistable.
+func (m *ConformalModel) MarshalState() ([]byte, error) {
+	var s conformalState
+	if inner, ok := m.inner.(Persistable); ok {
+		data, err := inner.MarshalState()
+		switch {
+		case err == nil:
+			s.Inner = data
+		case !errors.Is(err, ErrNotTrained):
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	s.Residuals = m.residuals
+	s.Pending = m.pending
+	s.Hits = m.hits
+	data, err := marshalState(m.Name(), s)
+	m.mu.Unlock()
+
+	if s.Inner == nil && len(s.Residuals) == 0 && len(s.Pending) == 0 {
+		return nil, ErrNotTrained
+	}
+	return data, err
+}
+
+// UnmarshalState restores calibration state and, when present, the inner model's state.
+func (m *ConformalModel) UnmarshalState(data []byte) error {
+	var s conformalState
+	if err := unmarshalState(data, m.Name(), &s); err != nil {
+		return err
+	}
+
+	if len(s.Inner) > 0 {
+		inner, ok := m.inner.(Persistable)
+		if !ok {
+			return fmt.Errorf("inner model %s does not support state persistence", m.inner.Name())
+		}
+		if err := inner.UnmarshalState(s.Inner); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.residuals = s.Residuals
+	m.pending = s.Pending
+	m.hits = s.Hits
+	if m.hits == nil {
+		m.hits = make(map[float64][]bool)
+	}
+	return nil
+}
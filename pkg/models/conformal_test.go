@@ -0,0 +1,136 @@
+package models
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// lastValueModel forecasts the newest observed value for every step.
+type lastValueModel struct{ steps int }
+
+func (m *lastValueModel) Train(ctx context.Context, history FeatureFrame) error { return nil }
+
+func (m *lastValueModel) Predict(ctx context.Context, features FeatureFrame) (Forecast, error) {
+	last := features.Rows[len(features.Rows)-1]["value"]
+	values := make([]float64, m.steps)
+	for i := range values {
+		values[i] = last
+	}
+	return Forecast{Metric: "rps", Values: values, StepSec: 60, Horizon: 60 * m.steps}, nil
+}
+
+func (m *lastValueModel) Name() string { return "last" }
+
+// runConformal feeds the model one new observation per tick from series and returns
+// the last forecast.
+func runConformal(t *testing.T, m *ConformalModel, series func(i int) float64, ticks int) Forecast {
+	t.Helper()
+	var rows []map[string]float64
+	var forecast Forecast
+	for i := range ticks {
+		rows = append(rows, map[string]float64{"timestamp": float64(i * 60), "value": series(i)})
+		var err error
+		forecast, err = m.Predict(context.Background(), FeatureFrame{Rows: rows})
+		if err != nil {
+			t.Fatalf("Predict() error = %v", err)
+		}
+	}
+	return forecast
+}
+
+func TestConformal_HorizonSpecificQuantiles(t *testing.T) {
+	m := NewConformalModel(&lastValueModel{steps: 5}, 60, ConformalOptions{MinSamples: 5})
+
+	// A linear ramp makes the last-value forecast exactly h+1 units low at step h.
+	forecast := runConformal(t, m, func(i int) float64 { return float64(i) }, 40)
+
+	last := forecast.Values[0]
+	for h := range 5 {
+		got := forecast.Quantiles[0.5][h]
+		if want := last + float64(h+1); math.Abs(got-want) > 1e-9 {
+			t.Errorf("p50 at step %d = %v, want %v", h, got, want)
+		}
+	}
+}
+
+func TestConformal_CoverageIsCalibrated(t *testing.T) {
+	m := NewConformalModel(&lastValueModel{steps: 3}, 60, ConformalOptions{Window: 200, MinSamples: 20})
+
+	// Deterministic noise: a repeating permutation of 0..19 around 100.
+	noise := []float64{7, 13, 2, 18, 9, 0, 15, 4, 11, 19, 6, 1, 16, 10, 3, 14, 8, 17, 5, 12}
+	runConformal(t, m, func(i int) float64 { return 100 + noise[i%len(noise)] }, 400)
+
+	coverage := m.Coverage()
+	for _, level := range []float64{0.5, 0.9} {
+		got, ok := coverage[level]
+		if !ok {
+			t.Fatalf("no coverage reported for %v", level)
+		}
+		if math.Abs(got-level) > 0.1 {
+			t.Errorf("coverage at %v = %v, want within 0.1", level, got)
+		}
+	}
+}
+
+func TestConformal_QuantilesDoNotCross(t *testing.T) {
+	m := NewConformalModel(&lastValueModel{steps: 4}, 60, ConformalOptions{MinSamples: 3})
+	forecast := runConformal(t, m, func(i int) float64 { return float64((i * 37) % 11) }, 30)
+
+	levels := DefaultConformalLevels
+	for i := 1; i < len(levels); i++ {
+		lower, upper := forecast.Quantiles[levels[i-1]], forecast.Quantiles[levels[i]]
+		for h := range upper {
+			if upper[h] < lower[h] {
+				t.Errorf("step %d: q%v = %v below q%v = %v", h, levels[i], upper[h], levels[i-1], lower[h])
+			}
+		}
+	}
+}
+
+func TestConformal_UncalibratedPassesThrough(t *testing.T) {
+	m := NewConformalModel(&lastValueModel{steps: 3}, 60, ConformalOptions{})
+
+	forecast, err := m.Predict(context.Background(), FeatureFrame{Rows: []map[string]float64{{"value": 5}}})
+	if err != nil {
+		t.Fatalf("Predict() error = %v", err)
+	}
+	if forecast.Quantiles != nil {
+		t.Errorf("Quantiles = %v, want nil without calibration data", forecast.Quantiles)
+	}
+	if m.Name() != "conformal(last)" {
+		t.Errorf("Name() = %q", m.Name())
+	}
+}
+
+func TestConformal_PersistCalibration(t *testing.T) {
+	build := func() *ConformalModel {
+		return NewConformalModel(NewARIMAModel("rps", 60, 300, 1, 1, 1), 60, ConformalOptions{MinSamples: 5})
+	}
+
+	src := build()
+	if err := src.Train(context.Background(), trainingFrame(60)); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	runConformal(t, src, func(i int) float64 { return float64(100 + i%7) }, 30)
+
+	data, err := src.MarshalState()
+	if err != nil {
+		t.Fatalf("MarshalState() error = %v", err)
+	}
+
+	dst := build()
+	if err := dst.UnmarshalState(data); err != nil {
+		t.Fatalf("UnmarshalState() error = %v", err)
+	}
+
+	if len(dst.Coverage()) == 0 {
+		t.Error("restored model lost its coverage history")
+	}
+	if len(dst.residuals) != len(src.residuals) {
+		t.Errorf("restored %d residual horizons, want %d", len(dst.residuals), len(src.residuals))
+	}
+	if _, err := dst.Inner().Predict(context.Background(), FeatureFrame{}); err != nil {
+		t.Errorf("inner model state not restored: %v", err)
+	}
+}
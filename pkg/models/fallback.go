@@ -0,0 +1,238 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// DefaultFallbackMaxJump is the MaxJump of a FallbackModel when none is configured.
+const DefaultFallbackMaxJump = 10.0
+
+// FallbackOptions configures a FallbackModel.
+type FallbackOptions struct {
+	// MaxJump bounds how far a plausible forecast may rise above recent actuals: a
+	// forecast whose peak exceeds MaxJump times the peak observed value in the
+	// prediction frame is rejected. Defaults to DefaultFallbackMaxJump.
+	MaxJump float64
+}
+
+// FallbackModel tries an ordered chain of models, e.g. sarima → baseline →
+// last-value, and returns the first plausible forecast.
+//
+// Train trains every model in the chain and fails only if all of them fail. A model
+// whose latest training failed is skipped by Predict, as is a model whose
+// prediction fails or is implausible: NaN or infinite values, or a jump of more
+// than MaxJump times the recent actuals. The returned forecast's Model field names
+// the model that produced it.
+//
+// FallbackModel is safe for concurrent use if the chained models are.
+type FallbackModel struct {
+	models []Model
+	opts   FallbackOptions
+
+	mu       sync.Mutex
+	trainErr []error
+}
+
+// NewFallbackModel chains models, the first being the primary. It panics if models
+// is empty.
+func NewFallbackModel(models []Model, opts FallbackOptions) *FallbackModel {
+	if len(models) == 0 {
+		panic("models: fallback chain must not be empty")
+	}
+	if opts.MaxJump <= 0 {
+		opts.MaxJump = DefaultFallbackMaxJump
+	}
+	return &FallbackModel{
+		models:   append([]Model(nil), models...),
+		opts:     opts,
+		trainErr: make([]error, len(models)),
+	}
+}
+
+// Name returns the chained models' names wrapped in "fallback(...)".
+func (m *FallbackModel) Name() string {
+	names := make([]string, len(m.models))
+	for i, model := range m.models {
+		names[i] = model.Name()
+	}
+	return fmt.Sprintf("fallback(%s)", strings.Join(names, ","))
+}
+
+// Inner returns the primary model.
+func (m *FallbackModel) Inner() Model {
+	return m.models[0]
+}
+
+// Train trains every model in the chain, returning an error only if none succeeded.
+func (m *FallbackModel) Train(ctx context.Context, history FeatureFrame) error {
+	errs := make([]error, len(m.models))
+	trained := false
+	for i, model := range m.models {
+		if err := model.Train(ctx, history); err != nil {
+			errs[i] = fmt.Errorf("%s: %w", model.Name(), err)
+			continue
+		}
+		trained = true
+	}
+
+	m.mu.Lock()
+	m.trainErr = errs
+	m.mu.Unlock()
+
+	if !trained {
+		return fmt.Errorf("fallback: every model failed to train: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Predict returns the forecast of the first model in the chain that trained
+// successfully and produces a plausible forecast. If none does, the error lists
+// why each model was skipped.
+func (m *FallbackModel) Predict(ctx context.Context, features FeatureFrame) (Forecast, error) {
+	m.mu.Lock()
+	trainErr := append([]error(nil), m.trainErr...)
+	m.mu.Unlock()
+
+	var errs []error
+	for i, model := range m.models {
+		if trainErr[i] != nil {
+			errs = append(errs, fmt.Errorf("skipped after training failure: %w", trainErr[i]))
+			continue
+		}
+
+		forecast, err := model.Predict(ctx, features)
+		if err == nil {
+			err = m.checkPlausible(forecast, features)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", model.Name(), err))
+			continue
+		}
+
+		if forecast.Model == "" {
+			forecast.Model = model.Name()
+		}
+		return forecast, nil
+	}
+	return Forecast{}, fmt.Errorf("fallback: no model produced a forecast: %w", errors.Join(errs...))
+}
+
+// checkPlausible rejects forecasts with non-finite values or a peak more than
+// MaxJump times the peak observed value in features.
+func (m *FallbackModel) checkPlausible(forecast Forecast, features FeatureFrame) error {
+	if len(forecast.Values) == 0 {
+		return errors.New("empty forecast")
+	}
+
+	peak := 0.0
+	for _, v := range forecast.Values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("implausible forecast: non-finite value %v", v)
+		}
+		peak = max(peak, v)
+	}
+	for level, values := range forecast.Quantiles {
+		for _, v := range values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return fmt.Errorf("implausible forecast: non-finite quantile %v value %v", level, v)
+			}
+		}
+	}
+
+	observed := 0.0
+	for _, row := range features.Rows {
+		if v, ok := row["value"]; ok && !math.IsNaN(v) {
+			observed = max(observed, v)
+		}
+	}
+	if observed > 0 && peak > m.opts.MaxJump*observed {
+		return fmt.Errorf("implausible forecast: peak %.2f is more than %gx the recent peak %.2f", peak, m.opts.MaxJump, observed)
+	}
+	return nil
+}
+
+// fallbackState is the persisted form of a FallbackModel: the state of each
+// Persistable model in the chain, keyed by model name.
+type fallbackState struct {
+	Models map[string]json.RawMessage `json:"models"`
+}
+
+// MarshalState serializes the state of every trained Persistable model in the chain.
+func (m *FallbackModel) MarshalState() ([]byte, error) {
+	s := fallbackState{Models: make(map[string]json.RawMessage)}
+	for _, model := range m.models {
+		p, ok := model.(Persistable)
+		if !ok {
+			continue
+		}
+		data, err := p.MarshalState()
+		switch {
+		case err == nil:
+			s.Models[model.Name()] = data
+		case !errors.Is(err, ErrNotTrained):
+			return nil, err
+		}
+	}
+	if len(s.Models) == 0 {
+		return nil, ErrNotTrained
+	}
+	return marshalState(m.Name(), s)
+}
+
+// UnmarshalState restores the state of the chained models present in data and
+// clears their training failures, since restored models can predict. Every state is
+// checked and decoded before any is applied, so an error leaves the chain unchanged.
+func (m *FallbackModel) UnmarshalState(data []byte) error {
+	var s fallbackState
+	if err := unmarshalState(data, m.Name(), &s); err != nil {
+		return err
+	}
+
+	var (
+		restored []int
+		applies  []func()
+		others   []func() error
+	)
+	for i, model := range m.models {
+		state, ok := s.Models[model.Name()]
+		if !ok {
+			continue
+		}
+		switch p := model.(type) {
+		case stateDecoder:
+			apply, err := p.decodeState(state)
+			if err != nil {
+				return err
+			}
+			applies = append(applies, apply)
+		case Persistable:
+			// Models that cannot decode ahead are restored before the others, so
+			// only the first of them can fail once the chain starts changing.
+			others = append(others, func() error { return p.UnmarshalState(state) })
+		default:
+			return fmt.Errorf("model %s does not support state persistence", model.Name())
+		}
+		restored = append(restored, i)
+	}
+
+	for _, restore := range others {
+		if err := restore(); err != nil {
+			return err
+		}
+	}
+	for _, apply := range applies {
+		apply()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, i := range restored {
+		m.trainErr[i] = nil
+	}
+	return nil
+}
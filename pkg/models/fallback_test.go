@@ -0,0 +1,173 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// stubModel returns fixed values or errors.
+type stubModel struct {
+	name       string
+	values     []float64
+	trainErr   error
+	predictErr error
+	predicts   int
+}
+
+func (m *stubModel) Name() string { return m.name }
+
+func (m *stubModel) Train(ctx context.Context, history FeatureFrame) error { return m.trainErr }
+
+func (m *stubModel) Predict(ctx context.Context, features FeatureFrame) (Forecast, error) {
+	m.predicts++
+	if m.predictErr != nil {
+		return Forecast{}, m.predictErr
+	}
+	return Forecast{Metric: "rps", Values: append([]float64(nil), m.values...), StepSec: 60, Horizon: 60 * len(m.values)}, nil
+}
+
+var fallbackFrame = FeatureFrame{Rows: []map[string]float64{
+	{"timestamp": 0, "value": 90},
+	{"timestamp": 60, "value": 100},
+}}
+
+func TestFallback_Predict(t *testing.T) {
+	tests := []struct {
+		name      string
+		primary   *stubModel
+		wantModel string
+		wantValue float64
+	}{
+		{"primary", &stubModel{name: "sarima", values: []float64{110, 120}}, "sarima", 110},
+		{"prediction error", &stubModel{name: "sarima", predictErr: errors.New("singular matrix")}, "baseline", 105},
+		{"training error", &stubModel{name: "sarima", values: []float64{110, 120}, trainErr: errors.New("too little data")}, "baseline", 105},
+		{"NaN", &stubModel{name: "sarima", values: []float64{110, math.NaN()}}, "baseline", 105},
+		{"huge jump", &stubModel{name: "sarima", values: []float64{110, 5000}}, "baseline", 105},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewFallbackModel([]Model{tt.primary, &stubModel{name: "baseline", values: []float64{105, 105}}}, FallbackOptions{})
+			if err := m.Train(context.Background(), fallbackFrame); err != nil {
+				t.Fatalf("Train() error = %v", err)
+			}
+
+			forecast, err := m.Predict(context.Background(), fallbackFrame)
+			if err != nil {
+				t.Fatalf("Predict() error = %v", err)
+			}
+			if forecast.Model != tt.wantModel {
+				t.Errorf("Model = %q, want %q", forecast.Model, tt.wantModel)
+			}
+			if forecast.Values[0] != tt.wantValue {
+				t.Errorf("Values[0] = %v, want %v", forecast.Values[0], tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestFallback_SkipsModelAfterTrainingFailure(t *testing.T) {
+	primary := &stubModel{name: "sarima", values: []float64{110}, trainErr: errors.New("too little data")}
+	m := NewFallbackModel([]Model{primary, NewLastValueModel("rps", 60, 60)}, FallbackOptions{})
+
+	if err := m.Train(context.Background(), fallbackFrame); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	forecast, err := m.Predict(context.Background(), fallbackFrame)
+	if err != nil {
+		t.Fatalf("Predict() error = %v", err)
+	}
+	if primary.predicts != 0 {
+		t.Errorf("expected the untrained primary not to be asked, got %d predictions", primary.predicts)
+	}
+	if forecast.Model != "last-value" || forecast.Values[0] != 100 {
+		t.Errorf("forecast = %+v, want last-value 100", forecast)
+	}
+
+	// A later successful training pass brings the primary back.
+	primary.trainErr = nil
+	if err := m.Train(context.Background(), fallbackFrame); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	if forecast, _ := m.Predict(context.Background(), fallbackFrame); forecast.Model != "sarima" {
+		t.Errorf("Model = %q, want sarima after retraining", forecast.Model)
+	}
+}
+
+func TestFallback_AllFail(t *testing.T) {
+	m := NewFallbackModel([]Model{
+		&stubModel{name: "a", trainErr: errors.New("a broke")},
+		&stubModel{name: "b", trainErr: errors.New("b broke")},
+	}, FallbackOptions{})
+
+	err := m.Train(context.Background(), fallbackFrame)
+	if err == nil || !strings.Contains(err.Error(), "a broke") || !strings.Contains(err.Error(), "b broke") {
+		t.Errorf("Train() error = %v, want both failures", err)
+	}
+	if _, err := m.Predict(context.Background(), fallbackFrame); err == nil {
+		t.Error("expected Predict to fail when every model is skipped")
+	}
+}
+
+func TestFallback_PersistState(t *testing.T) {
+	newChain := func() *FallbackModel {
+		return NewFallbackModel([]Model{NewBaselineModel("rps", 60, 600), NewLastValueModel("rps", 60, 600)}, FallbackOptions{})
+	}
+
+	m := newChain()
+	if _, err := m.MarshalState(); !errors.Is(err, ErrNotTrained) {
+		t.Fatalf("MarshalState() before training error = %v, want ErrNotTrained", err)
+	}
+	if err := m.Train(context.Background(), trainingFrame(120)); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	data, err := m.MarshalState()
+	if err != nil {
+		t.Fatalf("MarshalState() error = %v", err)
+	}
+
+	restored := newChain()
+	if err := restored.UnmarshalState(data); err != nil {
+		t.Fatalf("UnmarshalState() error = %v", err)
+	}
+	other := NewFallbackModel([]Model{NewBaselineModel("rps", 60, 600)}, FallbackOptions{})
+	if err := other.UnmarshalState(data); err == nil {
+		t.Error("expected state for a different chain to be rejected")
+	}
+}
+
+func TestFallback_UnmarshalStateIsAtomic(t *testing.T) {
+	newChain := func() (*FallbackModel, *BaselineModel) {
+		baseline := NewBaselineModel("rps", 60, 600)
+		return NewFallbackModel([]Model{baseline, NewARIMAModel("rps", 60, 600, 1, 0, 0)}, FallbackOptions{}), baseline
+	}
+
+	trained := NewBaselineModel("rps", 60, 600)
+	if err := trained.Train(context.Background(), trainingFrame(120)); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	baselineState, err := trained.MarshalState()
+	if err != nil {
+		t.Fatalf("MarshalState() error = %v", err)
+	}
+
+	chain, baseline := newChain()
+	data, err := marshalState(chain.Name(), fallbackState{Models: map[string]json.RawMessage{
+		trained.Name():         baselineState,
+		chain.models[1].Name(): json.RawMessage(`{"version":99}`),
+	}})
+	if err != nil {
+		t.Fatalf("marshalState() error = %v", err)
+	}
+
+	if err := chain.UnmarshalState(data); err == nil {
+		t.Fatal("expected the invalid ARIMA state to be rejected")
+	}
+	if _, err := baseline.MarshalState(); !errors.Is(err, ErrNotTrained) {
+		t.Errorf("baseline MarshalState() error = %v, want ErrNotTrained: a failed restore must leave the chain unchanged", err)
+	}
+}
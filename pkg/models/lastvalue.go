@@ -0,0 +1,56 @@
+package models
+
+import (
+	"context"
+	"fmt"
+)
+
+// LastValueModel forecasts the most recent observation for every step of the
+// horizon. It needs no training and cannot fail on any frame with a value, which
+// makes it the natural last link of a fallback chain.
+type LastValueModel struct {
+	metric  string
+	stepSec int
+	horizon int
+}
+
+// NewLastValueModel creates a new last-value model.
+func NewLastValueModel(metric string, stepSec, horizon int) *LastValueModel {
+	return &LastValueModel{
+		metric:  metric,
+		stepSec: stepSec,
+		horizon: horizon,
+	}
+}
+
+// Name returns the model identifier.
+func (m *LastValueModel) Name() string {
+	return "last-value"
+}
+
+// Train is a no-op.
+func (m *LastValueModel) Train(ctx context.Context, history FeatureFrame) error {
+	return nil
+}
+
+// Predict repeats the newest "value" feature in features over the horizon.
+func (m *LastValueModel) Predict(ctx context.Context, features FeatureFrame) (Forecast, error) {
+	for i := len(features.Rows) - 1; i >= 0; i-- {
+		value, ok := features.Rows[i]["value"]
+		if !ok {
+			continue
+		}
+
+		values := make([]float64, m.horizon/m.stepSec)
+		for j := range values {
+			values[j] = max(value, 0)
+		}
+		return Forecast{
+			Metric:  m.metric,
+			Values:  values,
+			StepSec: m.stepSec,
+			Horizon: m.horizon,
+		}, nil
+	}
+	return Forecast{}, fmt.Errorf("no 'value' field found in features")
+}
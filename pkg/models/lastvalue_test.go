@@ -0,0 +1,25 @@
+package models
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLastValueModel_Predict(t *testing.T) {
+	m := NewLastValueModel("rps", 60, 300)
+	forecast, err := m.Predict(context.Background(), FeatureFrame{Rows: []map[string]float64{
+		{"value": 80},
+		{"value": 95},
+		{"hour": 3},
+	}})
+	if err != nil {
+		t.Fatalf("Predict() error = %v", err)
+	}
+	if len(forecast.Values) != 5 || forecast.Values[4] != 95 {
+		t.Errorf("Values = %v, want five 95s", forecast.Values)
+	}
+
+	if _, err := m.Predict(context.Background(), FeatureFrame{Rows: []map[string]float64{{"hour": 3}}}); err == nil {
+		t.Error("expected error without a value feature")
+	}
+}
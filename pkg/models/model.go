@@ -60,6 +60,10 @@ type Forecast struct {
 	//     0.95: []float64{130, 137, 144},  // 95th percentile
 	//   }
 	Quantiles map[float64][]float64
+
+	// Model is the name of the model that produced the forecast when it differs from
+	// the predicting model's own name, as set by FallbackModel. Empty otherwise.
+	Model string
 }
 
 // Model defines the interface for forecasting models.
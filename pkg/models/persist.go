@@ -0,0 +1,254 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// StateVersion is the current version of the serialized model state format.
+// It is bumped whenever a built-in model's state layout changes incompatibly;
+// UnmarshalState rejects other versions so a stale blob is retrained rather than
+// misread.
+const StateVersion = 1
+
+// ErrNotTrained is returned by MarshalState when a model has no trained state yet.
+var ErrNotTrained = errors.New("model not trained")
+
+// Persistable is implemented by models whose trained state can be saved and restored,
+// so a restarted or rebuilt forecaster can predict immediately instead of waiting for
+// a fresh training pass. It is optional: callers type-assert a Model to Persistable.
+//
+// The built-in baseline, ARIMA, and SARIMA models implement it. BYOM models do not,
+// since their state lives in the external service.
+type Persistable interface {
+	// MarshalState serializes the model's trained state.
+	// Returns ErrNotTrained if there is nothing to persist yet.
+	MarshalState() ([]byte, error)
+
+	// UnmarshalState restores trained state produced by MarshalState. It returns an
+	// error, leaving the model unchanged, if the state was written by a different
+	// model, a model with different parameters, or an unsupported StateVersion.
+	UnmarshalState(data []byte) error
+}
+
+// stateDecoder is implemented by the built-in models, so that a model composed of
+// several can decode all their states before applying any. decodeState leaves the
+// model unchanged and returns a function applying the decoded state.
+type stateDecoder interface {
+	decodeState(data []byte) (apply func(), err error)
+}
+
+// stateEnvelope wraps a model's state with the version and the model name (which
+// encodes its parameters, e.g. "arima(2,1,1)") that produced it.
+type stateEnvelope struct {
+	Version int             `json:"version"`
+	Model   string          `json:"model"`
+	State   json.RawMessage `json:"state"`
+}
+
+// marshalState encodes state in a versioned envelope for the named model.
+func marshalState(model string, state any) ([]byte, error) {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s state: %w", model, err)
+	}
+	return json.Marshal(stateEnvelope{Version: StateVersion, Model: model, State: raw})
+}
+
+// unmarshalState decodes data into state after checking the envelope's version and
+// model name.
+func unmarshalState(data []byte, model string, state any) error {
+	var env stateEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return fmt.Errorf("decode model state: %w", err)
+	}
+	if env.Version != StateVersion {
+		return fmt.Errorf("unsupported model state version %d (want %d)", env.Version, StateVersion)
+	}
+	if env.Model != model {
+		return fmt.Errorf("model state is for %q, not %q", env.Model, model)
+	}
+	if err := json.Unmarshal(env.State, state); err != nil {
+		return fmt.Errorf("decode %s state: %w", model, err)
+	}
+	return nil
+}
+
+// applyState decodes data with d and applies it.
+func applyState(d stateDecoder, data []byte) error {
+	apply, err := d.decodeState(data)
+	if err != nil {
+		return err
+	}
+	apply()
+	return nil
+}
+
+// baselineState is the persisted form of a BaselineModel.
+type baselineState struct {
+	Minute         map[int]patternState `json:"minute,omitempty"`
+	Hour           map[int]patternState `json:"hour,omitempty"`
+	ResidualStdDev float64              `json:"residualStdDev"`
+}
+
+type patternState struct {
+	Mean   float64 `json:"mean"`
+	Max    float64 `json:"max"`
+	Min    float64 `json:"min"`
+	Count  int     `json:"count"`
+	StdDev float64 `json:"stddev"`
+}
+
+func toPatternStates(patterns map[int]*seasonalPattern) map[int]patternState {
+	out := make(map[int]patternState, len(patterns))
+	for k, p := range patterns {
+		if p == nil {
+			continue
+		}
+		out[k] = patternState{Mean: p.mean, Max: p.max, Min: p.min, Count: p.count, StdDev: p.stddev}
+	}
+	return out
+}
+
+func fromPatternStates(states map[int]patternState) map[int]*seasonalPattern {
+	out := make(map[int]*seasonalPattern, len(states))
+	for k, s := range states {
+		out[k] = &seasonalPattern{mean: s.Mean, max: s.Max, min: s.Min, count: s.Count, stddev: s.StdDev}
+	}
+	return out
+}
+
+// MarshalState serializes the learned seasonal buckets and residual spread.
+func (m *BaselineModel) MarshalState() ([]byte, error) {
+	if len(m.minuteSeasonality) == 0 && len(m.hourSeasonality) == 0 && m.residualStdDev == 0 {
+		return nil, ErrNotTrained
+	}
+	return marshalState(m.Name(), baselineState{
+		Minute:         toPatternStates(m.minuteSeasonality),
+		Hour:           toPatternStates(m.hourSeasonality),
+		ResidualStdDev: m.residualStdDev,
+	})
+}
+
+// UnmarshalState restores seasonal buckets saved by MarshalState. Because Train only
+// replaces buckets observed in the current window, restored buckets for hours outside
+// the window keep contributing to predictions.
+func (m *BaselineModel) UnmarshalState(data []byte) error {
+	return applyState(m, data)
+}
+
+func (m *BaselineModel) decodeState(data []byte) (func(), error) {
+	var s baselineState
+	if err := unmarshalState(data, m.Name(), &s); err != nil {
+		return nil, err
+	}
+	return func() {
+		m.minuteSeasonality = fromPatternStates(s.Minute)
+		m.hourSeasonality = fromPatternStates(s.Hour)
+		m.residualStdDev = s.ResidualStdDev
+	}, nil
+}
+
+// arimaState is the persisted form of an ARIMAModel or SARIMAModel. Seasonal
+// coefficients are empty for ARIMA.
+type arimaState struct {
+	ARCoeffs         []float64 `json:"ar"`
+	MACoeffs         []float64 `json:"ma"`
+	SeasonalARCoeffs []float64 `json:"seasonalAR,omitempty"`
+	SeasonalMACoeffs []float64 `json:"seasonalMA,omitempty"`
+	Mean             float64   `json:"mean"`
+	LastValues       []float64 `json:"lastValues"`
+	LastErrors       []float64 `json:"lastErrors"`
+	ResidualStdDev   float64   `json:"residualStdDev"`
+}
+
+// MarshalState serializes the fitted coefficients and the recent values and errors
+// used to seed the next prediction.
+func (m *ARIMAModel) MarshalState() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !m.trained {
+		return nil, ErrNotTrained
+	}
+	return marshalState(m.Name(), arimaState{
+		ARCoeffs:       m.arCoeffs,
+		MACoeffs:       m.maCoeffs,
+		Mean:           m.mean,
+		LastValues:     m.lastValues,
+		LastErrors:     m.lastErrors,
+		ResidualStdDev: m.residualStdDev,
+	})
+}
+
+// UnmarshalState restores state saved by MarshalState and marks the model trained.
+func (m *ARIMAModel) UnmarshalState(data []byte) error {
+	return applyState(m, data)
+}
+
+func (m *ARIMAModel) decodeState(data []byte) (func(), error) {
+	var s arimaState
+	if err := unmarshalState(data, m.Name(), &s); err != nil {
+		return nil, err
+	}
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.trained = true
+		m.arCoeffs = s.ARCoeffs
+		m.maCoeffs = s.MACoeffs
+		m.mean = s.Mean
+		m.lastValues = s.LastValues
+		m.lastErrors = s.LastErrors
+		m.residualStdDev = s.ResidualStdDev
+	}, nil
+}
+
+// MarshalState serializes the fitted seasonal and non-seasonal coefficients and the
+// recent values and errors used to seed the next prediction.
+func (m *SARIMAModel) MarshalState() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !m.trained {
+		return nil, ErrNotTrained
+	}
+	return marshalState(m.Name(), arimaState{
+		ARCoeffs:         m.arCoeffs,
+		MACoeffs:         m.maCoeffs,
+		SeasonalARCoeffs: m.seasonalARCoeffs,
+		SeasonalMACoeffs: m.seasonalMACoeffs,
+		Mean:             m.mean,
+		LastValues:       m.lastValues,
+		LastErrors:       m.lastErrors,
+		ResidualStdDev:   m.residualStdDev,
+	})
+}
+
+// UnmarshalState restores state saved by MarshalState and marks the model trained.
+func (m *SARIMAModel) UnmarshalState(data []byte) error {
+	return applyState(m, data)
+}
+
+func (m *SARIMAModel) decodeState(data []byte) (func(), error) {
+	var s arimaState
+	if err := unmarshalState(data, m.Name(), &s); err != nil {
+		return nil, err
+	}
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.trained = true
+		m.arCoeffs = s.ARCoeffs
+		m.maCoeffs = s.MACoeffs
+		m.seasonalARCoeffs = s.SeasonalARCoeffs
+		m.seasonalMACoeffs = s.SeasonalMACoeffs
+		m.mean = s.Mean
+		m.lastValues = s.LastValues
+		m.lastErrors = s.LastErrors
+		m.residualStdDev = s.ResidualStdDev
+	}, nil
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func trainingFrame(n int) FeatureFrame {
+	rows := make([]map[string]float64, n)
+	for i := range rows {
+		rows[i] = map[string]float64{
+			"value":  100 + float64(i%10)*5,
+			"minute": float64(i % 60),
+			"hour":   float64((i / 60) % 24),
+		}
+	}
+	return FeatureFrame{Rows: rows}
+}
+
+func assertSamePrediction(t *testing.T, a, b Model, features FeatureFrame) {
+	t.Helper()
+	ctx := context.Background()
+
+	fa, err := a.Predict(ctx, features)
+	if err != nil {
+		t.Fatalf("Predict() on trained model error = %v", err)
+	}
+	fb, err := b.Predict(ctx, features)
+	if err != nil {
+		t.Fatalf("Predict() on restored model error = %v", err)
+	}
+
+	if len(fa.Values) != len(fb.Values) {
+		t.Fatalf("len(Values) = %d, want %d", len(fb.Values), len(fa.Values))
+	}
+	for i := range fa.Values {
+		if fa.Values[i] != fb.Values[i] {
+			t.Fatalf("Values[%d] = %v, want %v", i, fb.Values[i], fa.Values[i])
+		}
+	}
+}
+
+func TestPersistable_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func() Model
+	}{
+		{"baseline", func() Model { return NewBaselineModel("rps", 60, 600) }},
+		{"arima", func() Model { return NewARIMAModel("rps", 60, 600, 2, 1, 1) }},
+		{"sarima", func() Model { return NewSARIMAModel("rps", 60, 600, 1, 1, 1, 1, 1, 1, 10) }},
+	}
+
+	frame := trainingFrame(180)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trained := tt.build()
+			if err := trained.Train(context.Background(), frame); err != nil {
+				t.Fatalf("Train() error = %v", err)
+			}
+
+			data, err := trained.(Persistable).MarshalState()
+			if err != nil {
+				t.Fatalf("MarshalState() error = %v", err)
+			}
+
+			restored := tt.build()
+			if err := restored.(Persistable).UnmarshalState(data); err != nil {
+				t.Fatalf("UnmarshalState() error = %v", err)
+			}
+
+			assertSamePrediction(t, trained, restored, frame)
+		})
+	}
+}
+
+func TestPersistable_NotTrained(t *testing.T) {
+	for _, m := range []Persistable{
+		NewBaselineModel("rps", 60, 600),
+		NewARIMAModel("rps", 60, 600, 1, 1, 1),
+		NewSARIMAModel("rps", 60, 600, 1, 1, 1, 0, 0, 0, 0),
+	} {
+		if _, err := m.MarshalState(); !errors.Is(err, ErrNotTrained) {
+			t.Errorf("%T.MarshalState() error = %v, want ErrNotTrained", m, err)
+		}
+	}
+}
+
+func TestPersistable_RejectsMismatchedModel(t *testing.T) {
+	src := NewARIMAModel("rps", 60, 600, 1, 1, 1)
+	if err := src.Train(context.Background(), trainingFrame(60)); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	data, err := src.MarshalState()
+	if err != nil {
+		t.Fatalf("MarshalState() error = %v", err)
+	}
+
+	dst := NewARIMAModel("rps", 60, 600, 2, 1, 1)
+	if err := dst.UnmarshalState(data); err == nil {
+		t.Error("UnmarshalState() accepted state from arima(1,1,1) into arima(2,1,1)")
+	}
+	if _, err := dst.Predict(context.Background(), FeatureFrame{}); err == nil {
+		t.Error("rejected state should leave the model untrained")
+	}
+
+	if err := NewSARIMAModel("rps", 60, 600, 1, 1, 1, 0, 0, 0, 0).UnmarshalState(data); err == nil {
+		t.Error("UnmarshalState() accepted ARIMA state into SARIMA")
+	}
+}
+
+func TestPersistable_RejectsUnknownVersion(t *testing.T) {
+	data, _ := json.Marshal(stateEnvelope{Version: StateVersion + 1, Model: "baseline", State: json.RawMessage(`{}`)})
+
+	if err := NewBaselineModel("rps", 60, 600).UnmarshalState(data); err == nil {
+		t.Error("UnmarshalState() accepted an unsupported version")
+	}
+}
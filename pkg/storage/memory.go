@@ -17,6 +17,7 @@ import (
 type MemoryStore struct {
 	mu            sync.RWMutex
 	snapshots     map[string]Snapshot
+	modelStates   map[string][]byte
 	ttl           time.Duration
 	cleanupTicker *time.Ticker
 	stopCleanup   chan struct{}
@@ -30,7 +31,8 @@ type MemoryStore struct {
 // The store is ready to use immediately with no additional configuration.
 func NewMemoryStore() *MemoryStore {
 	return &MemoryStore{
-		snapshots: make(map[string]Snapshot),
+		snapshots:   make(map[string]Snapshot),
+		modelStates: make(map[string][]byte),
 	}
 }
 
@@ -52,6 +54,7 @@ func NewMemoryStoreWithTTL(ttl, cleanupInterval time.Duration) *MemoryStore {
 
 	store := &MemoryStore{
 		snapshots:     make(map[string]Snapshot),
+		modelStates:   make(map[string][]byte),
 		ttl:           ttl,
 		cleanupTicker: time.NewTicker(cleanupInterval),
 		stopCleanup:   make(chan struct{}),
@@ -190,7 +193,7 @@ func (s *MemoryStore) Len() int {
 	return len(s.snapshots)
 }
 
-// Delete removes a snapshot for a workload.
+// Delete removes a snapshot, and any model state, for a workload.
 // This method is primarily useful for testing and cleanup.
 // Returns true if a snapshot was deleted, false if none existed.
 //
@@ -201,5 +204,45 @@ func (s *MemoryStore) Delete(workload string) bool {
 
 	_, existed := s.snapshots[workload]
 	delete(s.snapshots, workload)
+	delete(s.modelStates, workload)
 	return existed
 }
+
+// PutModelState stores serialized model state for a workload, replacing any existing
+// state. Model state is not subject to the snapshot TTL.
+//
+// This operation is safe for concurrent use.
+func (s *MemoryStore) PutModelState(ctx context.Context, workload string, state []byte) error {
+	if workload == "" {
+		return fmt.Errorf("workload cannot be empty")
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.modelStates[workload] = append([]byte(nil), state...)
+	return nil
+}
+
+// GetModelState retrieves serialized model state for a workload.
+//
+// This operation is safe for concurrent use.
+func (s *MemoryStore) GetModelState(ctx context.Context, workload string) ([]byte, bool, error) {
+	select {
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	default:
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, found := s.modelStates[workload]
+	return state, found, nil
+}
@@ -731,3 +731,38 @@ func BenchmarkMemoryStore_ConcurrentAccess(b *testing.B) {
 		}
 	})
 }
+
+func TestMemoryStore_ModelState(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	if _, found, _ := store.GetModelState(ctx, "api"); found {
+		t.Fatal("GetModelState() found state before put")
+	}
+
+	state := []byte("state-v1")
+	if err := store.PutModelState(ctx, "api", state); err != nil {
+		t.Fatalf("PutModelState() error = %v", err)
+	}
+	state[0] = 'X'
+
+	got, found, err := store.GetModelState(ctx, "api")
+	if err != nil || !found {
+		t.Fatalf("GetModelState() = found %v, err %v; want found", found, err)
+	}
+	if string(got) != "state-v1" {
+		t.Errorf("GetModelState() = %q, want %q (store must copy the input)", got, "state-v1")
+	}
+	if store.Len() != 0 {
+		t.Errorf("Len() = %d, model state should not count as a snapshot", store.Len())
+	}
+
+	store.Delete("api")
+	if _, found, _ := store.GetModelState(ctx, "api"); found {
+		t.Error("Delete() should remove model state")
+	}
+
+	if err := store.PutModelState(ctx, "", state); err == nil {
+		t.Error("PutModelState() with empty workload should fail")
+	}
+}
@@ -12,6 +12,11 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// modelStateTTL is how long persisted model state survives without being refreshed.
+// It is much longer than the snapshot TTL because model state is only useful if it
+// outlives restarts.
+const modelStateTTL = 7 * 24 * time.Hour
+
 // RedisStore implements the Store interface using Redis as a backend.
 // It enables multi-instance forecaster deployments by providing shared
 // storage for forecast snapshots with configurable TTL-based expiration.
@@ -73,11 +78,8 @@ func (r *RedisStore) Put(ctx context.Context, s Snapshot) error {
 		return errors.New("workload name required")
 	}
 
-	for _, c := range s.Workload {
-		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
-			(c >= '0' && c <= '9') || c == '-' || c == '_') {
-			return fmt.Errorf("invalid workload name %q: only alphanumeric, hyphens, and underscores allowed", s.Workload)
-		}
+	if err := validateWorkloadName(s.Workload); err != nil {
+		return err
 	}
 
 	data, err := json.Marshal(s)
@@ -146,6 +148,57 @@ func (r *RedisStore) List(ctx context.Context) ([]string, error) {
 	return workloads, nil
 }
 
+// PutModelState stores serialized model state for a workload.
+// The key format is "kedastral:model:{workload}". State expires after modelStateTTL
+// without an update, so state for deleted workloads does not accumulate.
+func (r *RedisStore) PutModelState(ctx context.Context, workload string, state []byte) error {
+	if workload == "" {
+		return errors.New("workload name required")
+	}
+	if err := validateWorkloadName(workload); err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("kedastral:model:%s", workload)
+
+	if err := r.client.Set(ctx, key, state, modelStateTTL).Err(); err != nil {
+		return fmt.Errorf("failed to store model state in redis: %w", err)
+	}
+
+	return nil
+}
+
+// GetModelState retrieves serialized model state for a workload.
+// Returns found=false if no state is stored.
+func (r *RedisStore) GetModelState(ctx context.Context, workload string) ([]byte, bool, error) {
+	if workload == "" {
+		return nil, false, errors.New("workload name required")
+	}
+
+	key := fmt.Sprintf("kedastral:model:%s", workload)
+
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get model state from redis: %w", err)
+	}
+
+	return data, true, nil
+}
+
+// validateWorkloadName rejects names that could break out of the key prefix.
+func validateWorkloadName(workload string) error {
+	for _, c := range workload {
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+			(c >= '0' && c <= '9') || c == '-' || c == '_') {
+			return fmt.Errorf("invalid workload name %q: only alphanumeric, hyphens, and underscores allowed", workload)
+		}
+	}
+	return nil
+}
+
 // Close closes the Redis client connection.
 // It is safe to call multiple times (idempotent).
 func (r *RedisStore) Close() error {
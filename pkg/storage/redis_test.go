@@ -530,3 +530,40 @@ func TestRedisStore_Close_Idempotent(t *testing.T) {
 		t.Errorf("third Close failed: %v", err)
 	}
 }
+
+func TestRedisStore_ModelState_RoundTrip(t *testing.T) {
+	_, addr := setupRedisContainer(t)
+
+	store, err := NewRedisStore(addr, "", 0, 1*time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+
+	if _, found, err := store.GetModelState(ctx, "api"); err != nil || found {
+		t.Fatalf("GetModelState() before put = found %v, err %v; want not found", found, err)
+	}
+
+	state := []byte(`{"version":1,"model":"baseline","state":{}}`)
+	if err := store.PutModelState(ctx, "api", state); err != nil {
+		t.Fatalf("PutModelState() error = %v", err)
+	}
+
+	got, found, err := store.GetModelState(ctx, "api")
+	if err != nil || !found {
+		t.Fatalf("GetModelState() = found %v, err %v; want found", found, err)
+	}
+	if string(got) != string(state) {
+		t.Errorf("GetModelState() = %s, want %s", got, state)
+	}
+
+	workloads, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(workloads) != 0 {
+		t.Errorf("List() = %v, model state should not be listed as a snapshot", workloads)
+	}
+}
@@ -3,6 +3,8 @@ package storage
 import (
 	"context"
 	"time"
+
+	"github.com/HatiCode/kedastral/pkg/capacity"
 )
 
 type Snapshot struct {
@@ -14,11 +16,60 @@ type Snapshot struct {
 	Values          []float64
 	DesiredReplicas []int
 
+	// IntervalSeconds is how often the forecaster produces a new snapshot of the
+	// workload, so readers know when to expect the next one.
+	IntervalSeconds int `json:"intervalSeconds,omitempty"`
+
 	// Quantiles contains optional quantile predictions for uncertainty estimation.
 	// Keys are quantile levels (e.g., 0.5, 0.75, 0.9, 0.95).
 	// Each value is a slice of predictions matching the length of Values.
 	// If nil or empty, quantile forecasts were not available.
 	Quantiles map[float64][]float64 `json:"quantiles,omitempty"`
+
+	// Model is the name of the model that produced the forecast. With a fallback
+	// chain it names the chain member that was actually used.
+	Model string `json:"model,omitempty"`
+
+	// Signals holds the forecast of every metric of a multi-metric workload, the
+	// primary metric first. Empty for single-metric workloads.
+	Signals []SignalForecast `json:"signals,omitempty"`
+
+	// BindingMetrics names, for each step, the metric that determined
+	// DesiredReplicas. Empty for single-metric workloads.
+	BindingMetrics []string `json:"bindingMetrics,omitempty"`
+
+	// QueueDepth is the queue depth observed when a queue-mode workload was planned,
+	// and Backlog the simulated depth at the end of each step under DesiredReplicas.
+	// Both are empty for throughput-mode workloads.
+	QueueDepth float64   `json:"queueDepth,omitempty"`
+	Backlog    []float64 `json:"backlog,omitempty"`
+
+	// Overrides lists the scheduled overrides active at any step of the horizon.
+	Overrides []ActiveOverride `json:"overrides,omitempty"`
+
+	// Trace records how the planner arrived at each step of DesiredReplicas: the
+	// need and its source, the rounding, and every clamp applied.
+	Trace []capacity.StepTrace `json:"trace,omitempty"`
+}
+
+// ActiveOverride is a scheduled override that bounded DesiredReplicas during part
+// of a snapshot's horizon.
+type ActiveOverride struct {
+	Name            string `json:"name"`
+	MinReplicas     int    `json:"minReplicas,omitempty"`
+	MaxReplicas     int    `json:"maxReplicas,omitempty"`
+	FreezeScaleDown bool   `json:"freezeScaleDown,omitempty"`
+
+	// Steps are the indexes of the steps at which the override was active.
+	Steps []int `json:"steps"`
+}
+
+// SignalForecast is the forecast of one metric of a multi-metric workload.
+type SignalForecast struct {
+	Metric       string    `json:"metric"`
+	Model        string    `json:"model,omitempty"`
+	TargetPerPod float64   `json:"targetPerPod"`
+	Values       []float64 `json:"values"`
 }
 
 type Store interface {
@@ -26,3 +77,12 @@ type Store interface {
 	GetLatest(ctx context.Context, workload string) (Snapshot, bool, error)
 	List(ctx context.Context) ([]string, error)
 }
+
+// ModelStateStore is implemented by stores that can also hold serialized model state
+// (see models.Persistable), so a forecaster can warm-start after a restart. It is
+// optional: callers type-assert a Store to ModelStateStore. MemoryStore and
+// RedisStore implement it; with Redis the state is shared across replicas.
+type ModelStateStore interface {
+	PutModelState(ctx context.Context, workload string, state []byte) error
+	GetModelState(ctx context.Context, workload string) ([]byte, bool, error)
+}